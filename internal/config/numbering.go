@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"gopos/pkg/numbering"
+)
+
+type NumberFormat struct {
+	Pattern string
+	Reset   string
+}
+
+// LoadNumberFormats reads the document number patterns per document type.
+// {OUTLET} is the code of the document's outlet, and every outlet has
+// counters of its own. Defaults give INV/MAIN/20261018/0001 and
+// RET/MAIN/20261018/0001, both restarting every day, and PO/MAIN/202610/0001,
+// GR/MAIN/202610/0001 and TRF/MAIN/202610/0001, restarting every month.
+// It returns an error when a pattern could hand out the same number twice.
+func LoadNumberFormats() (map[string]NumberFormat, error) {
+	formats := map[string]NumberFormat{
+		"invoice": {
+			Pattern: getEnv("INVOICE_NUMBER_PATTERN", "INV/{OUTLET}/{YYYYMMDD}/{SEQ:4}"),
+			Reset:   getEnv("INVOICE_NUMBER_RESET", numbering.ResetDaily),
+		},
+		"refund": {
+			Pattern: getEnv("REFUND_NUMBER_PATTERN", "RET/{OUTLET}/{YYYYMMDD}/{SEQ:4}"),
+			Reset:   getEnv("REFUND_NUMBER_RESET", numbering.ResetDaily),
+		},
+		"purchase_order": {
+			Pattern: getEnv("PURCHASE_ORDER_NUMBER_PATTERN", "PO/{OUTLET}/{YYYYMM}/{SEQ:4}"),
+			Reset:   getEnv("PURCHASE_ORDER_NUMBER_RESET", numbering.ResetMonthly),
+		},
+		"goods_receipt": {
+			Pattern: getEnv("GOODS_RECEIPT_NUMBER_PATTERN", "GR/{OUTLET}/{YYYYMM}/{SEQ:4}"),
+			Reset:   getEnv("GOODS_RECEIPT_NUMBER_RESET", numbering.ResetMonthly),
+		},
+		"stock_transfer": {
+			Pattern: getEnv("STOCK_TRANSFER_NUMBER_PATTERN", "TRF/{OUTLET}/{YYYYMM}/{SEQ:4}"),
+			Reset:   getEnv("STOCK_TRANSFER_NUMBER_RESET", numbering.ResetMonthly),
+		},
+	}
+
+	documents := make([]string, 0, len(formats))
+	for document := range formats {
+		documents = append(documents, document)
+	}
+	sort.Strings(documents)
+
+	for _, document := range documents {
+		format := formats[document]
+		if err := numbering.Validate(format.Pattern, format.Reset); err != nil {
+			return nil, fmt.Errorf("%s number format %q (%s): %w", document, format.Pattern, format.Reset, err)
+		}
+	}
+	return formats, nil
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,19 @@
+package config
+
+import "strconv"
+
+// StorageDir is the directory uploaded files, such as product images, are
+// kept in.
+func StorageDir() string {
+	return getEnv("STORAGE_DIR", "storage")
+}
+
+// MaxImageSize is the largest product image accepted, in bytes. It
+// defaults to 5 MB.
+func MaxImageSize() int64 {
+	size, err := strconv.ParseInt(getEnv("PRODUCT_IMAGE_MAX_SIZE", ""), 10, 64)
+	if err != nil || size <= 0 {
+		return 5 << 20
+	}
+	return size
+}
@@ -49,6 +49,28 @@ func (h *CategoryHandler) FindByID(c *gin.Context) {
 	response.Success(c, "Detail Category successful", category)
 }
 
+// Tree lists the categories as a tree, or with ?root_id= the subtree under
+// one category.
+func (h *CategoryHandler) Tree(c *gin.Context) {
+	var rootID *uint64
+	if value := c.Query("root_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			response.Error(c, errors.New("Invalid Root ID"))
+			return
+		}
+		rootID = &id
+	}
+
+	tree, err := h.categoryUC.Tree(rootID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Category Tree successful", tree)
+}
+
 func (h *CategoryHandler) Create(c *gin.Context) {
 	var category domain.Category
 	if err := c.ShouldBindJSON(&category); err != nil {
@@ -96,6 +118,28 @@ func (h *CategoryHandler) Update(c *gin.Context) {
 	response.Success(c, "Update Category successful", category)
 }
 
+func (h *CategoryHandler) Move(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var req domain.CategoryMoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.New("Invalid JSON body"))
+		return
+	}
+
+	category, err := h.categoryUC.Move(id, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Move Category successful", category)
+}
+
 func (h *CategoryHandler) Delete(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 64)
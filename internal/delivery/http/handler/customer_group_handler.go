@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CustomerGroupHandler struct {
+	customerGroupUC usecase.CustomerGroupUsecase
+}
+
+func NewCustomerGroupHandler(customerGroupUC usecase.CustomerGroupUsecase) *CustomerGroupHandler {
+	return &CustomerGroupHandler{customerGroupUC: customerGroupUC}
+}
+
+func (h *CustomerGroupHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	customerGroups, total, err := h.customerGroupUC.FindPaginated(page, limit)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Customer Group successful", customerGroups, page, limit, total)
+}
+
+func (h *CustomerGroupHandler) FindByID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	customerGroup, err := h.customerGroupUC.FindByID(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Detail Customer Group successful", customerGroup)
+}
+
+func (h *CustomerGroupHandler) Create(c *gin.Context) {
+	var customerGroup domain.CustomerGroup
+	if err := c.ShouldBindJSON(&customerGroup); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &customerGroup); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	if err := h.customerGroupUC.Create(&customerGroup); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Customer Group successful", customerGroup)
+}
+
+func (h *CustomerGroupHandler) Update(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var customerGroup domain.CustomerGroup
+	if err := c.ShouldBindJSON(&customerGroup); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &customerGroup); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+	customerGroup.ID = id
+
+	if err := h.customerGroupUC.Update(&customerGroup); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Customer Group successful", customerGroup)
+}
+
+func (h *CustomerGroupHandler) Delete(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	if err := h.customerGroupUC.Delete(id); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Delete Customer Group successful")
+}
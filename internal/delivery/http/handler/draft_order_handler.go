@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DraftOrderHandler struct {
+	draftOrderUC usecase.DraftOrderUsecase
+}
+
+func NewDraftOrderHandler(draftOrderUC usecase.DraftOrderUsecase) *DraftOrderHandler {
+	return &DraftOrderHandler{draftOrderUC: draftOrderUC}
+}
+
+func (h *DraftOrderHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	orders, total, err := h.draftOrderUC.FindPaginated(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Draft Order successful", orders, page, limit, total)
+}
+
+func (h *DraftOrderHandler) FindByID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	order, err := h.draftOrderUC.FindByID(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Detail Draft Order successful", order)
+}
+
+func (h *DraftOrderHandler) Create(c *gin.Context) {
+	var req domain.CreateDraftOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	req.CashierID = userID
+
+	order, err := h.draftOrderUC.Create(&req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Draft Order successful", order)
+}
+
+func (h *DraftOrderHandler) AddItem(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var req domain.SaleItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	order, err := h.draftOrderUC.AddItem(id, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Add Draft Order Item successful", order)
+}
+
+func (h *DraftOrderHandler) RemoveItem(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	itemID, err := strconv.ParseUint(c.Param("item_id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid Item ID"))
+		return
+	}
+
+	order, err := h.draftOrderUC.RemoveItem(id, itemID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Remove Draft Order Item successful", order)
+}
+
+func (h *DraftOrderHandler) Park(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	order, err := h.draftOrderUC.Park(id, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Park Draft Order successful", order)
+}
+
+func (h *DraftOrderHandler) Resume(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	order, err := h.draftOrderUC.Resume(id, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Resume Draft Order successful", order)
+}
+
+func (h *DraftOrderHandler) Discard(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.draftOrderUC.Discard(id, userID); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Discard Draft Order successful")
+}
+
+func (h *DraftOrderHandler) Checkout(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var req domain.CheckoutDraftOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	req.CashierID = userID
+
+	sale, err := h.draftOrderUC.Checkout(id, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Checkout Draft Order successful", sale)
+}
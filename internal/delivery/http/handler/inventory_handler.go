@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InventoryHandler struct {
+	inventoryUC usecase.InventoryUsecase
+}
+
+func NewInventoryHandler(inventoryUC usecase.InventoryUsecase) *InventoryHandler {
+	return &InventoryHandler{inventoryUC: inventoryUC}
+}
+
+func (h *InventoryHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	movements, total, err := h.inventoryUC.FindPaginated(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Inventory Movement successful", movements, page, limit, total)
+}
+
+func (h *InventoryHandler) StockCard(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	movements, total, err := h.inventoryUC.StockCard(c, id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "Stock Card successful", movements, page, limit, total)
+}
+
+func (h *InventoryHandler) Adjust(c *gin.Context) {
+	var req domain.StockAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	req.UserID = userID
+
+	movement, err := h.inventoryUC.Adjust(&req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Adjust Stock successful", movement)
+}
+
+func (h *InventoryHandler) LowStock(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	items, total, err := h.inventoryUC.LowStock(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "Low Stock Report successful", items, page, limit, total)
+}
+
+func (h *InventoryHandler) FindAlerts(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	alerts, total, err := h.inventoryUC.FindAlerts(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Stock Alert successful", alerts, page, limit, total)
+}
+
+func (h *InventoryHandler) AcknowledgeAlert(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	alert, err := h.inventoryUC.AcknowledgeAlert(id, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Acknowledge Stock Alert successful", alert)
+}
+
+func (h *InventoryHandler) SetStockLevel(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var req domain.StockLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	product, err := h.inventoryUC.SetStockLevel(id, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Stock Level successful", product)
+}
+
+func (h *InventoryHandler) FindLots(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	lots, err := h.inventoryUC.FindLots(c, id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "List Lot successful", lots)
+}
+
+func (h *InventoryHandler) NearExpiry(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	items, total, err := h.inventoryUC.NearExpiry(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "Near Expiry Report successful", items, page, limit, total)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OutletHandler struct {
+	outletUC usecase.OutletUsecase
+}
+
+func NewOutletHandler(outletUC usecase.OutletUsecase) *OutletHandler {
+	return &OutletHandler{outletUC: outletUC}
+}
+
+func (h *OutletHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	outlets, total, err := h.outletUC.FindPaginated(page, limit)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Outlet successful", outlets, page, limit, total)
+}
+
+func (h *OutletHandler) FindByID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	outlet, err := h.outletUC.FindByID(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Detail Outlet successful", outlet)
+}
+
+func (h *OutletHandler) Create(c *gin.Context) {
+	var outlet domain.Outlet
+	if err := c.ShouldBindJSON(&outlet); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &outlet); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	if err := h.outletUC.Create(&outlet); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Outlet successful", outlet)
+}
+
+func (h *OutletHandler) Update(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var outlet domain.Outlet
+	if err := c.ShouldBindJSON(&outlet); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &outlet); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+	outlet.ID = id
+
+	if err := h.outletUC.Update(&outlet); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Outlet successful", outlet)
+}
+
+func (h *OutletHandler) Delete(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	if err := h.outletUC.Delete(id); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Delete Outlet successful")
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentHandler struct {
+	paymentUC usecase.PaymentUsecase
+}
+
+func NewPaymentHandler(paymentUC usecase.PaymentUsecase) *PaymentHandler {
+	return &PaymentHandler{paymentUC: paymentUC}
+}
+
+func (h *PaymentHandler) Summary(c *gin.Context) {
+	totals, err := h.paymentUC.Summary(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Payment Summary successful", totals)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentMethodHandler struct {
+	paymentMethodUC usecase.PaymentMethodUsecase
+}
+
+func NewPaymentMethodHandler(paymentMethodUC usecase.PaymentMethodUsecase) *PaymentMethodHandler {
+	return &PaymentMethodHandler{paymentMethodUC: paymentMethodUC}
+}
+
+func (h *PaymentMethodHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	methods, total, err := h.paymentMethodUC.FindPaginated(page, limit)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Payment Method successful", methods, page, limit, total)
+}
+
+func (h *PaymentMethodHandler) FindByID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	method, err := h.paymentMethodUC.FindByID(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Detail Payment Method successful", method)
+}
+
+func (h *PaymentMethodHandler) Create(c *gin.Context) {
+	var method domain.PaymentMethod
+	if err := c.ShouldBindJSON(&method); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &method); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	if err := h.paymentMethodUC.Create(&method); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Payment Method successful", method)
+}
+
+func (h *PaymentMethodHandler) Update(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var method domain.PaymentMethod
+	if err := c.ShouldBindJSON(&method); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &method); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+	method.ID = id
+
+	if err := h.paymentMethodUC.Update(&method); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Payment Method successful", method)
+}
+
+func (h *PaymentMethodHandler) Delete(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	if err := h.paymentMethodUC.Delete(id); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Delete Payment Method successful")
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PriceListHandler struct {
+	priceListUC usecase.PriceListUsecase
+}
+
+func NewPriceListHandler(priceListUC usecase.PriceListUsecase) *PriceListHandler {
+	return &PriceListHandler{priceListUC: priceListUC}
+}
+
+func (h *PriceListHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	priceLists, total, err := h.priceListUC.FindPaginated(page, limit)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Price List successful", priceLists, page, limit, total)
+}
+
+func (h *PriceListHandler) FindByID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	priceList, err := h.priceListUC.FindByID(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Detail Price List successful", priceList)
+}
+
+func (h *PriceListHandler) Create(c *gin.Context) {
+	var priceList domain.PriceList
+	if err := c.ShouldBindJSON(&priceList); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &priceList); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	if err := h.priceListUC.Create(&priceList); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Price List successful", priceList)
+}
+
+func (h *PriceListHandler) Update(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var priceList domain.PriceList
+	if err := c.ShouldBindJSON(&priceList); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &priceList); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+	priceList.ID = id
+
+	if err := h.priceListUC.Update(&priceList); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Price List successful", priceList)
+}
+
+func (h *PriceListHandler) Delete(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	if err := h.priceListUC.Delete(id); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Delete Price List successful")
+}
+
+func (h *PriceListHandler) SetItems(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var req domain.PriceListItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	priceList, err := h.priceListUC.SetItems(id, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Price List Items successful", priceList)
+}
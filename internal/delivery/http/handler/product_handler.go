@@ -6,6 +6,7 @@ import (
 	"gopos/internal/usecase"
 	"gopos/pkg/response"
 	"gopos/pkg/utils"
+	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -41,7 +42,18 @@ func (h *ProductHandler) FindByID(c *gin.Context) {
 		return
 	}
 
-	product, err := h.productUC.FindByID(id)
+	// Query params: ?outlet_id=1 for the stock of a single outlet
+	var outletID *uint64
+	if outletParam := c.Query("outlet_id"); outletParam != "" {
+		outlet, err := strconv.ParseUint(outletParam, 10, 64)
+		if err != nil {
+			response.Error(c, errors.New("Invalid outlet ID"))
+			return
+		}
+		outletID = &outlet
+	}
+
+	product, err := h.productUC.FindByID(id, outletID)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -61,7 +73,13 @@ func (h *ProductHandler) Create(c *gin.Context) {
 		response.Error(c, err, errData)
 		return
 	}
-	if err := h.productUC.Create(&product); err != nil {
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.productUC.Create(&product, userID); err != nil {
 		response.Error(c, err)
 		return
 	}
@@ -86,6 +104,13 @@ func (h *ProductHandler) Update(c *gin.Context) {
 
 	product.ID = id
 
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	product.UpdatedBy = userID
+
 	updateData, err := h.productUC.Update(&product)
 
 	if err != nil {
@@ -96,6 +121,26 @@ func (h *ProductHandler) Update(c *gin.Context) {
 	response.Success(c, "Update Product successful", updateData)
 }
 
+func (h *ProductHandler) PriceHistory(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	history, total, err := h.productUC.FindPriceHistory(c, id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Price History successful", history, page, limit, total)
+}
+
 func (h *ProductHandler) Delete(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 64)
@@ -104,7 +149,7 @@ func (h *ProductHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	product, err := h.productUC.FindByID(id)
+	product, err := h.productUC.FindByID(id, nil)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -121,3 +166,232 @@ func (h *ProductHandler) Delete(c *gin.Context) {
 
 	response.Success(c, "Delete Product successful")
 }
+
+func (h *ProductHandler) SetVariantAttributes(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var req domain.VariantAttributesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	product, err := h.productUC.SetVariantAttributes(id, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Variant Attributes successful", product)
+}
+
+func (h *ProductHandler) CreateVariant(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var req domain.CreateVariantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	req.UserID = userID
+
+	variant, err := h.productUC.CreateVariant(id, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Product Variant successful", variant)
+}
+
+func (h *ProductHandler) SetUnits(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var req domain.ProductUnitsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	product, err := h.productUC.SetUnits(id, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Product Units successful", product)
+}
+
+func (h *ProductHandler) SetRecipe(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var req domain.RecipeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	product, err := h.productUC.SetRecipe(id, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Product Recipe successful", product)
+}
+
+func (h *ProductHandler) LookupBarcode(c *gin.Context) {
+	lookup, err := h.productUC.LookupBarcode(c.Param("barcode"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Lookup Barcode successful", lookup)
+}
+
+func (h *ProductHandler) AddBarcode(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var req domain.ProductBarcodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	product, err := h.productUC.AddBarcode(id, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Add Product Barcode successful", product)
+}
+
+func (h *ProductHandler) RemoveBarcode(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	barcodeID, err := strconv.ParseUint(c.Param("barcode_id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid Barcode ID"))
+		return
+	}
+
+	product, err := h.productUC.RemoveBarcode(id, barcodeID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Remove Product Barcode successful", product)
+}
+
+// Import takes a multipart form with the CSV or XLSX file in "file" and
+// the fields of domain.ProductImportRequest. A file with errors is answered
+// with the result listing them.
+func (h *ProductHandler) Import(c *gin.Context) {
+	var req domain.ProductImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, errors.New("Invalid Payload"))
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		response.Error(c, errors.New("Import file is required"))
+		return
+	}
+
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	result, err := h.productUC.Import(file, &req, userID)
+	if err != nil {
+		if result != nil {
+			response.Error(c, err, result)
+		} else {
+			response.Error(c, err)
+		}
+		return
+	}
+
+	message := "Import Product successful"
+	if req.DryRun {
+		message = "Check Product Import successful"
+	}
+	response.Success(c, message, result)
+}
+
+func (h *ProductHandler) Export(c *gin.Context) {
+	export, err := h.productUC.Export(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+export.Filename+`"`)
+	c.Data(http.StatusOK, export.ContentType, export.Data)
+}
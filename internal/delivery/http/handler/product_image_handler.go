@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProductImageHandler struct {
+	productImageUC usecase.ProductImageUsecase
+}
+
+func NewProductImageHandler(productImageUC usecase.ProductImageUsecase) *ProductImageHandler {
+	return &ProductImageHandler{productImageUC: productImageUC}
+}
+
+// Upload takes a multipart form with the file in "image" and, optionally,
+// is_primary=true.
+func (h *ProductImageHandler) Upload(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	file, err := c.FormFile("image")
+	if err != nil {
+		response.Error(c, errors.New("Image file is required"))
+		return
+	}
+	isPrimary, _ := strconv.ParseBool(c.PostForm("is_primary"))
+
+	product, err := h.productImageUC.Upload(id, file, isPrimary)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Upload Product Image successful", product)
+}
+
+// Show writes the image file, or its thumbnail with ?thumbnail=true. An
+// image never changes once uploaded, so clients may cache it.
+func (h *ProductImageHandler) Show(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	imageID, err := strconv.ParseUint(c.Param("image_id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid Image ID"))
+		return
+	}
+
+	thumbnail := c.Query("thumbnail") == "true"
+	image, file, err := h.productImageUC.Open(id, imageID, thumbnail)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	defer file.Close()
+
+	contentType, size := image.ContentType, image.Size
+	if thumbnail {
+		contentType, size = "image/jpeg", image.ThumbnailSize
+	}
+
+	c.DataFromReader(http.StatusOK, size, contentType, file, map[string]string{
+		"Cache-Control": "private, max-age=31536000, immutable",
+	})
+}
+
+func (h *ProductImageHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	imageID, err := strconv.ParseUint(c.Param("image_id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid Image ID"))
+		return
+	}
+
+	product, err := h.productImageUC.Delete(id, imageID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Remove Product Image successful", product)
+}
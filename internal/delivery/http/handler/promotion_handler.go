@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromotionHandler struct {
+	promotionUC usecase.PromotionUsecase
+}
+
+func NewPromotionHandler(promotionUC usecase.PromotionUsecase) *PromotionHandler {
+	return &PromotionHandler{promotionUC: promotionUC}
+}
+
+func (h *PromotionHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	promotions, total, err := h.promotionUC.FindPaginated(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Promotion successful", promotions, page, limit, total)
+}
+
+func (h *PromotionHandler) FindByID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	promo, err := h.promotionUC.FindByID(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Detail Promotion successful", promo)
+}
+
+func (h *PromotionHandler) Create(c *gin.Context) {
+	var promo domain.Promotion
+	if err := c.ShouldBindJSON(&promo); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &promo); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	if err := h.promotionUC.Create(&promo); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Promotion successful", promo)
+}
+
+func (h *PromotionHandler) Update(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var promo domain.Promotion
+	if err := c.ShouldBindJSON(&promo); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &promo); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+	promo.ID = id
+
+	if err := h.promotionUC.Update(&promo); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Promotion successful", promo)
+}
+
+func (h *PromotionHandler) Delete(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	if err := h.promotionUC.Delete(id); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Delete Promotion successful")
+}
+
+func (h *PromotionHandler) Evaluate(c *gin.Context) {
+	var req domain.EvaluateCartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	evaluation, err := h.promotionUC.Evaluate(&req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Evaluate Promotion successful", evaluation)
+}
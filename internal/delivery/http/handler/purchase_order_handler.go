@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PurchaseOrderHandler struct {
+	purchaseOrderUC usecase.PurchaseOrderUsecase
+}
+
+func NewPurchaseOrderHandler(purchaseOrderUC usecase.PurchaseOrderUsecase) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{purchaseOrderUC: purchaseOrderUC}
+}
+
+func (h *PurchaseOrderHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	orders, total, err := h.purchaseOrderUC.FindPaginated(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Purchase Order successful", orders, page, limit, total)
+}
+
+func (h *PurchaseOrderHandler) FindByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	order, err := h.purchaseOrderUC.FindByID(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Detail Purchase Order successful", order)
+}
+
+func (h *PurchaseOrderHandler) Create(c *gin.Context) {
+	var req domain.PurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	req.CreatedBy = userID
+
+	order, err := h.purchaseOrderUC.Create(&req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Purchase Order successful", order)
+}
+
+func (h *PurchaseOrderHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var req domain.PurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	order, err := h.purchaseOrderUC.Update(id, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Purchase Order successful", order)
+}
+
+func (h *PurchaseOrderHandler) Send(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	order, err := h.purchaseOrderUC.Send(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Send Purchase Order successful", order)
+}
+
+func (h *PurchaseOrderHandler) Cancel(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	order, err := h.purchaseOrderUC.Cancel(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Cancel Purchase Order successful", order)
+}
+
+func (h *PurchaseOrderHandler) FindReceipts(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	receipts, err := h.purchaseOrderUC.FindReceipts(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "List Goods Receipt successful", receipts)
+}
+
+func (h *PurchaseOrderHandler) Receive(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var req domain.GoodsReceiptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	req.ReceivedBy = userID
+
+	receipt, err := h.purchaseOrderUC.Receive(id, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Goods Receipt successful", receipt)
+}
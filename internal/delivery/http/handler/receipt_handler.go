@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/usecase"
+	"gopos/pkg/receipt"
+	"gopos/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReceiptHandler struct {
+	receiptUC usecase.ReceiptUsecase
+}
+
+func NewReceiptHandler(receiptUC usecase.ReceiptUsecase) *ReceiptHandler {
+	return &ReceiptHandler{receiptUC: receiptUC}
+}
+
+// Show renders the sale receipt: ?format=escpos|text|html (default text).
+func (h *ReceiptHandler) Show(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	format := c.DefaultQuery("format", receipt.FormatText)
+	content, contentType, err := h.receiptUC.Render(id, format)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, content)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SaleHandler struct {
+	saleUC usecase.SaleUsecase
+}
+
+func NewSaleHandler(saleUC usecase.SaleUsecase) *SaleHandler {
+	return &SaleHandler{saleUC: saleUC}
+}
+
+func (h *SaleHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	sales, total, err := h.saleUC.FindPaginated(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Sale successful", sales, page, limit, total)
+}
+
+func (h *SaleHandler) FindByID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	sale, err := h.saleUC.FindByID(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Detail Sale successful", sale)
+}
+
+func (h *SaleHandler) Create(c *gin.Context) {
+	var req domain.CreateSaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	cashierID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	req.CashierID = cashierID
+
+	sale, err := h.saleUC.Create(&req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Sale successful", sale)
+}
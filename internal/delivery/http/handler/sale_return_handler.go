@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SaleReturnHandler struct {
+	saleReturnUC usecase.SaleReturnUsecase
+}
+
+func NewSaleReturnHandler(saleReturnUC usecase.SaleReturnUsecase) *SaleReturnHandler {
+	return &SaleReturnHandler{saleReturnUC: saleReturnUC}
+}
+
+func (h *SaleReturnHandler) FindBySale(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	returns, err := h.saleReturnUC.FindBySaleID(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "List Sale Return successful", returns)
+}
+
+func (h *SaleReturnHandler) Create(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var req domain.CreateSaleReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	cashierID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	req.SaleID = id
+	req.CashierID = cashierID
+
+	saleReturn, err := h.saleReturnUC.Create(&req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Sale Return successful", saleReturn)
+}
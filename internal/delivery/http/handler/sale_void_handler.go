@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SaleVoidHandler struct {
+	saleVoidUC usecase.SaleVoidUsecase
+}
+
+func NewSaleVoidHandler(saleVoidUC usecase.SaleVoidUsecase) *SaleVoidHandler {
+	return &SaleVoidHandler{saleVoidUC: saleVoidUC}
+}
+
+func (h *SaleVoidHandler) FindBySale(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	voids, err := h.saleVoidUC.FindBySaleID(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "List Sale Void successful", voids)
+}
+
+func (h *SaleVoidHandler) Create(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var req domain.VoidSaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	cashierID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	req.SaleID = id
+	req.RequestedBy = cashierID
+
+	saleVoid, err := h.saleVoidUC.Create(&req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Sale Void successful", saleVoid)
+}
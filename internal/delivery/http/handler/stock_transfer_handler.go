@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StockTransferHandler struct {
+	stockTransferUC usecase.StockTransferUsecase
+}
+
+func NewStockTransferHandler(stockTransferUC usecase.StockTransferUsecase) *StockTransferHandler {
+	return &StockTransferHandler{stockTransferUC: stockTransferUC}
+}
+
+func (h *StockTransferHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	transfers, total, err := h.stockTransferUC.FindPaginated(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Stock Transfer successful", transfers, page, limit, total)
+}
+
+func (h *StockTransferHandler) FindByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	transfer, err := h.stockTransferUC.FindByID(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Detail Stock Transfer successful", transfer)
+}
+
+func (h *StockTransferHandler) InTransit(c *gin.Context) {
+	quantities, err := h.stockTransferUC.InTransit(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Stock In Transit successful", quantities)
+}
+
+func (h *StockTransferHandler) Create(c *gin.Context) {
+	var req domain.CreateStockTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	req.CreatedBy = userID
+
+	transfer, err := h.stockTransferUC.Create(&req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Stock Transfer successful", transfer)
+}
+
+func (h *StockTransferHandler) Ship(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	transfer, err := h.stockTransferUC.Ship(id, userID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Ship Stock Transfer successful", transfer)
+}
+
+func (h *StockTransferHandler) Receive(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var req domain.ReceiveStockTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &req); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	userID, err := utils.AuthUserID(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	req.ReceivedBy = userID
+
+	transfer, err := h.stockTransferUC.Receive(id, &req)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Receive Stock Transfer successful", transfer)
+}
+
+func (h *StockTransferHandler) Cancel(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	transfer, err := h.stockTransferUC.Cancel(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Cancel Stock Transfer successful", transfer)
+}
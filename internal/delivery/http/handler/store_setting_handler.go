@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StoreSettingHandler struct {
+	storeSettingUC usecase.StoreSettingUsecase
+}
+
+func NewStoreSettingHandler(storeSettingUC usecase.StoreSettingUsecase) *StoreSettingHandler {
+	return &StoreSettingHandler{storeSettingUC: storeSettingUC}
+}
+
+func (h *StoreSettingHandler) Get(c *gin.Context) {
+	setting, err := h.storeSettingUC.Get()
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Detail Store Setting successful", setting)
+}
+
+func (h *StoreSettingHandler) Update(c *gin.Context) {
+	var setting domain.StoreSetting
+	if err := c.ShouldBindJSON(&setting); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &setting); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	updated, err := h.storeSettingUC.Update(&setting)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Store Setting successful", updated)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SupplierHandler struct {
+	supplierUC usecase.SupplierUsecase
+}
+
+func NewSupplierHandler(supplierUC usecase.SupplierUsecase) *SupplierHandler {
+	return &SupplierHandler{supplierUC: supplierUC}
+}
+
+func (h *SupplierHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	suppliers, total, err := h.supplierUC.FindPaginated(page, limit)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Supplier successful", suppliers, page, limit, total)
+}
+
+func (h *SupplierHandler) FindByID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	supplier, err := h.supplierUC.FindByID(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Detail Supplier successful", supplier)
+}
+
+func (h *SupplierHandler) Create(c *gin.Context) {
+	var supplier domain.Supplier
+	if err := c.ShouldBindJSON(&supplier); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &supplier); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	if err := h.supplierUC.Create(&supplier); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Supplier successful", supplier)
+}
+
+func (h *SupplierHandler) Update(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var supplier domain.Supplier
+	if err := c.ShouldBindJSON(&supplier); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &supplier); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+	supplier.ID = id
+
+	if err := h.supplierUC.Update(&supplier); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Supplier successful", supplier)
+}
+
+func (h *SupplierHandler) Delete(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	if err := h.supplierUC.Delete(id); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Delete Supplier successful")
+}
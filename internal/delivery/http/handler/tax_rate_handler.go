@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaxRateHandler struct {
+	taxRateUC usecase.TaxRateUsecase
+}
+
+func NewTaxRateHandler(taxRateUC usecase.TaxRateUsecase) *TaxRateHandler {
+	return &TaxRateHandler{taxRateUC: taxRateUC}
+}
+
+func (h *TaxRateHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	rates, total, err := h.taxRateUC.FindPaginated(page, limit)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Tax Rate successful", rates, page, limit, total)
+}
+
+func (h *TaxRateHandler) FindByID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	rate, err := h.taxRateUC.FindByID(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Detail Tax Rate successful", rate)
+}
+
+func (h *TaxRateHandler) Create(c *gin.Context) {
+	var rate domain.TaxRate
+	if err := c.ShouldBindJSON(&rate); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &rate); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	if err := h.taxRateUC.Create(&rate); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Tax Rate successful", rate)
+}
+
+func (h *TaxRateHandler) Update(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var rate domain.TaxRate
+	if err := c.ShouldBindJSON(&rate); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &rate); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+	rate.ID = id
+
+	if err := h.taxRateUC.Update(&rate); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Tax Rate successful", rate)
+}
+
+func (h *TaxRateHandler) Delete(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	if err := h.taxRateUC.Delete(id); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Delete Tax Rate successful")
+}
+
+func (h *TaxRateHandler) Summary(c *gin.Context) {
+	totals, err := h.taxRateUC.Summary(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Tax Summary successful", totals)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TerminalHandler struct {
+	terminalUC usecase.TerminalUsecase
+}
+
+func NewTerminalHandler(terminalUC usecase.TerminalUsecase) *TerminalHandler {
+	return &TerminalHandler{terminalUC: terminalUC}
+}
+
+func (h *TerminalHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	terminals, total, err := h.terminalUC.FindPaginated(page, limit)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Terminal successful", terminals, page, limit, total)
+}
+
+func (h *TerminalHandler) FindByID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	terminal, err := h.terminalUC.FindByID(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Detail Terminal successful", terminal)
+}
+
+func (h *TerminalHandler) Create(c *gin.Context) {
+	var terminal domain.Terminal
+	if err := c.ShouldBindJSON(&terminal); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &terminal); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	if err := h.terminalUC.Create(&terminal); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Terminal successful", terminal)
+}
+
+func (h *TerminalHandler) Update(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var terminal domain.Terminal
+	if err := c.ShouldBindJSON(&terminal); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &terminal); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+	terminal.ID = id
+
+	if err := h.terminalUC.Update(&terminal); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Terminal successful", terminal)
+}
+
+func (h *TerminalHandler) Delete(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	if err := h.terminalUC.Delete(id); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Delete Terminal successful")
+}
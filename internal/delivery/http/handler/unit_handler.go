@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	"gopos/internal/usecase"
+	"gopos/pkg/response"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UnitHandler struct {
+	unitUC usecase.UnitUsecase
+}
+
+func NewUnitHandler(unitUC usecase.UnitUsecase) *UnitHandler {
+	return &UnitHandler{unitUC: unitUC}
+}
+
+func (h *UnitHandler) FindAll(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	units, total, err := h.unitUC.FindPaginated(page, limit)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Paginated(c, "List Unit successful", units, page, limit, total)
+}
+
+func (h *UnitHandler) FindByID(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	unit, err := h.unitUC.FindByID(id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Detail Unit successful", unit)
+}
+
+func (h *UnitHandler) Create(c *gin.Context) {
+	var unit domain.Unit
+	if err := c.ShouldBindJSON(&unit); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &unit); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+
+	if err := h.unitUC.Create(&unit); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Create Unit successful", unit)
+}
+
+func (h *UnitHandler) Update(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	var unit domain.Unit
+	if err := c.ShouldBindJSON(&unit); err != nil {
+		var errData []string
+		if validation := utils.HandleValidationError(err, &unit); len(validation) > 0 {
+			errData = validation
+			err = errors.New("Invalid Payload")
+		}
+		response.Error(c, err, errData)
+		return
+	}
+	unit.ID = id
+
+	if err := h.unitUC.Update(&unit); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Update Unit successful", unit)
+}
+
+func (h *UnitHandler) Delete(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 64)
+	if err != nil {
+		response.Error(c, errors.New("Invalid ID"))
+		return
+	}
+
+	if err := h.unitUC.Delete(id); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "Delete Unit successful")
+}
@@ -91,6 +91,28 @@ func (h *UserHandler) Update(c *gin.Context) {
 	response.Success(c, "User updated successfully", updatedUser)
 }
 
+func (h *UserHandler) UpdatePin(c *gin.Context) {
+	idParam := c.Param("id")
+	idUint, err := utils.StrToUint(idParam)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	var req domain.UpdatePinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.userUC.UpdatePin(idUint, req.Pin); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, "User PIN updated successfully")
+}
+
 func (h *UserHandler) Delete(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	DraftOrderStatusOpen      = "open"
+	DraftOrderStatusParked    = "parked"
+	DraftOrderStatusCompleted = "completed"
+	DraftOrderStatusDiscarded = "discarded"
+)
+
+// DraftOrder is an in-progress cart. It never touches stock; stock is only
+// taken when the draft is checked out into a sale.
+type DraftOrder struct {
+	ID        uint64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	CashierID uint             `gorm:"not null;index" json:"cashier_id"`
+	Status    string           `gorm:"size:20;not null;default:open;index" json:"status"`
+	Label     string           `gorm:"size:100" json:"label"`
+	Note      string           `gorm:"type:text" json:"note"`
+	SaleID    *uint64          `json:"sale_id,omitempty"`
+	ParkedAt  *time.Time       `json:"parked_at,omitempty"`
+	Items     []DraftOrderItem `gorm:"foreignKey:DraftOrderID" json:"items,omitempty"`
+	CreatedAt time.Time        `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+type DraftOrderItem struct {
+	ID           uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	DraftOrderID uint64    `gorm:"not null;index" json:"draft_order_id"`
+	ProductID    uint64    `gorm:"not null" json:"product_id"`
+	Product      *Product  `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	UnitID       *uint64   `json:"unit_id,omitempty"`
+	Quantity     int       `gorm:"not null" json:"quantity"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+type CreateDraftOrderRequest struct {
+	Label     string            `json:"label"`
+	Note      string            `json:"note"`
+	Items     []SaleItemRequest `json:"items" binding:"dive"`
+	CashierID uint              `json:"-"`
+}
+
+type CheckoutDraftOrderRequest struct {
+	Payments        []PaymentRequest `json:"payments" binding:"required,min=1,dive"`
+	CustomerGroupID *uint64          `json:"customer_group_id"`
+	Note            string           `json:"note"`
+	CashierID       uint             `json:"-"`
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+)
+
+// Inventory movement types. Quantity on a movement is the signed change in
+// stock, so sales and write-offs are negative.
+const (
+	MovementTypeSale            = "sale"
+	MovementTypeReturn          = "return"
+	MovementTypeVoid            = "void"
+	MovementTypePurchaseReceipt = "purchase_receipt"
+	MovementTypeAdjustment      = "adjustment"
+	MovementTypeTransferIn      = "transfer_in"
+	MovementTypeTransferOut     = "transfer_out"
+	MovementTypeWriteOff        = "write_off"
+)
+
+// InventoryMovement is one line of the stock ledger. Stock is only ever
+// changed together with a movement, so the stock of a product in an outlet
+// always equals the sum of its movements there and BalanceAfter is the
+// running total of that outlet.
+type InventoryMovement struct {
+	ID            uint64                 `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID     uint64                 `gorm:"not null;index" json:"product_id"`
+	OutletID      uint64                 `gorm:"not null;index" json:"outlet_id"`
+	Type          string                 `gorm:"size:30;not null" json:"type"`
+	Quantity      int                    `gorm:"not null" json:"quantity"`
+	BalanceAfter  int                    `gorm:"not null" json:"balance_after"`
+	ReferenceType string                 `gorm:"size:30" json:"reference_type"`
+	ReferenceID   *uint64                `json:"reference_id,omitempty"`
+	ReferenceNo   string                 `gorm:"size:50" json:"reference_no"`
+	Note          string                 `gorm:"type:text" json:"note"`
+	UserID        *uint                  `json:"user_id,omitempty"`
+	CreatedAt     time.Time              `gorm:"autoCreateTime;index" json:"created_at"`
+	Lots          []InventoryMovementLot `gorm:"foreignKey:MovementID" json:"lots,omitempty"`
+	Allocations   []LotAllocation        `gorm:"-" json:"-"`
+}
+
+// StockAdjustmentRequest corrects the stock of a product. For an adjustment
+// Quantity is the signed change; for a write-off it is the number of units
+// written off. LotID picks the lot, expired or not; without it outgoing
+// units are taken first-expired-first-out and incoming ones go to the
+// product's unlotted stock.
+type StockAdjustmentRequest struct {
+	ProductID uint64  `json:"product_id" binding:"required"`
+	OutletID  *uint64 `json:"outlet_id"`
+	LotID     *uint64 `json:"lot_id"`
+	Type      string  `json:"type" binding:"required,oneof=adjustment write_off"`
+	Quantity  int     `json:"quantity" binding:"required"`
+	Note      string  `json:"note" binding:"required"`
+	UserID    uint    `json:"-"`
+}
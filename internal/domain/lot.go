@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+)
+
+// ProductLot is a batch of a product in one outlet. Every unit of stock sits
+// in exactly one lot, so the lots of a product in an outlet always add up to
+// its stock there. Stock that came in without a lot number sits in the lot
+// with an empty LotNo. A lot expires at the end of its ExpiryDate.
+type ProductLot struct {
+	ID         uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID  uint64     `gorm:"not null;uniqueIndex:idx_product_lots_product_outlet_lot" json:"product_id"`
+	Product    *Product   `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	OutletID   uint64     `gorm:"not null;uniqueIndex:idx_product_lots_product_outlet_lot" json:"outlet_id"`
+	Outlet     *Outlet    `gorm:"foreignKey:OutletID" json:"outlet,omitempty"`
+	LotNo      string     `gorm:"size:50;not null;uniqueIndex:idx_product_lots_product_outlet_lot" json:"lot_no"`
+	ExpiryDate *time.Time `gorm:"type:date" json:"expiry_date,omitempty"`
+	Quantity   int        `gorm:"not null;default:0" json:"quantity"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// InventoryMovementLot is the part of a movement that went into or came out
+// of one lot. Quantity is signed like the movement's.
+type InventoryMovementLot struct {
+	ID         uint64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	MovementID uint64      `gorm:"not null;index" json:"movement_id"`
+	LotID      uint64      `gorm:"not null;index" json:"lot_id"`
+	Lot        *ProductLot `gorm:"foreignKey:LotID" json:"lot,omitempty"`
+	Quantity   int         `gorm:"not null" json:"quantity"`
+}
+
+// LotAllocation tells postStock which lots a movement uses instead of
+// leaving it to FEFO. Outgoing movements name the lot by LotID, incoming
+// ones by LotNo and ExpiryDate, since the lot may not exist in the outlet
+// yet. Quantity is always positive.
+type LotAllocation struct {
+	LotID      uint64
+	LotNo      string
+	ExpiryDate *time.Time
+	Quantity   int
+}
+
+// NearExpiryItem is one line of the near-expiry report. DaysLeft is
+// negative for lots that already expired.
+type NearExpiryItem struct {
+	LotID       uint64    `json:"lot_id"`
+	LotNo       string    `json:"lot_no"`
+	ExpiryDate  time.Time `json:"expiry_date"`
+	DaysLeft    int       `json:"days_left"`
+	ProductID   uint64    `json:"product_id"`
+	ProductCode string    `json:"product_code"`
+	ProductName string    `json:"product_name"`
+	OutletID    uint64    `json:"outlet_id"`
+	OutletCode  string    `json:"outlet_code"`
+	Quantity    int       `json:"quantity"`
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	DocTypeInvoice       = "invoice"
+	DocTypeRefund        = "refund"
+	DocTypePurchaseOrder = "purchase_order"
+	DocTypeGoodsReceipt  = "goods_receipt"
+	DocTypeStockTransfer = "stock_transfer"
+)
+
+// NumberSequence is the last number handed out for a document type, outlet
+// and period. The row is locked while a number is taken so numbers are
+// sequential without gaps.
+type NumberSequence struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	DocType    string    `gorm:"size:20;not null;uniqueIndex:uq_number_sequence" json:"doc_type"`
+	OutletCode string    `gorm:"size:20;not null;uniqueIndex:uq_number_sequence" json:"outlet_code"`
+	Period     string    `gorm:"size:10;not null;uniqueIndex:uq_number_sequence" json:"period"`
+	LastNumber int64     `gorm:"not null;default:0" json:"last_number"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	OutletTypeStore     = "store"
+	OutletTypeWarehouse = "warehouse"
+)
+
+// Outlet is a location that holds stock: a store with terminals or a
+// warehouse. Stock posted without an explicit outlet, such as the opening
+// stock of a new product, goes to the default outlet. Sales in an outlet
+// with a price list are priced with it, unless the customer group has one.
+type Outlet struct {
+	ID          uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code        string         `gorm:"unique;not null;size:20" json:"code" binding:"required"`
+	Name        string         `gorm:"not null;size:100" json:"name" binding:"required"`
+	Type        string         `gorm:"size:20;not null;default:store" json:"type" binding:"omitempty,oneof=store warehouse"`
+	Address     string         `gorm:"type:text" json:"address"`
+	Phone       string         `gorm:"size:30" json:"phone"`
+	PriceListID *uint64        `json:"price_list_id,omitempty"`
+	IsDefault   bool           `gorm:"default:false" json:"is_default"`
+	IsActive    bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// Terminal is a till in an outlet. A cashier opens a shift on a terminal and
+// every sale of that shift takes its stock from the terminal's outlet.
+type Terminal struct {
+	ID        uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code      string         `gorm:"unique;not null;size:20" json:"code" binding:"required"`
+	Name      string         `gorm:"not null;size:100" json:"name" binding:"required"`
+	OutletID  uint64         `gorm:"not null;index" json:"outlet_id" binding:"required"`
+	Outlet    *Outlet        `gorm:"foreignKey:OutletID" json:"outlet,omitempty"`
+	IsActive  bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// ProductStock is the stock of a product in one outlet. Product.Stock is
+// the sum over all outlets.
+type ProductStock struct {
+	ID              uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID       uint64    `gorm:"not null;uniqueIndex:idx_product_stocks_product_outlet" json:"product_id"`
+	OutletID        uint64    `gorm:"not null;uniqueIndex:idx_product_stocks_product_outlet" json:"outlet_id"`
+	Outlet          *Outlet   `gorm:"foreignKey:OutletID" json:"outlet,omitempty"`
+	Stock           int       `gorm:"not null;default:0" json:"stock"`
+	MinStock        *int      `json:"min_stock,omitempty"`        // overrides Product.MinStock when set
+	ReorderQuantity *int      `json:"reorder_quantity,omitempty"` // overrides Product.ReorderQuantity when set
+	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
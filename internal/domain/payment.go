@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	PaymentTypeCash         = "cash"
+	PaymentTypeDebitCard    = "debit_card"
+	PaymentTypeEWallet      = "e_wallet"
+	PaymentTypeBankTransfer = "bank_transfer"
+)
+
+type PaymentMethod struct {
+	ID        uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code      string         `gorm:"unique;not null;size:30" json:"code" binding:"required"`
+	Name      string         `gorm:"not null;size:100" json:"name" binding:"required"`
+	Type      string         `gorm:"not null;size:20" json:"type" binding:"required,oneof=cash debit_card e_wallet bank_transfer"`
+	IsActive  bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// Payment is a single tender used to settle a sale. Amount is the part
+// applied to the sale, Tendered is what the customer handed over.
+type Payment struct {
+	ID              uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	SaleID          uint64         `gorm:"not null;index" json:"sale_id"`
+	PaymentMethodID uint64         `gorm:"not null;index" json:"payment_method_id"`
+	PaymentMethod   *PaymentMethod `gorm:"foreignKey:PaymentMethodID" json:"payment_method,omitempty"`
+	Amount          float64        `gorm:"not null" json:"amount"`
+	Tendered        float64        `gorm:"not null" json:"tendered"`
+	Reference       string         `gorm:"size:100" json:"reference"`
+	CreatedAt       time.Time      `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type PaymentRequest struct {
+	PaymentMethodID uint64  `json:"payment_method_id" binding:"required"`
+	Amount          float64 `json:"amount" binding:"required,gt=0"`
+	Reference       string  `json:"reference"`
+}
+
+type PaymentMethodTotal struct {
+	PaymentMethodID uint64  `json:"payment_method_id"`
+	Code            string  `json:"code"`
+	Name            string  `json:"name"`
+	Type            string  `json:"type"`
+	Count           int64   `json:"count"`
+	Total           float64 `json:"total"`
+}
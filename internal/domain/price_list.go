@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PriceList is a named set of selling prices that replaces Product.Price
+// for the customer groups and outlets it is assigned to. A customer group's
+// list wins over the outlet's; products not on the list keep their own
+// price.
+type PriceList struct {
+	ID          uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code        string          `gorm:"unique;not null;size:20" json:"code" binding:"required,max=20"`
+	Name        string          `gorm:"not null;size:100" json:"name" binding:"required,max=100"`
+	Description string          `gorm:"type:text" json:"description"`
+	IsActive    bool            `gorm:"default:true" json:"is_active"`
+	Items       []PriceListItem `gorm:"foreignKey:PriceListID" json:"items,omitempty"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt  `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// PriceListItem is the price of one base unit of a product from
+// MinQuantity base units on a line upwards. A product can have several
+// tiers; a line gets the one with the highest MinQuantity it reaches, and a
+// line below every tier gets the product's own price.
+type PriceListItem struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	PriceListID uint64    `gorm:"not null;uniqueIndex:idx_price_list_items_list_product_quantity" json:"price_list_id"`
+	ProductID   uint64    `gorm:"not null;uniqueIndex:idx_price_list_items_list_product_quantity;index" json:"product_id"`
+	Product     *Product  `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	MinQuantity int       `gorm:"not null;default:1;uniqueIndex:idx_price_list_items_list_product_quantity" json:"min_quantity"`
+	Price       float64   `gorm:"not null" json:"price"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+type PriceListItemRequest struct {
+	ProductID   uint64   `json:"product_id" binding:"required"`
+	MinQuantity int      `json:"min_quantity" binding:"omitempty,gte=1"`
+	Price       *float64 `json:"price" binding:"required,gte=0"`
+}
+
+// PriceListItemsRequest replaces the prices of a price list.
+type PriceListItemsRequest struct {
+	Items []PriceListItemRequest `json:"items" binding:"dive"`
+}
+
+// CustomerGroup sorts customers, such as walk-in and wholesale, for pricing.
+// A sale for the group is priced with its price list.
+type CustomerGroup struct {
+	ID          uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code        string         `gorm:"unique;not null;size:20" json:"code" binding:"required,max=20"`
+	Name        string         `gorm:"not null;size:100" json:"name" binding:"required,max=100"`
+	PriceListID *uint64        `json:"price_list_id,omitempty"`
+	PriceList   *PriceList     `gorm:"foreignKey:PriceListID" json:"price_list,omitempty"`
+	IsActive    bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// ProductPriceHistory records a change of Product.Price. Rows are only
+// ever added: the table answers what a product cost at any past moment.
+type ProductPriceHistory struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID uint64    `gorm:"not null;index" json:"product_id"`
+	OldPrice  *float64  `json:"old_price"`
+	Price     float64   `gorm:"not null" json:"price"`
+	ChangedBy *uint     `json:"changed_by,omitempty"`
+	CreatedAt time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+}
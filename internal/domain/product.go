@@ -6,20 +6,57 @@ import (
 	"gorm.io/gorm"
 )
 
+// Product.Stock is the stock over all outlets, or of OutletID when the
+// product was read for one outlet. On create Stock is the opening stock,
+// booked to OutletID or the default outlet. MinStock and ReorderQuantity
+// apply to every outlet that does not set its own. Goods receipts of a
+// product with TrackExpiry must carry a lot number and expiry date.
+//
+// A product with variants only groups them: it holds no stock and cannot
+// be sold, and each variant is a product of its own with ParentID set and
+// one option per attribute of the parent.
+//
+// Price, CostPrice and every stock figure are per base unit. Units lists
+// the other units the product is bought or sold in.
+//
+// A product created without Barcodes gets an internal EAN-13.
+//
+// A composite product is made from its Recipe when sold: it holds no stock
+// of its own, the sale takes its components out of stock instead, and its
+// CostPrice is the cost of its components, kept up to date as they change.
 type Product struct {
-	ID          uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
-	Code        string         `gorm:"unique;not null;size:50" json:"code" binding:"required"`
-	Name        string         `gorm:"not null;size:100" json:"name" binding:"required"`
-	Description string         `gorm:"type:text" json:"description"`
-	CategoryID  *uint64        `json:"category_id,omitempty"`
-	Category    *Category      `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
-	Price       *float64       `gorm:"not null" json:"price" binding:"required"`
-	CostPrice   float64        `json:"cost_price"`
-	Stock       int            `gorm:"default:0" json:"stock"`
-	IsActive    bool           `gorm:"default:true" json:"is_active"`
-	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID              uint64             `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code            string             `gorm:"unique;not null;size:50" json:"code" binding:"required"`
+	ParentID        *uint64            `gorm:"index" json:"parent_id,omitempty"`
+	HasVariants     bool               `gorm:"not null;default:false" json:"has_variants"`
+	Name            string             `gorm:"not null;size:100" json:"name" binding:"required"`
+	Description     string             `gorm:"type:text" json:"description"`
+	CategoryID      *uint64            `json:"category_id,omitempty"`
+	Category        *Category          `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	TaxRateID       *uint64            `json:"tax_rate_id,omitempty"`
+	TaxRate         *TaxRate           `gorm:"foreignKey:TaxRateID" json:"tax_rate,omitempty"`
+	Price           *float64           `gorm:"not null" json:"price" binding:"required"`
+	CostPrice       float64            `json:"cost_price"`
+	Stock           int                `gorm:"default:0" json:"stock"` // opening stock on create, read-only afterwards
+	OutletID        *uint64            `gorm:"-" json:"outlet_id,omitempty"`
+	MinStock        int                `gorm:"not null;default:0" json:"min_stock" binding:"gte=0"`
+	ReorderQuantity int                `gorm:"not null;default:0" json:"reorder_quantity" binding:"gte=0"`
+	TrackExpiry     bool               `gorm:"not null;default:false" json:"track_expiry"`
+	BaseUnitID      *uint64            `json:"base_unit_id,omitempty"`
+	BaseUnit        *Unit              `gorm:"foreignKey:BaseUnitID" json:"base_unit,omitempty"`
+	Units           []ProductUnit      `gorm:"foreignKey:ProductID" json:"units,omitempty"`
+	Barcodes        []ProductBarcode   `gorm:"foreignKey:ProductID" json:"barcodes,omitempty"`
+	Images          []ProductImage     `gorm:"foreignKey:ProductID" json:"images,omitempty"`
+	IsComposite     bool               `gorm:"not null;default:false" json:"is_composite"`
+	Recipe          []RecipeItem       `gorm:"foreignKey:ProductID" json:"recipe,omitempty"`
+	Stocks          []ProductStock     `gorm:"foreignKey:ProductID" json:"stocks,omitempty"`
+	Attributes      []VariantAttribute `gorm:"foreignKey:ProductID" json:"attributes,omitempty"`
+	Options         []VariantOption    `gorm:"foreignKey:ProductID" json:"options,omitempty"`
+	Variants        []Product          `gorm:"foreignKey:ParentID" json:"variants,omitempty"`
+	IsActive        bool               `gorm:"default:true" json:"is_active"`
+	CreatedAt       time.Time          `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time          `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt       gorm.DeletedAt     `gorm:"index" json:"deleted_at,omitempty"`
 }
 type ProductUpdate struct {
 	ID          uint64    `json:"id"`
@@ -28,15 +65,40 @@ type ProductUpdate struct {
 	Description string    `json:"description"`
 	CategoryID  *uint64   `json:"category_id,omitempty"`
 	Category    *Category `json:"category,omitempty"`
+	TaxRateID   *uint64   `json:"tax_rate_id,omitempty"`
 	Price       *float64  `json:"price"`
 	CostPrice   float64   `json:"cost_price"`
-	Stock       int       `json:"stock"`
 	IsActive    bool      `json:"is_active"`
+	TrackExpiry *bool     `json:"track_expiry"`
+	UpdatedBy   uint      `json:"-"`
 }
 
+// Category sits under its ParentID, or at the top without one, so the
+// catalog reads Beverages > Coffee > Espresso. Breadcrumbs and Path trace
+// the way down from the top category and Children hold the subcategories;
+// they are filled in when asked for, not stored.
 type Category struct {
-	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
-	Name      string    `gorm:"not null;size:100;unique" json:"name" binding:"required"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID          uint64               `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name        string               `gorm:"not null;size:100;unique" json:"name" binding:"required"`
+	ParentID    *uint64              `gorm:"index" json:"parent_id,omitempty"`
+	TaxRateID   *uint64              `json:"tax_rate_id,omitempty"`
+	TaxRate     *TaxRate             `gorm:"foreignKey:TaxRateID" json:"tax_rate,omitempty"`
+	Breadcrumbs []CategoryBreadcrumb `gorm:"-" json:"breadcrumbs,omitempty"`
+	Path        string               `gorm:"-" json:"path,omitempty"`
+	Children    []Category           `gorm:"-" json:"children,omitempty"`
+	CreatedAt   time.Time            `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time            `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// CategoryBreadcrumb is one step of a category's path, the top category
+// first and the category itself last.
+type CategoryBreadcrumb struct {
+	ID   uint64 `json:"id"`
+	Name string `json:"name"`
+}
+
+// CategoryMoveRequest moves a category with its subcategories under
+// ParentID, or to the top without one.
+type CategoryMoveRequest struct {
+	ParentID *uint64 `json:"parent_id"`
 }
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+)
+
+// ProductBarcode is a barcode a product is scanned by. A product can carry
+// several, such as the manufacturer's EAN next to an in-store code. With
+// UnitID the barcode scans the product in that unit, e.g. the EAN printed
+// on the carton. Type is one of the symbologies in pkg/barcode.
+type ProductBarcode struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID uint64    `gorm:"not null;index" json:"product_id"`
+	Product   *Product  `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	UnitID    *uint64   `json:"unit_id,omitempty"`
+	Unit      *Unit     `gorm:"foreignKey:UnitID" json:"unit,omitempty"`
+	Barcode   string    `gorm:"unique;not null;size:48" json:"barcode"`
+	Type      string    `gorm:"size:10;not null" json:"type"`
+	IsPrimary bool      `gorm:"not null;default:false" json:"is_primary"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// ProductBarcodeRequest adds a barcode to a product. Without Barcode an
+// internal EAN-13 is generated; without Type it is detected from the value.
+type ProductBarcodeRequest struct {
+	Barcode   string  `json:"barcode" binding:"max=48"`
+	Type      string  `json:"type" binding:"omitempty,oneof=ean13 ean8 upca code128"`
+	UnitID    *uint64 `json:"unit_id"`
+	IsPrimary bool    `json:"is_primary"`
+}
+
+// BarcodeLookup is what the till needs to ring up a scan: the product, the
+// unit the barcode stands for and the price of one such unit.
+type BarcodeLookup struct {
+	Barcode     string  `json:"barcode"`
+	Type        string  `json:"type"`
+	ProductID   uint64  `json:"product_id"`
+	ProductCode string  `json:"product_code"`
+	ProductName string  `json:"product_name"`
+	CategoryID  *uint64 `json:"category_id,omitempty"`
+	TaxRateID   *uint64 `json:"tax_rate_id,omitempty"`
+	UnitID      *uint64 `json:"unit_id,omitempty"`
+	UnitCode    string  `json:"unit_code"`
+	UnitFactor  int     `json:"unit_factor"`
+	Price       float64 `json:"price"`
+	TrackExpiry bool    `json:"track_expiry"`
+	IsActive    bool    `json:"is_active"`
+}
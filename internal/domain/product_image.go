@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+)
+
+// ProductImage is a picture of a product for the till to show. The file
+// and its JPEG thumbnail live in file storage under Path and ThumbnailPath;
+// clients fetch them from GET /products/:id/images/:image_id, adding
+// ?thumbnail=true for the thumbnail. The primary image is the one shown
+// when there is room for only one.
+type ProductImage struct {
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID     uint64    `gorm:"not null;index" json:"product_id"`
+	Path          string    `gorm:"not null;unique;size:255" json:"-"`
+	ThumbnailPath string    `gorm:"not null;size:255" json:"-"`
+	ContentType   string    `gorm:"not null;size:50" json:"content_type"`
+	Size          int64     `gorm:"not null" json:"size"`
+	ThumbnailSize int64     `gorm:"not null" json:"thumbnail_size"`
+	Width         int       `gorm:"not null" json:"width"`
+	Height        int       `gorm:"not null" json:"height"`
+	IsPrimary     bool      `gorm:"not null;default:false" json:"is_primary"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
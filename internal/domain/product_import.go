@@ -0,0 +1,33 @@
+package domain
+
+// ProductImportRequest goes with the uploaded CSV or XLSX file. With
+// DryRun the file is only checked. Opening stock of new products goes to
+// OutletID, or the default outlet.
+type ProductImportRequest struct {
+	DryRun   bool    `form:"dry_run"`
+	OutletID *uint64 `form:"outlet_id"`
+}
+
+// ProductImportResult reports an import, or what it would do on a dry run.
+// Rows are numbered as in the file, the header being row 1. An import with
+// any errors writes nothing.
+type ProductImportResult struct {
+	DryRun  bool                 `json:"dry_run"`
+	Rows    int                  `json:"rows"`
+	Created int                  `json:"created"`
+	Updated int                  `json:"updated"`
+	Errors  []ProductImportError `json:"errors,omitempty"`
+}
+
+type ProductImportError struct {
+	Row     int    `json:"row"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// ProductExport is an exported product file ready to download.
+type ProductExport struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
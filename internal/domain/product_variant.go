@@ -0,0 +1,41 @@
+package domain
+
+// VariantAttribute is something the variants of a product differ in, such
+// as size, color or flavor.
+type VariantAttribute struct {
+	ID        uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID uint64 `gorm:"not null;index" json:"product_id"`
+	Name      string `gorm:"size:50;not null" json:"name"`
+	Position  int    `gorm:"not null;default:0" json:"position"`
+}
+
+// VariantOption is the value a variant has for one attribute of its parent.
+type VariantOption struct {
+	ID          uint64            `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID   uint64            `gorm:"not null;index" json:"product_id"`
+	AttributeID uint64            `gorm:"not null" json:"attribute_id"`
+	Attribute   *VariantAttribute `gorm:"foreignKey:AttributeID" json:"attribute,omitempty"`
+	Value       string            `gorm:"size:50;not null" json:"value"`
+}
+
+// VariantAttributesRequest sets the attributes of a product, in order.
+type VariantAttributesRequest struct {
+	Attributes []string `json:"attributes" binding:"required,min=1,dive,required,max=50"`
+}
+
+// CreateVariantRequest adds a variant under a product. Options holds one
+// value per attribute of the parent, keyed by attribute name. Name defaults
+// to the parent's name followed by the option values; Stock is the opening
+// stock, booked to OutletID or the default outlet. Without Barcode the
+// variant gets an internal EAN-13.
+type CreateVariantRequest struct {
+	Code      string            `json:"code" binding:"required,max=50"`
+	Barcode   string            `json:"barcode" binding:"max=48"`
+	Name      string            `json:"name" binding:"max=100"`
+	Price     *float64          `json:"price" binding:"required,gte=0"`
+	CostPrice float64           `json:"cost_price" binding:"gte=0"`
+	Stock     int               `json:"stock" binding:"gte=0"`
+	OutletID  *uint64           `json:"outlet_id"`
+	Options   map[string]string `json:"options" binding:"required,min=1"`
+	UserID    uint              `json:"-"`
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Promotion types and scopes, see pkg/promotion for how they are applied.
+const (
+	PromotionTypePercentage = "percentage"
+	PromotionTypeFixed      = "fixed"
+	PromotionTypeBuyXGetY   = "buy_x_get_y"
+	PromotionTypeBundle     = "bundle"
+
+	PromotionScopeItem = "item"
+	PromotionScopeCart = "cart"
+)
+
+// Promotion is a discount rule. Value is a percentage, a fixed amount or the
+// bundle price depending on Type. StartsAt/EndsAt bound the dates it runs,
+// StartTime/EndTime ("15:04") the hours of the day. OutletID nil means every
+// outlet. Without targets it applies to every product.
+type Promotion struct {
+	ID             uint64            `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code           string            `gorm:"unique;not null;size:30" json:"code" binding:"required"`
+	Name           string            `gorm:"not null;size:100" json:"name" binding:"required"`
+	Type           string            `gorm:"not null;size:20" json:"type" binding:"required,oneof=percentage fixed buy_x_get_y bundle"`
+	Scope          string            `gorm:"not null;size:10;default:item" json:"scope" binding:"omitempty,oneof=item cart"`
+	Value          float64           `gorm:"not null;default:0" json:"value" binding:"gte=0"`
+	BuyQuantity    int               `gorm:"not null;default:0" json:"buy_quantity" binding:"gte=0"`
+	GetQuantity    int               `gorm:"not null;default:0" json:"get_quantity" binding:"gte=0"`
+	BundleQuantity int               `gorm:"not null;default:0" json:"bundle_quantity" binding:"gte=0"`
+	MinSpend       float64           `gorm:"not null;default:0" json:"min_spend" binding:"gte=0"`
+	Stackable      bool              `gorm:"not null" json:"stackable"`
+	Priority       int               `gorm:"not null;default:0" json:"priority"`
+	StartsAt       *time.Time        `json:"starts_at,omitempty"`
+	EndsAt         *time.Time        `json:"ends_at,omitempty"`
+	StartTime      string            `gorm:"size:5" json:"start_time" binding:"omitempty,datetime=15:04"`
+	EndTime        string            `gorm:"size:5" json:"end_time" binding:"omitempty,datetime=15:04"`
+	OutletID       *uint64           `json:"outlet_id,omitempty"`
+	IsActive       bool              `gorm:"default:true" json:"is_active"`
+	Targets        []PromotionTarget `gorm:"foreignKey:PromotionID" json:"targets"`
+	CreatedAt      time.Time         `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt      gorm.DeletedAt    `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// PromotionTarget limits a promotion to a product or a whole category.
+type PromotionTarget struct {
+	ID          uint64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	PromotionID uint64  `gorm:"not null;index" json:"promotion_id"`
+	ProductID   *uint64 `json:"product_id,omitempty"`
+	CategoryID  *uint64 `json:"category_id,omitempty"`
+}
+
+// SaleItemPromotion records a promotion applied to a sale line.
+type SaleItemPromotion struct {
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	SaleItemID    uint64    `gorm:"not null;index" json:"sale_item_id"`
+	PromotionID   uint64    `gorm:"not null;index" json:"promotion_id"`
+	PromotionCode string    `gorm:"size:30;not null" json:"promotion_code"`
+	PromotionName string    `gorm:"size:100;not null" json:"promotion_name"`
+	Discount      float64   `gorm:"not null" json:"discount"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// EvaluateCartRequest prices the cart like a sale in OutletID for
+// CustomerGroupID would be.
+type EvaluateCartRequest struct {
+	Items           []SaleItemRequest `json:"items" binding:"required,min=1,dive"`
+	OutletID        *uint64           `json:"outlet_id"`
+	CustomerGroupID *uint64           `json:"customer_group_id"`
+}
+
+// CartEvaluation previews the promotions a cart would get at checkout.
+type CartEvaluation struct {
+	Items    []CartEvaluationItem `json:"items"`
+	Subtotal float64              `json:"subtotal"`
+	Discount float64              `json:"discount"`
+	Total    float64              `json:"total"`
+}
+
+type CartEvaluationItem struct {
+	ProductID  uint64              `json:"product_id"`
+	UnitID     *uint64             `json:"unit_id,omitempty"`
+	Quantity   int                 `json:"quantity"`
+	UnitPrice  float64             `json:"unit_price"`
+	Subtotal   float64             `json:"subtotal"`
+	Discount   float64             `json:"discount"`
+	Promotions []SaleItemPromotion `json:"promotions"`
+}
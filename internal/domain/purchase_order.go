@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	PurchaseOrderStatusDraft             = "draft"
+	PurchaseOrderStatusSent              = "sent"
+	PurchaseOrderStatusPartiallyReceived = "partially_received"
+	PurchaseOrderStatusReceived          = "received"
+	PurchaseOrderStatusCancelled         = "cancelled"
+)
+
+// PurchaseOrder can be edited while it is a draft. Once sent, goods are
+// booked against it through goods receipts until every line is received.
+type PurchaseOrder struct {
+	ID           uint64              `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderNo      string              `gorm:"size:50;unique;not null" json:"order_no"`
+	SupplierID   uint64              `gorm:"not null;index" json:"supplier_id"`
+	Supplier     *Supplier           `gorm:"foreignKey:SupplierID" json:"supplier,omitempty"`
+	OutletID     uint64              `gorm:"not null;index" json:"outlet_id"`
+	Outlet       *Outlet             `gorm:"foreignKey:OutletID" json:"outlet,omitempty"`
+	Status       string              `gorm:"size:20;not null;default:draft" json:"status"`
+	ExpectedDate *time.Time          `json:"expected_date,omitempty"`
+	Note         string              `gorm:"type:text" json:"note"`
+	Total        float64             `gorm:"not null" json:"total"`
+	CreatedBy    uint                `gorm:"not null" json:"created_by"`
+	Items        []PurchaseOrderItem `gorm:"foreignKey:PurchaseOrderID" json:"items,omitempty"`
+	Receipts     []GoodsReceipt      `gorm:"foreignKey:PurchaseOrderID" json:"receipts,omitempty"`
+	CreatedAt    time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// PurchaseOrderItem quantities and UnitCost are in the line's unit; every
+// unit received puts UnitFactor base units in stock.
+type PurchaseOrderItem struct {
+	ID               uint64   `gorm:"primaryKey;autoIncrement" json:"id"`
+	PurchaseOrderID  uint64   `gorm:"not null;index" json:"purchase_order_id"`
+	ProductID        uint64   `gorm:"not null" json:"product_id"`
+	Product          *Product `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	UnitID           *uint64  `json:"unit_id,omitempty"`
+	UnitCode         string   `gorm:"size:20" json:"unit_code,omitempty"`
+	UnitFactor       int      `gorm:"not null;default:1" json:"unit_factor"`
+	Quantity         int      `gorm:"not null" json:"quantity"`
+	ReceivedQuantity int      `gorm:"not null;default:0" json:"received_quantity"`
+	UnitCost         float64  `gorm:"not null" json:"unit_cost"`
+	Subtotal         float64  `gorm:"not null" json:"subtotal"`
+}
+
+// GoodsReceipt books delivered goods into stock. Each line updates the
+// product's cost price to the weighted average of the stock on hand and
+// the received goods.
+type GoodsReceipt struct {
+	ID              uint64             `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReceiptNo       string             `gorm:"size:50;unique;not null" json:"receipt_no"`
+	PurchaseOrderID uint64             `gorm:"not null;index" json:"purchase_order_id"`
+	ReceivedBy      uint               `gorm:"not null" json:"received_by"`
+	Note            string             `gorm:"type:text" json:"note"`
+	Total           float64            `gorm:"not null" json:"total"`
+	Items           []GoodsReceiptItem `gorm:"foreignKey:GoodsReceiptID" json:"items,omitempty"`
+	CreatedAt       time.Time          `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type GoodsReceiptItem struct {
+	ID                  uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	GoodsReceiptID      uint64     `gorm:"not null;index" json:"goods_receipt_id"`
+	PurchaseOrderItemID uint64     `gorm:"not null" json:"purchase_order_item_id"`
+	ProductID           uint64     `gorm:"not null" json:"product_id"`
+	UnitFactor          int        `gorm:"not null;default:1" json:"unit_factor"`
+	Quantity            int        `gorm:"not null" json:"quantity"`
+	UnitCost            float64    `gorm:"not null" json:"unit_cost"`
+	Subtotal            float64    `gorm:"not null" json:"subtotal"`
+	LotNo               string     `gorm:"size:50" json:"lot_no,omitempty"`
+	ExpiryDate          *time.Time `gorm:"type:date" json:"expiry_date,omitempty"`
+}
+
+// PurchaseOrderItemRequest orders Quantity of the product in UnitID, or in
+// its base unit when UnitID is nil, at UnitCost per unit ordered.
+type PurchaseOrderItemRequest struct {
+	ProductID uint64  `json:"product_id" binding:"required"`
+	UnitID    *uint64 `json:"unit_id"`
+	Quantity  int     `json:"quantity" binding:"required,gt=0"`
+	UnitCost  float64 `json:"unit_cost" binding:"gte=0"`
+}
+
+// PurchaseOrderRequest delivers to OutletID, the default outlet when empty.
+type PurchaseOrderRequest struct {
+	SupplierID   uint64                     `json:"supplier_id" binding:"required"`
+	OutletID     *uint64                    `json:"outlet_id"`
+	ExpectedDate *time.Time                 `json:"expected_date"`
+	Note         string                     `json:"note"`
+	Items        []PurchaseOrderItemRequest `json:"items" binding:"required,min=1,dive"`
+	CreatedBy    uint                       `json:"-"`
+}
+
+// GoodsReceiptItemRequest receives Quantity of a purchase order line. A
+// missing UnitCost falls back to the cost on the order.
+type GoodsReceiptItemRequest struct {
+	PurchaseOrderItemID uint64     `json:"purchase_order_item_id" binding:"required"`
+	Quantity            int        `json:"quantity" binding:"required,gt=0"`
+	UnitCost            *float64   `json:"unit_cost" binding:"omitempty,gte=0"`
+	LotNo               string     `json:"lot_no" binding:"max=50"`
+	ExpiryDate          *time.Time `json:"expiry_date"`
+}
+
+type GoodsReceiptRequest struct {
+	Items      []GoodsReceiptItemRequest `json:"items" binding:"required,min=1,dive"`
+	Note       string                    `json:"note"`
+	ReceivedBy uint                      `json:"-"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+)
+
+// RecipeItem is one component of a composite product: Quantity of the
+// component, in UnitID or the component's base unit, goes into one base
+// unit of the composite.
+type RecipeItem struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID   uint64    `gorm:"not null;uniqueIndex:idx_recipe_items_product_component" json:"product_id"`
+	Product     *Product  `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	ComponentID uint64    `gorm:"not null;uniqueIndex:idx_recipe_items_product_component;index" json:"component_id"`
+	Component   *Product  `gorm:"foreignKey:ComponentID" json:"component,omitempty"`
+	UnitID      *uint64   `json:"unit_id,omitempty"`
+	Unit        *Unit     `gorm:"foreignKey:UnitID" json:"unit,omitempty"`
+	Quantity    int       `gorm:"not null" json:"quantity"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type RecipeItemRequest struct {
+	ComponentID uint64  `json:"component_id" binding:"required"`
+	UnitID      *uint64 `json:"unit_id"`
+	Quantity    int     `json:"quantity" binding:"required,gt=0"`
+}
+
+// RecipeRequest replaces the recipe of a product. An empty recipe turns a
+// composite product back into one that holds stock of its own.
+type RecipeRequest struct {
+	Items []RecipeItemRequest `json:"items" binding:"dive"`
+}
+
+// SaleItemComponent is what one unit of a composite sale line took out of
+// the stock of a component, in the component's base units. Returns and
+// voids put back the same, even if the recipe changed since.
+type SaleItemComponent struct {
+	ID          uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+	SaleItemID  uint64 `gorm:"not null;index" json:"sale_item_id"`
+	ProductID   uint64 `gorm:"not null" json:"product_id"`
+	ProductCode string `gorm:"size:50;not null" json:"product_code"`
+	Quantity    int    `gorm:"not null" json:"quantity"`
+}
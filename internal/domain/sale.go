@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	SaleStatusCompleted = "completed"
+)
+
+// Sale amounts: Subtotal is the sum of the lines as priced, DiscountTotal
+// what promotions took off, TaxTotal the tax in the discounted amount
+// (inclusive pricing) or on top of it (exclusive pricing), and Total what
+// the customer pays. Lines are priced with PriceListID when the customer
+// group or the outlet has one.
+type Sale struct {
+	ID               uint64       `gorm:"primaryKey;autoIncrement" json:"id"`
+	InvoiceNo        string       `gorm:"size:50;unique;not null" json:"invoice_no"`
+	CashierID        uint         `gorm:"not null;index" json:"cashier_id"`
+	ShiftID          *uint64      `gorm:"index" json:"shift_id,omitempty"`
+	OutletID         uint64       `gorm:"not null;index" json:"outlet_id"`
+	DraftOrderID     *uint64      `json:"draft_order_id,omitempty"`
+	CustomerGroupID  *uint64      `json:"customer_group_id,omitempty"`
+	PriceListID      *uint64      `json:"price_list_id,omitempty"`
+	Status           string       `gorm:"size:20;not null;default:completed" json:"status"`
+	Subtotal         float64      `gorm:"not null" json:"subtotal"`
+	DiscountTotal    float64      `gorm:"not null;default:0" json:"discount_total"`
+	TaxTotal         float64      `gorm:"not null;default:0" json:"tax_total"`
+	Total            float64      `gorm:"not null" json:"total"`
+	PriceIncludesTax bool         `gorm:"not null" json:"price_includes_tax"`
+	PaidAmount       float64      `gorm:"not null" json:"paid_amount"`
+	ChangeAmount     float64      `gorm:"not null" json:"change_amount"`
+	Note             string       `gorm:"type:text" json:"note"`
+	Items            []SaleItem   `gorm:"foreignKey:SaleID" json:"items,omitempty"`
+	Taxes            []SaleTax    `gorm:"foreignKey:SaleID" json:"taxes,omitempty"`
+	Payments         []Payment    `gorm:"foreignKey:SaleID" json:"payments,omitempty"`
+	Returns          []SaleReturn `gorm:"foreignKey:SaleID" json:"returns,omitempty"`
+	Voids            []SaleVoid   `gorm:"foreignKey:SaleID" json:"voids,omitempty"`
+	CreatedAt        time.Time    `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time    `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// SaleItem keeps a snapshot of the product at the time of sale so later
+// price, cost or tax changes do not rewrite history. Total is what the
+// customer pays for the line: Subtotal less Discount, plus exclusive tax.
+// Quantities and prices are in the line's unit; each unit takes UnitFactor
+// base units out of stock, or for a composite product the Components.
+type SaleItem struct {
+	ID               uint64              `gorm:"primaryKey;autoIncrement" json:"id"`
+	SaleID           uint64              `gorm:"not null;index" json:"sale_id"`
+	ProductID        uint64              `gorm:"not null;index" json:"product_id"`
+	ProductCode      string              `gorm:"size:50;not null" json:"product_code"`
+	ProductName      string              `gorm:"size:100;not null" json:"product_name"`
+	UnitID           *uint64             `json:"unit_id,omitempty"`
+	UnitCode         string              `gorm:"size:20" json:"unit_code,omitempty"`
+	UnitFactor       int                 `gorm:"not null;default:1" json:"unit_factor"`
+	Quantity         int                 `gorm:"not null" json:"quantity"`
+	ReturnedQuantity int                 `gorm:"not null;default:0" json:"returned_quantity"`
+	VoidedQuantity   int                 `gorm:"not null;default:0" json:"voided_quantity"`
+	UnitPrice        float64             `gorm:"not null" json:"unit_price"`
+	CostPrice        float64             `json:"cost_price"`
+	Subtotal         float64             `gorm:"not null" json:"subtotal"`
+	Discount         float64             `gorm:"not null;default:0" json:"discount"`
+	TaxRateID        *uint64             `json:"tax_rate_id,omitempty"`
+	TaxRate          float64             `gorm:"not null;default:0" json:"tax_rate"`
+	TaxAmount        float64             `gorm:"not null;default:0" json:"tax_amount"`
+	Total            float64             `gorm:"not null" json:"total"`
+	Promotions       []SaleItemPromotion `gorm:"foreignKey:SaleItemID" json:"promotions,omitempty"`
+	Components       []SaleItemComponent `gorm:"foreignKey:SaleItemID" json:"components,omitempty"`
+	CreatedAt        time.Time           `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// SaleItemRequest sells Quantity of the product in UnitID, or in its base
+// unit when UnitID is nil.
+type SaleItemRequest struct {
+	ProductID uint64  `json:"product_id" binding:"required"`
+	UnitID    *uint64 `json:"unit_id"`
+	Quantity  int     `json:"quantity" binding:"required,gt=0"`
+}
+
+type CreateSaleRequest struct {
+	Items           []SaleItemRequest `json:"items" binding:"required,min=1,dive"`
+	Payments        []PaymentRequest  `json:"payments" binding:"required,min=1,dive"`
+	CustomerGroupID *uint64           `json:"customer_group_id"`
+	Note            string            `json:"note"`
+	CashierID       uint              `json:"-"`
+	DraftOrderID    *uint64           `json:"-"`
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+)
+
+// SaleReturn refunds part or all of a completed sale. It always points to
+// the original sale so reports can net the refund against it.
+type SaleReturn struct {
+	ID              uint64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReturnNo        string           `gorm:"size:50;unique;not null" json:"return_no"`
+	SaleID          uint64           `gorm:"not null;index" json:"sale_id"`
+	CashierID       uint             `gorm:"not null;index" json:"cashier_id"`
+	ShiftID         *uint64          `gorm:"index" json:"shift_id,omitempty"`
+	OutletID        uint64           `gorm:"not null;index" json:"outlet_id"`
+	PaymentMethodID uint64           `gorm:"not null;index" json:"payment_method_id"`
+	PaymentMethod   *PaymentMethod   `gorm:"foreignKey:PaymentMethodID" json:"payment_method,omitempty"`
+	TotalRefund     float64          `gorm:"not null" json:"total_refund"`
+	Reason          string           `gorm:"type:text" json:"reason"`
+	Items           []SaleReturnItem `gorm:"foreignKey:SaleReturnID" json:"items,omitempty"`
+	CreatedAt       time.Time        `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// SaleReturnItem keeps the share of the line's tax it refunds, so the tax
+// report can take it back off.
+type SaleReturnItem struct {
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	SaleReturnID  uint64    `gorm:"not null;index" json:"sale_return_id"`
+	SaleItemID    uint64    `gorm:"not null;index" json:"sale_item_id"`
+	ProductID     uint64    `gorm:"not null;index" json:"product_id"`
+	Quantity      int       `gorm:"not null" json:"quantity"`
+	UnitFactor    int       `gorm:"not null;default:1" json:"unit_factor"`
+	UnitPrice     float64   `gorm:"not null" json:"unit_price"`
+	Subtotal      float64   `gorm:"not null" json:"subtotal"`
+	TaxRateID     *uint64   `json:"tax_rate_id,omitempty"`
+	TaxableAmount float64   `gorm:"not null;default:0" json:"taxable_amount"`
+	TaxAmount     float64   `gorm:"not null;default:0" json:"tax_amount"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type SaleReturnItemRequest struct {
+	SaleItemID uint64 `json:"sale_item_id" binding:"required"`
+	Quantity   int    `json:"quantity" binding:"required,gt=0"`
+}
+
+type CreateSaleReturnRequest struct {
+	Items           []SaleReturnItemRequest `json:"items" binding:"required,min=1,dive"`
+	PaymentMethodID uint64                  `json:"payment_method_id" binding:"required"`
+	Reason          string                  `json:"reason"`
+	SaleID          uint64                  `json:"-"`
+	CashierID       uint                    `json:"-"`
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	SaleStatusVoided = "voided"
+)
+
+// SaleStatusesBooked are the sales counted in totals. A voided sale still
+// belongs to the shift it was rung up in; the money going back out is booked
+// through its SaleVoid in the shift that performed the void.
+var SaleStatusesBooked = []string{SaleStatusCompleted, SaleStatusVoided}
+
+// Casbin object and action a supervisor needs to approve a void.
+const (
+	VoidPermissionObject = "void"
+	VoidPermissionAction = "approve"
+)
+
+// SaleVoid cancels a whole sale or some of its lines. The voided amount is
+// paid back with PaymentMethodID during the requesting cashier's shift.
+type SaleVoid struct {
+	ID              uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	SaleID          uint64         `gorm:"not null;index" json:"sale_id"`
+	ShiftID         *uint64        `gorm:"index" json:"shift_id,omitempty"`
+	OutletID        uint64         `gorm:"not null" json:"outlet_id"`
+	RequestedBy     uint           `gorm:"not null" json:"requested_by"`
+	ApprovedBy      uint           `gorm:"not null" json:"approved_by"`
+	PaymentMethodID uint64         `gorm:"not null" json:"payment_method_id"`
+	PaymentMethod   *PaymentMethod `gorm:"foreignKey:PaymentMethodID" json:"payment_method,omitempty"`
+	Amount          float64        `gorm:"not null" json:"amount"`
+	Reason          string         `gorm:"type:text;not null" json:"reason"`
+	Items           []SaleVoidItem `gorm:"foreignKey:SaleVoidID" json:"items,omitempty"`
+	CreatedAt       time.Time      `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// SaleVoidItem keeps the share of the line's tax it cancels, like a
+// SaleReturnItem.
+type SaleVoidItem struct {
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	SaleVoidID    uint64    `gorm:"not null;index" json:"sale_void_id"`
+	SaleItemID    uint64    `gorm:"not null;index" json:"sale_item_id"`
+	ProductID     uint64    `gorm:"not null" json:"product_id"`
+	Quantity      int       `gorm:"not null" json:"quantity"`
+	UnitFactor    int       `gorm:"not null;default:1" json:"unit_factor"`
+	Amount        float64   `gorm:"not null" json:"amount"`
+	TaxRateID     *uint64   `json:"tax_rate_id,omitempty"`
+	TaxableAmount float64   `gorm:"not null;default:0" json:"taxable_amount"`
+	TaxAmount     float64   `gorm:"not null;default:0" json:"tax_amount"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// VoidSaleRequest voids the listed lines, or every remaining line when Items
+// is empty. The supervisor approves in the same request with either their
+// password or their PIN.
+type VoidSaleRequest struct {
+	Items              []SaleReturnItemRequest `json:"items" binding:"dive"`
+	PaymentMethodID    uint64                  `json:"payment_method_id"`
+	Reason             string                  `json:"reason" binding:"required"`
+	SupervisorUsername string                  `json:"supervisor_username" binding:"required"`
+	SupervisorPassword string                  `json:"supervisor_password"`
+	SupervisorPin      string                  `json:"supervisor_pin"`
+	SaleID             uint64                  `json:"-"`
+	RequestedBy        uint                    `json:"-"`
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	ShiftStatusOpen   = "open"
+	ShiftStatusClosed = "closed"
+)
+
+const (
+	CashMovementPaidIn  = "paid_in"
+	CashMovementPaidOut = "paid_out"
+)
+
+// Shift is a cashier's drawer session on a terminal. ExpectedCash,
+// CountedCash and Variance are filled in when the shift is closed.
+type Shift struct {
+	ID            uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID        uint           `gorm:"not null;index" json:"user_id"`
+	TerminalID    *uint64        `gorm:"index" json:"terminal_id,omitempty"`
+	OutletID      uint64         `gorm:"not null;index" json:"outlet_id"`
+	Status        string         `gorm:"size:20;not null;default:open" json:"status"`
+	OpeningFloat  float64        `gorm:"not null" json:"opening_float"`
+	ExpectedCash  float64        `json:"expected_cash"`
+	CountedCash   float64        `json:"counted_cash"`
+	Variance      float64        `json:"variance"`
+	Note          string         `gorm:"type:text" json:"note"`
+	OpenedAt      time.Time      `gorm:"not null" json:"opened_at"`
+	ClosedAt      *time.Time     `json:"closed_at,omitempty"`
+	CashMovements []CashMovement `gorm:"foreignKey:ShiftID" json:"cash_movements,omitempty"`
+	CreatedAt     time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// CashMovement is cash put into (paid in) or taken out of (paid out) the
+// drawer outside of a sale, e.g. buying supplies from petty cash.
+type CashMovement struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ShiftID   uint64    `gorm:"not null;index" json:"shift_id"`
+	UserID    uint      `gorm:"not null" json:"user_id"`
+	Type      string    `gorm:"size:20;not null" json:"type"`
+	Amount    float64   `gorm:"not null" json:"amount"`
+	Reason    string    `gorm:"size:255" json:"reason"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// OpenShiftRequest opens a shift on TerminalID. Without a terminal the
+// shift sells from the default outlet.
+type OpenShiftRequest struct {
+	TerminalID   *uint64 `json:"terminal_id"`
+	OpeningFloat float64 `json:"opening_float" binding:"gte=0"`
+	Note         string  `json:"note"`
+	UserID       uint    `json:"-"`
+}
+
+type CloseShiftRequest struct {
+	CountedCash *float64 `json:"counted_cash" binding:"required,gte=0"`
+	Note        string   `json:"note"`
+	UserID      uint     `json:"-"`
+}
+
+type CashMovementRequest struct {
+	Type   string  `json:"type" binding:"required,oneof=paid_in paid_out"`
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+	Reason string  `json:"reason" binding:"required"`
+	UserID uint    `json:"-"`
+}
+
+type ShiftSummary struct {
+	Shift         Shift                `json:"shift"`
+	SalesCount    int64                `json:"sales_count"`
+	SalesTotal    float64              `json:"sales_total"`
+	RefundsCount  int64                `json:"refunds_count"`
+	RefundsTotal  float64              `json:"refunds_total"`
+	CashSales     float64              `json:"cash_sales"`
+	CashRefunds   float64              `json:"cash_refunds"`
+	VoidsCount    int64                `json:"voids_count"`
+	VoidsTotal    float64              `json:"voids_total"`
+	CashVoids     float64              `json:"cash_voids"`
+	PaidIn        float64              `json:"paid_in"`
+	PaidOut       float64              `json:"paid_out"`
+	ExpectedCash  float64              `json:"expected_cash"`
+	PaymentTotals []PaymentMethodTotal `json:"payment_totals"`
+	RefundTotals  []PaymentMethodTotal `json:"refund_totals"`
+	VoidTotals    []PaymentMethodTotal `json:"void_totals"`
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	StockAlertStatusOpen         = "open"
+	StockAlertStatusAcknowledged = "acknowledged"
+	StockAlertStatusResolved     = "resolved"
+)
+
+// StockAlert is raised by the movement that takes the stock of a product in
+// an outlet from above its minimum to at or below it. It resolves itself
+// once a later movement brings the stock back above the minimum.
+type StockAlert struct {
+	ID             uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID      uint64     `gorm:"not null;index" json:"product_id"`
+	Product        *Product   `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	OutletID       uint64     `gorm:"not null;index" json:"outlet_id"`
+	Outlet         *Outlet    `gorm:"foreignKey:OutletID" json:"outlet,omitempty"`
+	MovementID     uint64     `gorm:"not null" json:"movement_id"`
+	Stock          int        `gorm:"not null" json:"stock"`
+	MinStock       int        `gorm:"not null" json:"min_stock"`
+	Status         string     `gorm:"size:20;not null;default:open;index" json:"status"`
+	AcknowledgedBy *uint      `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// LowStockItem is one line of the low-stock report: a product whose stock
+// in an outlet is at or below its minimum.
+type LowStockItem struct {
+	ProductID         uint64 `json:"product_id"`
+	ProductCode       string `json:"product_code"`
+	ProductName       string `json:"product_name"`
+	OutletID          uint64 `json:"outlet_id"`
+	OutletCode        string `json:"outlet_code"`
+	Stock             int    `json:"stock"`
+	MinStock          int    `json:"min_stock"`
+	ReorderQuantity   int    `json:"reorder_quantity"`
+	SuggestedQuantity int    `json:"suggested_quantity"`
+}
+
+// StockLevelRequest sets the minimum stock and reorder quantity of a
+// product. Without OutletID it changes the product defaults and leaves nil
+// fields as they are; with it, it replaces the override of that outlet and
+// a nil field falls back to the product default.
+type StockLevelRequest struct {
+	OutletID        *uint64 `json:"outlet_id"`
+	MinStock        *int    `json:"min_stock" binding:"omitempty,gte=0"`
+	ReorderQuantity *int    `json:"reorder_quantity" binding:"omitempty,gte=0"`
+}
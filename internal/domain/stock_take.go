@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	StockTakeStatusOpen      = "open"
+	StockTakeStatusApproved  = "approved"
+	StockTakeStatusCancelled = "cancelled"
+)
+
+// StockTake is a physical count (stock opname) of one outlet. System
+// quantities and cost prices are snapshotted when it is opened; on approval
+// the difference between counted and current quantity is posted as
+// adjustments, so stock moved while counting is not counted twice.
+type StockTake struct {
+	ID         uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	OutletID   uint64          `gorm:"not null;index" json:"outlet_id"`
+	Outlet     *Outlet         `gorm:"foreignKey:OutletID" json:"outlet,omitempty"`
+	Status     string          `gorm:"size:20;not null;default:open" json:"status"`
+	CategoryID *uint64         `json:"category_id,omitempty"`
+	Note       string          `gorm:"type:text" json:"note"`
+	CreatedBy  uint            `gorm:"not null" json:"created_by"`
+	ApprovedBy *uint           `json:"approved_by,omitempty"`
+	ApprovedAt *time.Time      `json:"approved_at,omitempty"`
+	Items      []StockTakeItem `gorm:"foreignKey:StockTakeID" json:"items,omitempty"`
+	CreatedAt  time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// StockTakeItem is one product of the count. CountedQuantity is the sum of
+// the counters' counts and stays nil until someone counts the product.
+// AdjustedQuantity is what approval posted for it.
+type StockTakeItem struct {
+	ID               uint64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	StockTakeID      uint64           `gorm:"not null;index" json:"stock_take_id"`
+	ProductID        uint64           `gorm:"not null" json:"product_id"`
+	Product          *Product         `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	SystemQuantity   int              `gorm:"not null" json:"system_quantity"`
+	CountedQuantity  *int             `json:"counted_quantity"`
+	AdjustedQuantity *int             `json:"adjusted_quantity"`
+	CostPrice        float64          `gorm:"not null" json:"cost_price"`
+	Counts           []StockTakeCount `gorm:"foreignKey:StockTakeItemID" json:"counts,omitempty"`
+}
+
+// StockTakeCount is what one counter counted for a product. A counter
+// submitting again for the same product replaces their earlier count.
+type StockTakeCount struct {
+	ID              uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	StockTakeItemID uint64    `gorm:"not null;uniqueIndex:idx_stock_take_counts_item_counter" json:"stock_take_item_id"`
+	CounterID       uint      `gorm:"not null;uniqueIndex:idx_stock_take_counts_item_counter" json:"counter_id"`
+	Quantity        int       `gorm:"not null" json:"quantity"`
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+type CreateStockTakeRequest struct {
+	OutletID   *uint64 `json:"outlet_id"`
+	CategoryID *uint64 `json:"category_id"`
+	Note       string  `json:"note"`
+	CreatedBy  uint    `json:"-"`
+}
+
+// StockTakeCountRequest identifies the product by ID or by barcode.
+// Quantity is in base units, or in the unit the scanned barcode is for.
+type StockTakeCountRequest struct {
+	ProductID *uint64 `json:"product_id" binding:"required_without=Barcode"`
+	Barcode   string  `json:"barcode" binding:"required_without=ProductID"`
+	Quantity  *int    `json:"quantity" binding:"required,gte=0"`
+	CounterID uint    `json:"-"`
+}
+
+type StockTakeVariance struct {
+	ProductID       uint64  `json:"product_id"`
+	ProductCode     string  `json:"product_code"`
+	ProductName     string  `json:"product_name"`
+	SystemQuantity  int     `json:"system_quantity"`
+	CountedQuantity *int    `json:"counted_quantity"`
+	Variance        int     `json:"variance"`
+	CostPrice       float64 `json:"cost_price"`
+	VarianceValue   float64 `json:"variance_value"`
+}
+
+// StockTakeVarianceReport lists counted minus system quantity per product,
+// valued at the snapshot cost price. Once approved, the system quantity is
+// the stock at approval and the variance what was posted. Products nobody counted have no
+// variance and are left untouched on approval.
+type StockTakeVarianceReport struct {
+	StockTakeID    uint64              `json:"stock_take_id"`
+	Status         string              `json:"status"`
+	CountedItems   int                 `json:"counted_items"`
+	UncountedItems int                 `json:"uncounted_items"`
+	GainValue      float64             `json:"gain_value"`
+	LossValue      float64             `json:"loss_value"`
+	NetValue       float64             `json:"net_value"`
+	Items          []StockTakeVariance `json:"items"`
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	StockTransferStatusDraft     = "draft"
+	StockTransferStatusInTransit = "in_transit"
+	StockTransferStatusReceived  = "received"
+	StockTransferStatusCancelled = "cancelled"
+)
+
+// StockTransfer moves stock from one outlet to another. Shipping takes the
+// goods out of the source outlet and receiving books what actually arrived
+// into the destination, so in between the goods are in transit and belong
+// to neither outlet.
+type StockTransfer struct {
+	ID           uint64              `gorm:"primaryKey;autoIncrement" json:"id"`
+	TransferNo   string              `gorm:"size:50;unique;not null" json:"transfer_no"`
+	FromOutletID uint64              `gorm:"not null;index" json:"from_outlet_id"`
+	FromOutlet   *Outlet             `gorm:"foreignKey:FromOutletID" json:"from_outlet,omitempty"`
+	ToOutletID   uint64              `gorm:"not null;index" json:"to_outlet_id"`
+	ToOutlet     *Outlet             `gorm:"foreignKey:ToOutletID" json:"to_outlet,omitempty"`
+	Status       string              `gorm:"size:20;not null;default:draft" json:"status"`
+	Note         string              `gorm:"type:text" json:"note"`
+	CreatedBy    uint                `gorm:"not null" json:"created_by"`
+	ShippedBy    *uint               `json:"shipped_by,omitempty"`
+	ShippedAt    *time.Time          `json:"shipped_at,omitempty"`
+	ReceivedBy   *uint               `json:"received_by,omitempty"`
+	ReceivedAt   *time.Time          `json:"received_at,omitempty"`
+	Items        []StockTransferItem `gorm:"foreignKey:StockTransferID" json:"items,omitempty"`
+	CreatedAt    time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// StockTransferItem is one product of the transfer. Discrepancy is the
+// received minus the shipped quantity, negative when goods went missing on
+// the way, and needs a DiscrepancyReason whenever it is not zero.
+type StockTransferItem struct {
+	ID                uint64   `gorm:"primaryKey;autoIncrement" json:"id"`
+	StockTransferID   uint64   `gorm:"not null;index" json:"stock_transfer_id"`
+	ProductID         uint64   `gorm:"not null" json:"product_id"`
+	Product           *Product `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Quantity          int      `gorm:"not null" json:"quantity"`
+	ReceivedQuantity  *int     `json:"received_quantity"`
+	Discrepancy       int      `gorm:"not null;default:0" json:"discrepancy"`
+	DiscrepancyReason string   `gorm:"size:255" json:"discrepancy_reason"`
+}
+
+type StockTransferItemRequest struct {
+	ProductID uint64 `json:"product_id" binding:"required"`
+	Quantity  int    `json:"quantity" binding:"required,gt=0"`
+}
+
+type CreateStockTransferRequest struct {
+	FromOutletID uint64                     `json:"from_outlet_id" binding:"required"`
+	ToOutletID   uint64                     `json:"to_outlet_id" binding:"required"`
+	Note         string                     `json:"note"`
+	Items        []StockTransferItemRequest `json:"items" binding:"required,min=1,dive"`
+	CreatedBy    uint                       `json:"-"`
+}
+
+type ReceiveStockTransferItemRequest struct {
+	StockTransferItemID uint64 `json:"stock_transfer_item_id" binding:"required"`
+	ReceivedQuantity    *int   `json:"received_quantity" binding:"required,gte=0"`
+	Reason              string `json:"reason"`
+}
+
+// ReceiveStockTransferRequest lists the lines that did not arrive as
+// shipped; every other line is received in full.
+type ReceiveStockTransferRequest struct {
+	Items      []ReceiveStockTransferItemRequest `json:"items" binding:"dive"`
+	ReceivedBy uint                              `json:"-"`
+}
+
+// InTransitQuantity is stock shipped by one outlet and not yet received by
+// the other.
+type InTransitQuantity struct {
+	ProductID    uint64 `json:"product_id"`
+	ProductCode  string `json:"product_code"`
+	ProductName  string `json:"product_name"`
+	FromOutletID uint64 `json:"from_outlet_id"`
+	ToOutletID   uint64 `json:"to_outlet_id"`
+	Quantity     int    `json:"quantity"`
+}
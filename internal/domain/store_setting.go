@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+)
+
+// StoreSetting holds store-wide details printed on receipts.
+type StoreSetting struct {
+	ID               uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	StoreName        string    `gorm:"size:100;not null" json:"store_name" binding:"required"`
+	Address          string    `gorm:"size:255" json:"address"`
+	Phone            string    `gorm:"size:30" json:"phone"`
+	ReceiptHeader    string    `gorm:"type:text" json:"receipt_header"`
+	ReceiptFooter    string    `gorm:"type:text" json:"receipt_footer"`
+	PaperWidth       int       `gorm:"not null;default:58" json:"paper_width" binding:"omitempty,oneof=58 80"`
+	PriceIncludesTax bool      `gorm:"not null" json:"price_includes_tax"` // product prices already contain tax
+	CreatedAt        time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Supplier struct {
+	ID          uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code        string         `gorm:"unique;not null;size:30" json:"code" binding:"required"`
+	Name        string         `gorm:"not null;size:100" json:"name" binding:"required"`
+	ContactName string         `gorm:"size:100" json:"contact_name"`
+	Phone       string         `gorm:"size:30" json:"phone"`
+	Email       string         `gorm:"size:100" json:"email" binding:"omitempty,email"`
+	Address     string         `gorm:"type:text" json:"address"`
+	IsActive    bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TaxRate is a tax such as PPN or PB1. Rate is a percentage, 11 for 11%.
+// A rate on the product wins over the one on its category.
+type TaxRate struct {
+	ID        uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code      string         `gorm:"unique;not null;size:30" json:"code" binding:"required"`
+	Name      string         `gorm:"not null;size:100" json:"name" binding:"required"`
+	Rate      float64        `gorm:"not null" json:"rate" binding:"gte=0,lte=100"`
+	IsActive  bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// SaleTax is the per-rate tax breakdown of a sale.
+type SaleTax struct {
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	SaleID        uint64    `gorm:"not null;index" json:"sale_id"`
+	TaxRateID     uint64    `gorm:"not null;index" json:"tax_rate_id"`
+	TaxCode       string    `gorm:"size:30;not null" json:"tax_code"`
+	TaxName       string    `gorm:"size:100;not null" json:"tax_name"`
+	Rate          float64   `gorm:"not null" json:"rate"`
+	TaxableAmount float64   `gorm:"not null" json:"taxable_amount"`
+	TaxAmount     float64   `gorm:"not null" json:"tax_amount"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type TaxTotal struct {
+	TaxRateID     uint64  `json:"tax_rate_id"`
+	Code          string  `json:"code"`
+	Name          string  `json:"name"`
+	Rate          float64 `json:"rate"`
+	Count         int64   `json:"count"`
+	TaxableAmount float64 `json:"taxable_amount"`
+	TaxAmount     float64 `json:"tax_amount"`
+}
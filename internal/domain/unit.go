@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Unit is a unit of measure such as piece, pack or carton.
+type Unit struct {
+	ID        uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code      string         `gorm:"unique;not null;size:20" json:"code" binding:"required,max=20"`
+	Name      string         `gorm:"not null;size:50" json:"name" binding:"required,max=50"`
+	IsActive  bool           `gorm:"default:true" json:"is_active"`
+	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+}
+
+// ProductUnit is a unit a product is bought or sold in besides its base
+// unit. Factor is the number of base units in one of it. Price is the
+// selling price of one unit; without it the unit sells at Factor times the
+// product's price.
+type ProductUnit struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID uint64    `gorm:"not null;uniqueIndex:idx_product_units_product_unit" json:"product_id"`
+	UnitID    uint64    `gorm:"not null;uniqueIndex:idx_product_units_product_unit" json:"unit_id"`
+	Unit      *Unit     `gorm:"foreignKey:UnitID" json:"unit,omitempty"`
+	Factor    int       `gorm:"not null" json:"factor"`
+	Price     *float64  `json:"price,omitempty"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+type ProductUnitRequest struct {
+	UnitID uint64   `json:"unit_id" binding:"required"`
+	Factor int      `json:"factor" binding:"required,gt=1"`
+	Price  *float64 `json:"price" binding:"omitempty,gte=0"`
+}
+
+// ProductUnitsRequest sets the base unit of a product and replaces its
+// other units.
+type ProductUnitsRequest struct {
+	BaseUnitID uint64               `json:"base_unit_id" binding:"required"`
+	Units      []ProductUnitRequest `json:"units" binding:"dive"`
+}
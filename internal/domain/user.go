@@ -12,11 +12,16 @@ type User struct {
 	Name      string         `gorm:"size:100" json:"name"`
 	Email     string         `gorm:"size:100;unique;not null" json:"email"`
 	Password  string         `gorm:"size:255;not null" json:"password"`
+	Pin       string         `gorm:"size:255" json:"-"` // hashed, used for supervisor overrides
 	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
 
+type UpdatePinRequest struct {
+	Pin string `json:"pin" binding:"required,numeric,min=4,max=8"`
+}
+
 // type UserRole struct {
 // 	ID       uint   `gorm:"primaryKey"`
 // 	UserID   uint   `gorm:"column:user_id"`
@@ -31,5 +36,6 @@ type UserRepository interface {
 	FindByID(id uint) (*User, error)
 	Save(user *User) (*User, error)
 	Update(user *User) (*User, error)
+	UpdatePin(id uint, pin string) error
 	Delete(*User) error
 }
@@ -6,17 +6,21 @@ import (
 	appError "gopos/pkg/errors"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CategoryRepository interface {
 	FindPaginated(page, limit int) ([]domain.Category, int64, error)
 	FindAll() ([]domain.Category, error)
 	FindByID(id uint64) (*domain.Category, error)
+	CountChildren(id uint64) (int64, error)
 	Create(category *domain.Category) error
 	Update(category *domain.Category) error
+	Move(id uint64, parentID *uint64) error
 	Delete(category *domain.Category) error
 }
 
+// Categories are deleted for good; the table has no deleted_at.
 type categoryRepository struct {
 	db *gorm.DB
 }
@@ -30,11 +34,11 @@ func (r *categoryRepository) FindPaginated(page, limit int) ([]domain.Category,
 	var total int64
 
 	offset := (page - 1) * limit
-	if err := r.db.Model(&domain.Category{}).Where("deleted_at IS NULL").Count(&total).Error; err != nil {
+	if err := r.db.Model(&domain.Category{}).Count(&total).Error; err != nil {
 		return nil, 0, appError.ParseMySQLError(err)
 	}
 
-	if err := r.db.Where("deleted_at IS NULL").Limit(limit).Offset(offset).Find(&categories).Error; err != nil {
+	if err := r.db.Order("name").Limit(limit).Offset(offset).Find(&categories).Error; err != nil {
 		return nil, 0, appError.ParseMySQLError(err)
 	}
 
@@ -43,13 +47,13 @@ func (r *categoryRepository) FindPaginated(page, limit int) ([]domain.Category,
 
 func (r *categoryRepository) FindAll() ([]domain.Category, error) {
 	var categories []domain.Category
-	err := r.db.Where("deleted_at IS NULL").Find(&categories).Error
+	err := r.db.Order("name").Find(&categories).Error
 	return categories, appError.ParseMySQLError(err)
 }
 
 func (r *categoryRepository) FindByID(id uint64) (*domain.Category, error) {
 	var category domain.Category
-	err := r.db.Where("deleted_at IS NULL").First(&category, id).Error
+	err := r.db.First(&category, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -59,21 +63,60 @@ func (r *categoryRepository) FindByID(id uint64) (*domain.Category, error) {
 	return &category, nil
 }
 
+func (r *categoryRepository) CountChildren(id uint64) (int64, error) {
+	var children int64
+	err := r.db.Model(&domain.Category{}).Where("parent_id = ?", id).Count(&children).Error
+	return children, appError.ParseMySQLError(err)
+}
+
 func (r *categoryRepository) Create(category *domain.Category) error {
 	err := r.db.Create(category).Error
 	if err != nil {
-		return err
+		return appError.ParseMySQLError(err)
 	}
 	return nil
 }
 
 func (r *categoryRepository) Update(category *domain.Category) error {
-	if err := r.db.Model(&domain.User{}).Where("id = ? AND deleted_at IS NULL", category.ID).Updates(category).Error; err != nil {
+	if err := r.db.Model(&domain.Category{}).Where("id = ?", category.ID).
+		Select("name", "tax_rate_id").Updates(category).Error; err != nil {
 		return appError.ParseMySQLError(err)
 	}
 	return nil
 }
 
+// Move puts a category, with its subcategories, under parentID, or at the
+// top when it is nil. Every category row is locked while the new parent's
+// ancestors are walked, so two moves at once cannot close a loop between
+// them.
+func (r *categoryRepository) Move(id uint64, parentID *uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if parentID != nil {
+			var tree []domain.Category
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "parent_id").
+				Find(&tree).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+
+			parents := make(map[uint64]*uint64, len(tree))
+			for _, node := range tree {
+				parents[node.ID] = node.ParentID
+			}
+			for ancestor, steps := parentID, 0; ancestor != nil && steps <= len(tree); ancestor, steps = parents[*ancestor], steps+1 {
+				if *ancestor == id {
+					return appError.ErrCategoryCycle
+				}
+			}
+		}
+
+		if err := tx.Model(&domain.Category{}).Where("id = ?", id).
+			Update("parent_id", parentID).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		return nil
+	})
+}
+
 func (r *categoryRepository) Delete(category *domain.Category) error {
 	err := r.db.Delete(category).Error
 	if err != nil {
@@ -81,3 +124,32 @@ func (r *categoryRepository) Delete(category *domain.Category) error {
 	}
 	return nil
 }
+
+// categoryWithDescendants returns id and the IDs of every category below
+// it. The whole tree is read at once; a catalog has at most a few hundred
+// categories.
+func categoryWithDescendants(db *gorm.DB, id uint64) ([]uint64, error) {
+	var tree []domain.Category
+	if err := db.Model(&domain.Category{}).Select("id", "parent_id").Find(&tree).Error; err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+
+	children := make(map[uint64][]uint64, len(tree))
+	for _, node := range tree {
+		if node.ParentID != nil {
+			children[*node.ParentID] = append(children[*node.ParentID], node.ID)
+		}
+	}
+
+	ids := []uint64{id}
+	seen := map[uint64]bool{id: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids, nil
+}
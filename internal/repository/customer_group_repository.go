@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+type CustomerGroupRepository interface {
+	FindPaginated(page, limit int) ([]domain.CustomerGroup, int64, error)
+	FindByID(id uint64) (*domain.CustomerGroup, error)
+	Create(customerGroup *domain.CustomerGroup) error
+	Update(customerGroup *domain.CustomerGroup) error
+	Delete(customerGroup *domain.CustomerGroup) error
+}
+
+type customerGroupRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomerGroupRepository(db *gorm.DB) CustomerGroupRepository {
+	return &customerGroupRepository{db}
+}
+
+func (r *customerGroupRepository) FindPaginated(page, limit int) ([]domain.CustomerGroup, int64, error) {
+	var customerGroups []domain.CustomerGroup
+	var total int64
+
+	offset := (page - 1) * limit
+	if err := r.db.Model(&domain.CustomerGroup{}).Where("deleted_at IS NULL").Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := r.db.Where("deleted_at IS NULL").Limit(limit).Offset(offset).Find(&customerGroups).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return customerGroups, total, nil
+}
+
+func (r *customerGroupRepository) FindByID(id uint64) (*domain.CustomerGroup, error) {
+	var customerGroup domain.CustomerGroup
+	err := r.db.Preload("PriceList").Where("deleted_at IS NULL").First(&customerGroup, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &customerGroup, nil
+}
+
+func (r *customerGroupRepository) Create(customerGroup *domain.CustomerGroup) error {
+	err := r.db.Create(customerGroup).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *customerGroupRepository) Update(customerGroup *domain.CustomerGroup) error {
+	// Select is used so a customer group can be deactivated (is_active = false)
+	if err := r.db.Model(&domain.CustomerGroup{}).
+		Where("id = ? AND deleted_at IS NULL", customerGroup.ID).
+		Select("code", "name", "price_list_id", "is_active").
+		Updates(customerGroup).Error; err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *customerGroupRepository) Delete(customerGroup *domain.CustomerGroup) error {
+	err := r.db.Delete(customerGroup).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+type DraftOrderRepository interface {
+	FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.DraftOrder, int64, error)
+	FindByID(id uint64) (*domain.DraftOrder, error)
+	Create(order *domain.DraftOrder) error
+	AddItem(item *domain.DraftOrderItem) error
+	RemoveItem(orderID, itemID uint64) error
+	UpdateStatus(order *domain.DraftOrder, from ...string) error
+}
+
+type draftOrderRepository struct {
+	db *gorm.DB
+}
+
+func NewDraftOrderRepository(db *gorm.DB) DraftOrderRepository {
+	return &draftOrderRepository{db}
+}
+
+func (r *draftOrderRepository) FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.DraftOrder, int64, error) {
+	var orders []domain.DraftOrder
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&domain.DraftOrder{})
+
+	for key, value := range filters {
+		switch key {
+		case "status":
+			query = query.Where("status IN ?", value)
+		case "cashier_id":
+			query = query.Where("cashier_id = ?", value)
+		case "label":
+			query = query.Where("label LIKE ?", "%"+value.(string)+"%")
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := query.Preload("Items").Order("updated_at DESC").Limit(limit).Offset(offset).Find(&orders).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return orders, total, nil
+}
+
+func (r *draftOrderRepository) FindByID(id uint64) (*domain.DraftOrder, error) {
+	var order domain.DraftOrder
+	err := r.db.Preload("Items.Product").First(&order, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &order, nil
+}
+
+func (r *draftOrderRepository) Create(order *domain.DraftOrder) error {
+	err := r.db.Create(order).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+// AddItem adds the quantity to an existing line for the same product and
+// unit, or creates a new line when it is not in the cart yet.
+func (r *draftOrderRepository) AddItem(item *domain.DraftOrderItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var existing domain.DraftOrderItem
+		err := tx.Where("draft_order_id = ? AND product_id = ? AND unit_id <=> ?", item.DraftOrderID, item.ProductID, item.UnitID).First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return appError.ParseMySQLError(err)
+		}
+
+		if err == nil {
+			existing.Quantity += item.Quantity
+			if err := tx.Model(&existing).Update("quantity", existing.Quantity).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+			*item = existing
+			return nil
+		}
+
+		if err := tx.Create(item).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		return nil
+	})
+}
+
+func (r *draftOrderRepository) RemoveItem(orderID, itemID uint64) error {
+	result := r.db.Where("id = ? AND draft_order_id = ?", itemID, orderID).Delete(&domain.DraftOrderItem{})
+	if result.Error != nil {
+		return appError.ParseMySQLError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appError.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateStatus moves the draft to order.Status only while it is still in
+// one of the given statuses, so two terminals cannot resume or discard the
+// same draft at once.
+func (r *draftOrderRepository) UpdateStatus(order *domain.DraftOrder, from ...string) error {
+	result := r.db.Model(&domain.DraftOrder{}).
+		Where("id = ? AND status IN ?", order.ID, from).
+		Updates(map[string]interface{}{
+			"status":     order.Status,
+			"cashier_id": order.CashierID,
+			"parked_at":  order.ParkedAt,
+		})
+	if result.Error != nil {
+		return appError.ParseMySQLError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appError.ErrAlreadyProcessed
+	}
+	return nil
+}
@@ -0,0 +1,531 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InventoryRepository interface {
+	FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.InventoryMovement, int64, error)
+	Post(movement *domain.InventoryMovement) error
+	LowStock(page, limit int, filters map[string]interface{}) ([]domain.LowStockItem, int64, error)
+	FindAlerts(page, limit int, filters map[string]interface{}) ([]domain.StockAlert, int64, error)
+	FindAlertByID(id uint64) (*domain.StockAlert, error)
+	AcknowledgeAlert(alert *domain.StockAlert) error
+	FindLots(productID uint64, filters map[string]interface{}) ([]domain.ProductLot, error)
+	FindLotByID(id uint64) (*domain.ProductLot, error)
+	NearExpiry(page, limit int, filters map[string]interface{}) ([]domain.NearExpiryItem, int64, error)
+}
+
+type inventoryRepository struct {
+	db *gorm.DB
+}
+
+func NewInventoryRepository(db *gorm.DB) InventoryRepository {
+	return &inventoryRepository{db}
+}
+
+func (r *inventoryRepository) FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.InventoryMovement, int64, error) {
+	var movements []domain.InventoryMovement
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&domain.InventoryMovement{})
+
+	for key, value := range filters {
+		switch key {
+		case "product_id":
+			query = query.Where("product_id = ?", value)
+		case "outlet_id":
+			query = query.Where("outlet_id = ?", value)
+		case "type":
+			query = query.Where("type = ?", value)
+		case "start_date":
+			query = query.Where("created_at >= ?", value)
+		case "end_date":
+			query = query.Where("created_at < ?", value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := query.Preload("Lots.Lot").Order("id").Limit(limit).Offset(offset).Find(&movements).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return movements, total, nil
+}
+
+// Post applies a single movement in its own transaction.
+func (r *inventoryRepository) Post(movement *domain.InventoryMovement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return postStock(tx, movement)
+	})
+}
+
+// LowStock lists every active product whose stock in an outlet is at or
+// below its minimum there. Outlets without a stock row count as zero stock.
+func (r *inventoryRepository) LowStock(page, limit int, filters map[string]interface{}) ([]domain.LowStockItem, int64, error) {
+	var items []domain.LowStockItem
+	var total int64
+
+	offset := (page - 1) * limit
+
+	minStock := "COALESCE(product_stocks.min_stock, products.min_stock)"
+	stock := "COALESCE(product_stocks.stock, 0)"
+
+	query := r.db.Table("products").
+		Joins("CROSS JOIN outlets").
+		Joins("LEFT JOIN product_stocks ON product_stocks.product_id = products.id AND product_stocks.outlet_id = outlets.id").
+		Where("products.deleted_at IS NULL AND products.is_active = ?", true).
+		Where("outlets.deleted_at IS NULL AND outlets.is_active = ?", true).
+		Where(minStock + " > 0").
+		Where(stock + " <= " + minStock)
+
+	for key, value := range filters {
+		switch key {
+		case "outlet_id":
+			query = query.Where("outlets.id = ?", value)
+		case "category_id":
+			query = query.Where("products.category_id = ?", value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := query.
+		Select("products.id AS product_id, products.code AS product_code, products.name AS product_name, " +
+			"outlets.id AS outlet_id, outlets.code AS outlet_code, " + stock + " AS stock, " + minStock + " AS min_stock, " +
+			"COALESCE(product_stocks.reorder_quantity, products.reorder_quantity) AS reorder_quantity").
+		Order("outlets.id, products.code").Limit(limit).Offset(offset).
+		Scan(&items).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	for i := range items {
+		items[i].SuggestedQuantity = items[i].ReorderQuantity
+		if items[i].SuggestedQuantity <= 0 {
+			items[i].SuggestedQuantity = items[i].MinStock - items[i].Stock
+		}
+	}
+
+	return items, total, nil
+}
+
+func (r *inventoryRepository) FindAlerts(page, limit int, filters map[string]interface{}) ([]domain.StockAlert, int64, error) {
+	var alerts []domain.StockAlert
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&domain.StockAlert{})
+
+	for key, value := range filters {
+		switch key {
+		case "status":
+			query = query.Where("status = ?", value)
+		case "product_id":
+			query = query.Where("product_id = ?", value)
+		case "outlet_id":
+			query = query.Where("outlet_id = ?", value)
+		case "start_date":
+			query = query.Where("created_at >= ?", value)
+		case "end_date":
+			query = query.Where("created_at < ?", value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := query.Preload("Product").Preload("Outlet").
+		Order("id DESC").Limit(limit).Offset(offset).Find(&alerts).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return alerts, total, nil
+}
+
+func (r *inventoryRepository) FindAlertByID(id uint64) (*domain.StockAlert, error) {
+	var alert domain.StockAlert
+	if err := r.db.Preload("Product").Preload("Outlet").First(&alert, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &alert, nil
+}
+
+func (r *inventoryRepository) AcknowledgeAlert(alert *domain.StockAlert) error {
+	now := time.Now()
+	result := r.db.Model(&domain.StockAlert{}).
+		Where("id = ? AND status = ?", alert.ID, domain.StockAlertStatusOpen).
+		Updates(map[string]interface{}{
+			"status":          domain.StockAlertStatusAcknowledged,
+			"acknowledged_by": alert.AcknowledgedBy,
+			"acknowledged_at": now,
+		})
+	if result.Error != nil {
+		return appError.ParseMySQLError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appError.ErrAlreadyProcessed
+	}
+	alert.Status = domain.StockAlertStatusAcknowledged
+	alert.AcknowledgedAt = &now
+	return nil
+}
+
+func (r *inventoryRepository) FindLots(productID uint64, filters map[string]interface{}) ([]domain.ProductLot, error) {
+	var lots []domain.ProductLot
+
+	query := r.db.Preload("Outlet").Where("product_id = ?", productID)
+
+	for key, value := range filters {
+		switch key {
+		case "outlet_id":
+			query = query.Where("outlet_id = ?", value)
+		case "in_stock":
+			query = query.Where("quantity > 0")
+		}
+	}
+
+	if err := query.Order("outlet_id, expiry_date IS NULL, expiry_date, id").Find(&lots).Error; err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+	return lots, nil
+}
+
+func (r *inventoryRepository) FindLotByID(id uint64) (*domain.ProductLot, error) {
+	var lot domain.ProductLot
+	if err := r.db.First(&lot, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &lot, nil
+}
+
+// NearExpiry lists the lots still holding stock that expire before the
+// "before" filter, soonest first. Lots that already expired are included.
+func (r *inventoryRepository) NearExpiry(page, limit int, filters map[string]interface{}) ([]domain.NearExpiryItem, int64, error) {
+	var items []domain.NearExpiryItem
+	var total int64
+
+	offset := (page - 1) * limit
+	today := time.Now().Format("2006-01-02")
+
+	query := r.db.Table("product_lots").
+		Joins("JOIN products ON products.id = product_lots.product_id").
+		Joins("JOIN outlets ON outlets.id = product_lots.outlet_id").
+		Where("product_lots.quantity > 0 AND product_lots.expiry_date IS NOT NULL").
+		Where("products.deleted_at IS NULL")
+
+	for key, value := range filters {
+		switch key {
+		case "before":
+			query = query.Where("product_lots.expiry_date < ?", value)
+		case "outlet_id":
+			query = query.Where("product_lots.outlet_id = ?", value)
+		case "product_id":
+			query = query.Where("product_lots.product_id = ?", value)
+		case "category_id":
+			query = query.Where("products.category_id = ?", value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := query.
+		Select("product_lots.id AS lot_id, product_lots.lot_no, product_lots.expiry_date, "+
+			"DATEDIFF(product_lots.expiry_date, ?) AS days_left, products.id AS product_id, products.code AS product_code, "+
+			"products.name AS product_name, outlets.id AS outlet_id, outlets.code AS outlet_code, product_lots.quantity", today).
+		Order("product_lots.expiry_date, product_lots.id").Limit(limit).Offset(offset).
+		Scan(&items).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return items, total, nil
+}
+
+// postStock is the only place product stock changes. It applies the
+// movement's quantity to the product's stock in the movement's outlet and
+// to its total stock, and records the movement with the resulting outlet
+// balance, inside the caller's transaction. Outgoing movements are
+// conditional so stock can never go below zero in any outlet. The movement
+// is spread over the product's lots, and crossing the minimum stock raises
+// or resolves a stock alert.
+func postStock(tx *gorm.DB, movement *domain.InventoryMovement) error {
+	if movement.OutletID == 0 {
+		return fmt.Errorf("stock movement for product %d has no outlet", movement.ProductID)
+	}
+
+	// Products are always locked before their outlet rows, in that order
+	query := tx.Model(&domain.Product{}).Where("id = ?", movement.ProductID)
+	if movement.Quantity < 0 {
+		query = query.Where("deleted_at IS NULL")
+	}
+
+	result := query.UpdateColumn("stock", gorm.Expr("stock + ?", movement.Quantity))
+	if result.Error != nil {
+		return appError.ParseMySQLError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if movement.Quantity < 0 {
+			return appError.ErrInsufficientStock
+		}
+		return appError.ErrNotFound
+	}
+
+	if movement.Quantity < 0 {
+		result = tx.Model(&domain.ProductStock{}).
+			Where("product_id = ? AND outlet_id = ? AND stock >= ?", movement.ProductID, movement.OutletID, -movement.Quantity).
+			UpdateColumn("stock", gorm.Expr("stock + ?", movement.Quantity))
+		if result.Error != nil {
+			return appError.ParseMySQLError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return appError.ErrInsufficientStock
+		}
+	} else {
+		stock := domain.ProductStock{ProductID: movement.ProductID, OutletID: movement.OutletID, Stock: movement.Quantity}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "product_id"}, {Name: "outlet_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"stock": gorm.Expr("stock + ?", movement.Quantity)}),
+		}).Create(&stock).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+	}
+
+	// The row is locked by the update above until the transaction ends
+	var level struct {
+		Stock    int
+		MinStock int
+	}
+	if err := tx.Table("product_stocks").
+		Select("product_stocks.stock, COALESCE(product_stocks.min_stock, products.min_stock) AS min_stock").
+		Joins("JOIN products ON products.id = product_stocks.product_id").
+		Where("product_stocks.product_id = ? AND product_stocks.outlet_id = ?", movement.ProductID, movement.OutletID).
+		Scan(&level).Error; err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	movement.BalanceAfter = level.Stock
+
+	if err := tx.Create(movement).Error; err != nil {
+		return appError.ParseMySQLError(err)
+	}
+
+	if err := postLots(tx, movement); err != nil {
+		return err
+	}
+
+	return checkStockLevel(tx, movement, level.Stock-movement.Quantity, level.MinStock)
+}
+
+// postLots spreads a posted movement over the product's lots in its outlet
+// and records how much went into or out of each. Outgoing units come from
+// the allocated lots first, then first-expired-first-out. Sales and
+// transfers never take expired lots; write-offs, adjustments and stock takes
+// do. Incoming units go to the allocated lots and the rest to the unlotted
+// stock.
+func postLots(tx *gorm.DB, movement *domain.InventoryMovement) error {
+	var parts []domain.InventoryMovementLot
+
+	if movement.Quantity < 0 {
+		remaining := -movement.Quantity
+		for _, allocation := range movement.Allocations {
+			result := tx.Model(&domain.ProductLot{}).
+				Where("id = ? AND product_id = ? AND outlet_id = ? AND quantity >= ?", allocation.LotID, movement.ProductID, movement.OutletID, allocation.Quantity).
+				UpdateColumn("quantity", gorm.Expr("quantity - ?", allocation.Quantity))
+			if result.Error != nil {
+				return appError.ParseMySQLError(result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return appError.ErrInsufficientStock
+			}
+			parts = append(parts, domain.InventoryMovementLot{LotID: allocation.LotID, Quantity: -allocation.Quantity})
+			remaining -= allocation.Quantity
+		}
+
+		if remaining > 0 {
+			query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("product_id = ? AND outlet_id = ? AND quantity > 0", movement.ProductID, movement.OutletID)
+			if movement.Type == domain.MovementTypeSale || movement.Type == domain.MovementTypeTransferOut {
+				query = query.Where("expiry_date IS NULL OR expiry_date >= ?", time.Now().Format("2006-01-02"))
+			}
+
+			var lots []domain.ProductLot
+			if err := query.Order("expiry_date IS NULL, expiry_date, id").Find(&lots).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+
+			for _, lot := range lots {
+				take := lot.Quantity
+				if take > remaining {
+					take = remaining
+				}
+				if err := tx.Model(&domain.ProductLot{}).Where("id = ?", lot.ID).
+					UpdateColumn("quantity", gorm.Expr("quantity - ?", take)).Error; err != nil {
+					return appError.ParseMySQLError(err)
+				}
+				parts = append(parts, domain.InventoryMovementLot{LotID: lot.ID, Quantity: -take})
+				remaining -= take
+				if remaining == 0 {
+					break
+				}
+			}
+
+			// The outlet had the stock, so whatever the open lots lack has expired
+			if remaining > 0 {
+				return appError.ErrStockExpired
+			}
+		}
+	} else if movement.Quantity > 0 {
+		remaining := movement.Quantity
+		allocations := movement.Allocations
+		for _, allocation := range allocations {
+			remaining -= allocation.Quantity
+		}
+		if remaining > 0 {
+			allocations = append(allocations, domain.LotAllocation{Quantity: remaining})
+		}
+
+		for _, allocation := range allocations {
+			lotID, err := receiveLot(tx, movement, allocation)
+			if err != nil {
+				return err
+			}
+			parts = append(parts, domain.InventoryMovementLot{LotID: lotID, Quantity: allocation.Quantity})
+		}
+	}
+
+	if len(parts) == 0 {
+		return nil
+	}
+	for i := range parts {
+		parts[i].MovementID = movement.ID
+	}
+	if err := tx.Create(&parts).Error; err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	movement.Lots = parts
+	return nil
+}
+
+// reversedLots returns the lots the outgoing movements of a document took
+// the product from, as allocations that put up to quantity units back into
+// lots with the same number and expiry. Lots taken last come back first.
+func reversedLots(tx *gorm.DB, referenceType string, referenceID, productID uint64, quantity int) ([]domain.LotAllocation, error) {
+	var taken []struct {
+		LotNo      string
+		ExpiryDate *time.Time
+		Quantity   int
+	}
+	if err := tx.Table("inventory_movement_lots").
+		Select("product_lots.lot_no, product_lots.expiry_date, -inventory_movement_lots.quantity AS quantity").
+		Joins("JOIN inventory_movements ON inventory_movements.id = inventory_movement_lots.movement_id").
+		Joins("JOIN product_lots ON product_lots.id = inventory_movement_lots.lot_id").
+		Where("inventory_movements.reference_type = ? AND inventory_movements.reference_id = ? AND inventory_movements.product_id = ?", referenceType, referenceID, productID).
+		Where("inventory_movement_lots.quantity < 0").
+		Order("inventory_movement_lots.id DESC").
+		Scan(&taken).Error; err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+
+	var allocations []domain.LotAllocation
+	for _, lot := range taken {
+		if quantity == 0 {
+			break
+		}
+		if lot.Quantity > quantity {
+			lot.Quantity = quantity
+		}
+		allocations = append(allocations, domain.LotAllocation{LotNo: lot.LotNo, ExpiryDate: lot.ExpiryDate, Quantity: lot.Quantity})
+		quantity -= lot.Quantity
+	}
+	return allocations, nil
+}
+
+// receiveLot adds the allocation to its lot in the movement's outlet,
+// creating the lot the first time. A lot keeps the expiry date it was
+// first received with.
+func receiveLot(tx *gorm.DB, movement *domain.InventoryMovement, allocation domain.LotAllocation) (uint64, error) {
+	lot := domain.ProductLot{
+		ProductID:  movement.ProductID,
+		OutletID:   movement.OutletID,
+		LotNo:      allocation.LotNo,
+		ExpiryDate: allocation.ExpiryDate,
+		Quantity:   allocation.Quantity,
+	}
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}, {Name: "outlet_id"}, {Name: "lot_no"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"quantity": gorm.Expr("quantity + ?", allocation.Quantity)}),
+	}).Create(&lot).Error; err != nil {
+		return 0, appError.ParseMySQLError(err)
+	}
+
+	var ids []uint64
+	if err := tx.Model(&domain.ProductLot{}).
+		Where("product_id = ? AND outlet_id = ? AND lot_no = ?", movement.ProductID, movement.OutletID, allocation.LotNo).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, appError.ParseMySQLError(err)
+	}
+	if len(ids) == 0 {
+		return 0, appError.ErrNotFound
+	}
+	return ids[0], nil
+}
+
+// checkStockLevel raises an alert when the movement took the stock from
+// above the minimum to at or below it, and resolves the alerts of the
+// product in the outlet when it brought the stock back above it.
+func checkStockLevel(tx *gorm.DB, movement *domain.InventoryMovement, before, minStock int) error {
+	after := movement.BalanceAfter
+	if minStock <= 0 {
+		return nil
+	}
+
+	if before > minStock && after <= minStock {
+		alert := domain.StockAlert{
+			ProductID:  movement.ProductID,
+			OutletID:   movement.OutletID,
+			MovementID: movement.ID,
+			Stock:      after,
+			MinStock:   minStock,
+			Status:     domain.StockAlertStatusOpen,
+		}
+		if err := tx.Create(&alert).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		return nil
+	}
+
+	if before <= minStock && after > minStock {
+		if err := tx.Model(&domain.StockAlert{}).
+			Where("product_id = ? AND outlet_id = ? AND status IN ?", movement.ProductID, movement.OutletID,
+				[]string{domain.StockAlertStatusOpen, domain.StockAlertStatusAcknowledged}).
+			Updates(map[string]interface{}{
+				"status":      domain.StockAlertStatusResolved,
+				"resolved_at": time.Now(),
+			}).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"fmt"
+	"gopos/internal/config"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+	"gopos/pkg/numbering"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NumberSequenceRepository interface {
+	Next(tx *gorm.DB, docType string, outletID uint64, at time.Time) (string, error)
+}
+
+type numberSequenceRepository struct {
+	formats map[string]config.NumberFormat
+}
+
+func NewNumberSequenceRepository(formats map[string]config.NumberFormat) NumberSequenceRepository {
+	return &numberSequenceRepository{formats: formats}
+}
+
+// Next allocates the next document number of the outlet inside the
+// caller's transaction. Every outlet counts on its own and puts its code in
+// the number. The sequence row stays locked until that transaction ends,
+// and a rollback also rolls the counter back, so no number is ever skipped.
+func (r *numberSequenceRepository) Next(tx *gorm.DB, docType string, outletID uint64, at time.Time) (string, error) {
+	format, ok := r.formats[docType]
+	if !ok {
+		return "", fmt.Errorf("no number format configured for %s", docType)
+	}
+
+	var outletCodes []string
+	if err := tx.Model(&domain.Outlet{}).Where("id = ?", outletID).Pluck("code", &outletCodes).Error; err != nil {
+		return "", appError.ParseMySQLError(err)
+	}
+	if len(outletCodes) == 0 {
+		return "", fmt.Errorf("outlet %d does not exist", outletID)
+	}
+	outletCode := outletCodes[0]
+
+	period := numbering.Period(format.Reset, at)
+
+	// Make sure the row exists before locking it
+	seed := domain.NumberSequence{DocType: docType, OutletCode: outletCode, Period: period}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&seed).Error; err != nil {
+		return "", appError.ParseMySQLError(err)
+	}
+
+	var seq domain.NumberSequence
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("doc_type = ? AND outlet_code = ? AND period = ?", docType, outletCode, period).
+		First(&seq).Error; err != nil {
+		return "", appError.ParseMySQLError(err)
+	}
+
+	seq.LastNumber++
+	if err := tx.Model(&seq).Update("last_number", seq.LastNumber).Error; err != nil {
+		return "", appError.ParseMySQLError(err)
+	}
+
+	return numbering.Format(format.Pattern, outletCode, at, seq.LastNumber), nil
+}
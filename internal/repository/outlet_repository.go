@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+type OutletRepository interface {
+	FindPaginated(page, limit int) ([]domain.Outlet, int64, error)
+	FindByID(id uint64) (*domain.Outlet, error)
+	FindDefault() (*domain.Outlet, error)
+	Create(outlet *domain.Outlet) error
+	Update(outlet *domain.Outlet) error
+	Delete(outlet *domain.Outlet) error
+}
+
+type outletRepository struct {
+	db *gorm.DB
+}
+
+func NewOutletRepository(db *gorm.DB) OutletRepository {
+	return &outletRepository{db}
+}
+
+func (r *outletRepository) FindPaginated(page, limit int) ([]domain.Outlet, int64, error) {
+	var outlets []domain.Outlet
+	var total int64
+
+	offset := (page - 1) * limit
+	if err := r.db.Model(&domain.Outlet{}).Where("deleted_at IS NULL").Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := r.db.Where("deleted_at IS NULL").Limit(limit).Offset(offset).Find(&outlets).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return outlets, total, nil
+}
+
+func (r *outletRepository) FindByID(id uint64) (*domain.Outlet, error) {
+	var outlet domain.Outlet
+	err := r.db.Where("deleted_at IS NULL").First(&outlet, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &outlet, nil
+}
+
+func (r *outletRepository) FindDefault() (*domain.Outlet, error) {
+	var outlet domain.Outlet
+	err := r.db.Where("is_default = ? AND deleted_at IS NULL", true).First(&outlet).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &outlet, nil
+}
+
+// Create and Update keep a single default outlet: making an outlet the
+// default takes the flag off the previous one.
+func (r *outletRepository) Create(outlet *domain.Outlet) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := clearDefaultOutlet(tx, outlet); err != nil {
+			return err
+		}
+		if err := tx.Create(outlet).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		return nil
+	})
+}
+
+func (r *outletRepository) Update(outlet *domain.Outlet) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := clearDefaultOutlet(tx, outlet); err != nil {
+			return err
+		}
+		// Select is used so an outlet can be deactivated (is_active = false)
+		if err := tx.Model(&domain.Outlet{}).
+			Where("id = ? AND deleted_at IS NULL", outlet.ID).
+			Select("code", "name", "type", "address", "phone", "price_list_id", "is_default", "is_active").
+			Updates(outlet).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		return nil
+	})
+}
+
+func clearDefaultOutlet(tx *gorm.DB, outlet *domain.Outlet) error {
+	if !outlet.IsDefault {
+		return nil
+	}
+	if err := tx.Model(&domain.Outlet{}).
+		Where("is_default = ? AND id <> ?", true, outlet.ID).
+		Update("is_default", false).Error; err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *outletRepository) Delete(outlet *domain.Outlet) error {
+	err := r.db.Delete(outlet).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
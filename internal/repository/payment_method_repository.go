@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+type PaymentMethodRepository interface {
+	FindPaginated(page, limit int) ([]domain.PaymentMethod, int64, error)
+	FindByID(id uint64) (*domain.PaymentMethod, error)
+	Create(method *domain.PaymentMethod) error
+	Update(method *domain.PaymentMethod) error
+	Delete(method *domain.PaymentMethod) error
+}
+
+type paymentMethodRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentMethodRepository(db *gorm.DB) PaymentMethodRepository {
+	return &paymentMethodRepository{db}
+}
+
+func (r *paymentMethodRepository) FindPaginated(page, limit int) ([]domain.PaymentMethod, int64, error) {
+	var methods []domain.PaymentMethod
+	var total int64
+
+	offset := (page - 1) * limit
+	if err := r.db.Model(&domain.PaymentMethod{}).Where("deleted_at IS NULL").Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := r.db.Where("deleted_at IS NULL").Limit(limit).Offset(offset).Find(&methods).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return methods, total, nil
+}
+
+func (r *paymentMethodRepository) FindByID(id uint64) (*domain.PaymentMethod, error) {
+	var method domain.PaymentMethod
+	err := r.db.Where("deleted_at IS NULL").First(&method, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &method, nil
+}
+
+func (r *paymentMethodRepository) Create(method *domain.PaymentMethod) error {
+	err := r.db.Create(method).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *paymentMethodRepository) Update(method *domain.PaymentMethod) error {
+	// Select is used so a method can be deactivated (is_active = false)
+	if err := r.db.Model(&domain.PaymentMethod{}).
+		Where("id = ? AND deleted_at IS NULL", method.ID).
+		Select("code", "name", "type", "is_active").
+		Updates(method).Error; err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *paymentMethodRepository) Delete(method *domain.PaymentMethod) error {
+	err := r.db.Delete(method).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+type PaymentRepository interface {
+	TotalsByMethod(filters map[string]interface{}) ([]domain.PaymentMethodTotal, error)
+	RefundTotalsByMethod(filters map[string]interface{}) ([]domain.PaymentMethodTotal, error)
+	VoidTotalsByMethod(filters map[string]interface{}) ([]domain.PaymentMethodTotal, error)
+}
+
+type paymentRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) PaymentRepository {
+	return &paymentRepository{db}
+}
+
+// TotalsByMethod sums the amount applied to sales per payment method.
+func (r *paymentRepository) TotalsByMethod(filters map[string]interface{}) ([]domain.PaymentMethodTotal, error) {
+	var totals []domain.PaymentMethodTotal
+
+	query := r.db.Table("payments").
+		Select(`payment_methods.id AS payment_method_id, payment_methods.code, payment_methods.name,
+			payment_methods.type, COUNT(payments.id) AS count, COALESCE(SUM(payments.amount), 0) AS total`).
+		Joins("JOIN sales ON sales.id = payments.sale_id").
+		Joins("JOIN payment_methods ON payment_methods.id = payments.payment_method_id").
+		Where("sales.status IN ?", domain.SaleStatusesBooked)
+
+	for key, value := range filters {
+		switch key {
+		case "start_date":
+			query = query.Where("sales.created_at >= ?", value)
+		case "end_date":
+			query = query.Where("sales.created_at < ?", value)
+		case "cashier_id":
+			query = query.Where("sales.cashier_id = ?", value)
+		case "shift_id":
+			query = query.Where("sales.shift_id = ?", value)
+		}
+	}
+
+	err := query.
+		Group("payment_methods.id, payment_methods.code, payment_methods.name, payment_methods.type").
+		Order("payment_methods.id").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+
+	return totals, nil
+}
+
+// RefundTotalsByMethod sums the refunds paid out per payment method.
+func (r *paymentRepository) RefundTotalsByMethod(filters map[string]interface{}) ([]domain.PaymentMethodTotal, error) {
+	var totals []domain.PaymentMethodTotal
+
+	query := r.db.Table("sale_returns").
+		Select(`payment_methods.id AS payment_method_id, payment_methods.code, payment_methods.name,
+			payment_methods.type, COUNT(sale_returns.id) AS count, COALESCE(SUM(sale_returns.total_refund), 0) AS total`).
+		Joins("JOIN payment_methods ON payment_methods.id = sale_returns.payment_method_id")
+
+	for key, value := range filters {
+		switch key {
+		case "start_date":
+			query = query.Where("sale_returns.created_at >= ?", value)
+		case "end_date":
+			query = query.Where("sale_returns.created_at < ?", value)
+		case "cashier_id":
+			query = query.Where("sale_returns.cashier_id = ?", value)
+		case "shift_id":
+			query = query.Where("sale_returns.shift_id = ?", value)
+		}
+	}
+
+	err := query.
+		Group("payment_methods.id, payment_methods.code, payment_methods.name, payment_methods.type").
+		Order("payment_methods.id").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+
+	return totals, nil
+}
+
+// VoidTotalsByMethod sums the amounts paid back for voids per payment method.
+func (r *paymentRepository) VoidTotalsByMethod(filters map[string]interface{}) ([]domain.PaymentMethodTotal, error) {
+	var totals []domain.PaymentMethodTotal
+
+	query := r.db.Table("sale_voids").
+		Select(`payment_methods.id AS payment_method_id, payment_methods.code, payment_methods.name,
+			payment_methods.type, COUNT(sale_voids.id) AS count, COALESCE(SUM(sale_voids.amount), 0) AS total`).
+		Joins("JOIN payment_methods ON payment_methods.id = sale_voids.payment_method_id")
+
+	for key, value := range filters {
+		switch key {
+		case "start_date":
+			query = query.Where("sale_voids.created_at >= ?", value)
+		case "end_date":
+			query = query.Where("sale_voids.created_at < ?", value)
+		case "cashier_id":
+			query = query.Where("sale_voids.requested_by = ?", value)
+		case "shift_id":
+			query = query.Where("sale_voids.shift_id = ?", value)
+		}
+	}
+
+	err := query.
+		Group("payment_methods.id, payment_methods.code, payment_methods.name, payment_methods.type").
+		Order("payment_methods.id").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+
+	return totals, nil
+}
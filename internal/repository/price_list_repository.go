@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+type PriceListRepository interface {
+	FindPaginated(page, limit int) ([]domain.PriceList, int64, error)
+	FindByID(id uint64) (*domain.PriceList, error)
+	Create(priceList *domain.PriceList) error
+	Update(priceList *domain.PriceList) error
+	Delete(priceList *domain.PriceList) error
+	FindActive(id uint64) (*domain.PriceList, error)
+	SetItems(priceListID uint64, items []domain.PriceListItem) error
+	FindPrice(priceListID, productID uint64, quantity int) (*domain.PriceListItem, error)
+}
+
+type priceListRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceListRepository(db *gorm.DB) PriceListRepository {
+	return &priceListRepository{db}
+}
+
+func (r *priceListRepository) FindPaginated(page, limit int) ([]domain.PriceList, int64, error) {
+	var priceLists []domain.PriceList
+	var total int64
+
+	offset := (page - 1) * limit
+	if err := r.db.Model(&domain.PriceList{}).Where("deleted_at IS NULL").Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := r.db.Where("deleted_at IS NULL").Limit(limit).Offset(offset).Find(&priceLists).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return priceLists, total, nil
+}
+
+func (r *priceListRepository) FindByID(id uint64) (*domain.PriceList, error) {
+	var priceList domain.PriceList
+	err := r.db.Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("product_id, min_quantity") }).
+		Preload("Items.Product").
+		Where("deleted_at IS NULL").First(&priceList, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &priceList, nil
+}
+
+// FindActive returns the price list without its prices, nil when it is
+// inactive or deleted.
+func (r *priceListRepository) FindActive(id uint64) (*domain.PriceList, error) {
+	var priceList domain.PriceList
+	err := r.db.Where("is_active = ? AND deleted_at IS NULL", true).First(&priceList, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &priceList, nil
+}
+
+func (r *priceListRepository) Create(priceList *domain.PriceList) error {
+	err := r.db.Create(priceList).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *priceListRepository) Update(priceList *domain.PriceList) error {
+	// Select is used so a price list can be deactivated (is_active = false)
+	if err := r.db.Model(&domain.PriceList{}).
+		Where("id = ? AND deleted_at IS NULL", priceList.ID).
+		Select("code", "name", "description", "is_active").
+		Updates(priceList).Error; err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *priceListRepository) Delete(priceList *domain.PriceList) error {
+	err := r.db.Delete(priceList).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+// SetItems replaces the prices of the price list.
+func (r *priceListRepository) SetItems(priceListID uint64, items []domain.PriceListItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("price_list_id = ?", priceListID).Delete(&domain.PriceListItem{}).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		for i := range items {
+			items[i].ID = 0
+			items[i].PriceListID = priceListID
+		}
+		if len(items) > 0 {
+			if err := tx.Create(&items).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+		}
+		return nil
+	})
+}
+
+// FindPrice returns the tier of the product a line of quantity base units
+// reaches on the price list, nil when it reaches none.
+func (r *priceListRepository) FindPrice(priceListID, productID uint64, quantity int) (*domain.PriceListItem, error) {
+	var item domain.PriceListItem
+	err := r.db.Where("price_list_id = ? AND product_id = ? AND min_quantity <= ?", priceListID, productID, quantity).
+		Order("min_quantity DESC").First(&item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &item, nil
+}
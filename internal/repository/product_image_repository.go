@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProductImageRepository interface {
+	FindByID(productID, imageID uint64) (*domain.ProductImage, error)
+	Create(image *domain.ProductImage) error
+	Delete(productID, imageID uint64) (*domain.ProductImage, error)
+}
+
+type productImageRepository struct {
+	db *gorm.DB
+}
+
+func NewProductImageRepository(db *gorm.DB) ProductImageRepository {
+	return &productImageRepository{db}
+}
+
+func (r *productImageRepository) FindByID(productID, imageID uint64) (*domain.ProductImage, error) {
+	var image domain.ProductImage
+	err := r.db.Where("id = ? AND product_id = ?", imageID, productID).First(&image).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &image, nil
+}
+
+// Create stores an image of a product. The first image of a product is
+// its primary one whatever image.IsPrimary says. Uploads to one product
+// are serialized on the product row, and an image whose path is already
+// stored fails with ErrDuplicateEntry.
+func (r *productImageRepository) Create(image *domain.ProductImage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var locked []uint64
+		if err := tx.Model(&domain.Product{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", image.ProductID).Pluck("id", &locked).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		var duplicates int64
+		if err := tx.Model(&domain.ProductImage{}).Where("path = ?", image.Path).
+			Count(&duplicates).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		if duplicates > 0 {
+			return appError.ErrDuplicateEntry
+		}
+
+		var images int64
+		if err := tx.Model(&domain.ProductImage{}).Where("product_id = ?", image.ProductID).
+			Count(&images).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		if images == 0 {
+			image.IsPrimary = true
+		}
+
+		if image.IsPrimary {
+			if err := tx.Model(&domain.ProductImage{}).Where("product_id = ?", image.ProductID).
+				UpdateColumn("is_primary", false).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+		}
+
+		if err := tx.Create(image).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		return nil
+	})
+}
+
+// Delete removes an image of a product and returns it, so its files can be
+// removed too. When it was the primary one, the oldest image left takes its
+// place.
+func (r *productImageRepository) Delete(productID, imageID uint64) (*domain.ProductImage, error) {
+	var image domain.ProductImage
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND product_id = ?", imageID, productID).First(&image).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appError.ErrNotFound
+			}
+			return appError.ParseMySQLError(err)
+		}
+
+		if err := tx.Delete(&image).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		if image.IsPrimary {
+			var next []uint64
+			if err := tx.Model(&domain.ProductImage{}).Where("product_id = ?", productID).
+				Order("id").Limit(1).Pluck("id", &next).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+			if len(next) > 0 {
+				if err := tx.Model(&domain.ProductImage{}).Where("id = ?", next[0]).
+					UpdateColumn("is_primary", true).Error; err != nil {
+					return appError.ParseMySQLError(err)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
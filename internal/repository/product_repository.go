@@ -3,9 +3,12 @@ package repository
 import (
 	"errors"
 	"gopos/internal/domain"
+	"gopos/pkg/barcode"
 	appError "gopos/pkg/errors"
+	"gopos/pkg/utils"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductRepository interface {
@@ -13,8 +16,24 @@ type ProductRepository interface {
 	FindPaginated(page, limit int) ([]domain.Product, int64, error)
 	FindAll() ([]domain.Product, error)
 	FindByID(id uint64) (*domain.Product, error)
-	Create(product *domain.Product) error
-	Update(product *domain.Product) error
+	FindByCode(code string) (*domain.Product, error)
+	FindByCodes(codes []string) ([]domain.Product, error)
+	FindBarcodes(values []string) ([]domain.ProductBarcode, error)
+	FindByBarcode(values []string) (*domain.ProductBarcode, error)
+	FindStocks(productID uint64) ([]domain.ProductStock, error)
+	OutletStock(productID, outletID uint64) (int, error)
+	SetStockLevel(productID uint64, req *domain.StockLevelRequest) error
+	FindVariants(parentID uint64, outletID *uint64) ([]domain.Product, error)
+	SetVariantAttributes(product *domain.Product, attributes []domain.VariantAttribute) error
+	SetUnits(productID, baseUnitID uint64, units []domain.ProductUnit) error
+	AddBarcode(productBarcode *domain.ProductBarcode) error
+	FindRecipeUses(componentID uint64) ([]domain.RecipeItem, error)
+	SetRecipe(product *domain.Product, items []domain.RecipeItem) error
+	RemoveBarcode(productID, barcodeID uint64) error
+	Create(product *domain.Product, userID uint) error
+	Import(creates, updates []domain.Product, priceChanges []domain.ProductPriceHistory, userID uint) error
+	Update(product *domain.Product, priceChange *domain.ProductPriceHistory) error
+	FindPriceHistory(productID uint64, page, limit int, filters map[string]interface{}) ([]domain.ProductPriceHistory, int64, error)
 	Delete(category *domain.Product) error
 }
 
@@ -56,20 +75,68 @@ func (r *productRepository) FindPaginatedWithFilter(page, limit int, filters map
 
 	offset := (page - 1) * limit
 
-	query := r.db.Model(&domain.Product{}).Where("deleted_at IS NULL")
+	query := r.db.Model(&domain.Product{}).Where("products.deleted_at IS NULL")
+
+	// The catalog lists every product once, with its variants inside it;
+	// flat lists the variants as products of their own instead
+	_, flat := filters["flat"]
+	if flat {
+		query = query.Where("products.has_variants = ?", false)
+	} else {
+		query = query.Where("products.parent_id IS NULL")
+	}
+
+	// With an outlet, stock and minimum stock are those of that outlet
+	// instead of the total and the product default
+	stock, minStock := "products.stock", "products.min_stock"
+	var outletID *uint64
+	if value, ok := filters["outlet_id"]; ok {
+		id := value.(uint64)
+		outletID = &id
+		query = query.Joins("LEFT JOIN product_stocks ON product_stocks.product_id = products.id AND product_stocks.outlet_id = ?", id)
+		stock, minStock = "COALESCE(product_stocks.stock, 0)", "COALESCE(product_stocks.min_stock, products.min_stock)"
+	}
 
 	for key, value := range filters {
 		switch key {
 		case "code":
-			query = query.Where("code LIKE ?", "%"+value.(string)+"%")
+			code := "%" + value.(string) + "%"
+			if flat {
+				query = query.Where("products.code LIKE ?", code)
+			} else {
+				query = query.Where("products.code LIKE ? OR EXISTS (SELECT 1 FROM products variants WHERE variants.parent_id = products.id AND variants.deleted_at IS NULL AND variants.code LIKE ?)", code, code)
+			}
+		case "barcode":
+			if flat {
+				query = query.Where("EXISTS (SELECT 1 FROM product_barcodes WHERE product_barcodes.product_id = products.id AND product_barcodes.barcode = ?)", value)
+			} else {
+				query = query.Where("EXISTS (SELECT 1 FROM product_barcodes LEFT JOIN products variants ON variants.id = product_barcodes.product_id AND variants.deleted_at IS NULL "+
+					"WHERE product_barcodes.barcode = ? AND (product_barcodes.product_id = products.id OR variants.parent_id = products.id))", value)
+			}
 		case "name":
-			query = query.Where("name LIKE ?", "%"+value.(string)+"%")
+			query = query.Where("products.name LIKE ?", "%"+value.(string)+"%")
 		case "category_id":
-			query = query.Where("category_id = ?", value)
+			if _, ok := filters["include_subcategories"]; ok {
+				ids, err := categoryWithDescendants(r.db, uint64(value.(int)))
+				if err != nil {
+					return nil, 0, err
+				}
+				query = query.Where("products.category_id IN ?", ids)
+			} else {
+				query = query.Where("products.category_id = ?", value)
+			}
 		case "min_price":
-			query = query.Where("price >= ?", value)
+			query = query.Where("products.price >= ?", value)
 		case "max_price":
-			query = query.Where("price <= ?", value)
+			query = query.Where("products.price <= ?", value)
+		case "stock_min":
+			query = query.Where(stock+" >= ?", value)
+		case "stock_max":
+			query = query.Where(stock+" <= ?", value)
+		case "low_stock":
+			query = query.Where(minStock + " > 0").Where(stock + " <= " + minStock)
+		case "after_id":
+			query = query.Where("products.id > ?", value)
 		}
 	}
 
@@ -78,11 +145,31 @@ func (r *productRepository) FindPaginatedWithFilter(page, limit int, filters map
 		return nil, 0, appError.ParseMySQLError(err)
 	}
 
-	// Ambil data
-	if err := query.Limit(limit).Offset(offset).Find(&products).Error; err != nil {
+	if outletID != nil {
+		query = query.Select("products.*, " + stock + " AS stock")
+	}
+
+	if flat {
+		query = query.Preload("Category").
+			Preload("Barcodes", func(db *gorm.DB) *gorm.DB { return db.Where("is_primary = ?", true) })
+	} else {
+		query = query.Preload("Attributes", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+			Preload("Variants", func(db *gorm.DB) *gorm.DB { return r.variantQuery(db, outletID) }).
+			Preload("Variants.Options")
+	}
+
+	// Ambil data; ordered by ID so pages neither skip nor repeat products
+	if err := query.Order("products.id").Limit(limit).Offset(offset).Find(&products).Error; err != nil {
 		return nil, 0, appError.ParseMySQLError(err)
 	}
 
+	for i := range products {
+		products[i].OutletID = outletID
+		for j := range products[i].Variants {
+			products[i].Variants[j].OutletID = outletID
+		}
+	}
+
 	return products, total, nil
 }
 
@@ -94,7 +181,15 @@ func (r *productRepository) FindAll() ([]domain.Product, error) {
 
 func (r *productRepository) FindByID(id uint64) (*domain.Product, error) {
 	var product domain.Product
-	err := r.db.Where("deleted_at IS NULL").First(&product, id).Error
+	err := r.db.Preload("Category").Preload("BaseUnit").Preload("Units.Unit").
+		Preload("Attributes", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Preload("Options.Attribute").
+		Preload("Barcodes", func(db *gorm.DB) *gorm.DB { return db.Order("is_primary DESC, id") }).
+		Preload("Barcodes.Unit").
+		Preload("Images", func(db *gorm.DB) *gorm.DB { return db.Order("is_primary DESC, id") }).
+		Preload("Recipe", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Recipe.Component.BaseUnit").Preload("Recipe.Component.Units.Unit").Preload("Recipe.Unit").
+		Where("deleted_at IS NULL").First(&product, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -104,26 +199,569 @@ func (r *productRepository) FindByID(id uint64) (*domain.Product, error) {
 	return &product, nil
 }
 
-func (r *productRepository) Create(product *domain.Product) error {
-	err := r.db.Create(product).Error
+func (r *productRepository) FindByCode(code string) (*domain.Product, error) {
+	var product domain.Product
+	err := r.db.Preload("Category").Where("code = ? AND deleted_at IS NULL", code).First(&product).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &product, nil
+}
+
+// FindByCodes returns the products with any of codes, deleted ones
+// included: a deleted product still holds its code.
+func (r *productRepository) FindByCodes(codes []string) ([]domain.Product, error) {
+	var products []domain.Product
+	if len(codes) == 0 {
+		return products, nil
+	}
+	err := r.db.Unscoped().Where("code IN ?", codes).Find(&products).Error
+	return products, appError.ParseMySQLError(err)
+}
+
+// FindBarcodes returns the stored barcodes among values.
+func (r *productRepository) FindBarcodes(values []string) ([]domain.ProductBarcode, error) {
+	var barcodes []domain.ProductBarcode
+	if len(values) == 0 {
+		return barcodes, nil
+	}
+	err := r.db.Where("barcode IN ?", values).Find(&barcodes).Error
+	return barcodes, appError.ParseMySQLError(err)
+}
+
+// FindByBarcode returns the barcode stored under the first of values that
+// exists, with the product it belongs to and that product's units. A
+// barcode of a deleted product is not found.
+func (r *productRepository) FindByBarcode(values []string) (*domain.ProductBarcode, error) {
+	var barcodes []domain.ProductBarcode
+	if err := r.db.Preload("Product", "deleted_at IS NULL").
+		Preload("Product.BaseUnit").Preload("Product.Units.Unit").
+		Where("barcode IN ?", values).Find(&barcodes).Error; err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+
+	for _, value := range values {
+		for i := range barcodes {
+			if barcodes[i].Barcode == value && barcodes[i].Product != nil {
+				return &barcodes[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (r *productRepository) FindStocks(productID uint64) ([]domain.ProductStock, error) {
+	var stocks []domain.ProductStock
+	if err := r.db.Preload("Outlet").Where("product_id = ?", productID).
+		Order("outlet_id").Find(&stocks).Error; err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+	return stocks, nil
+}
+
+// OutletStock returns the product's stock in the outlet, zero when the
+// product never had stock there.
+func (r *productRepository) OutletStock(productID, outletID uint64) (int, error) {
+	var stocks []int
+	if err := r.db.Model(&domain.ProductStock{}).
+		Where("product_id = ? AND outlet_id = ?", productID, outletID).
+		Pluck("stock", &stocks).Error; err != nil {
+		return 0, appError.ParseMySQLError(err)
+	}
+	if len(stocks) == 0 {
+		return 0, nil
+	}
+	return stocks[0], nil
+}
+
+// FindVariants lists the live variants of a product. With an outlet, their
+// stock is the stock of that outlet.
+func (r *productRepository) FindVariants(parentID uint64, outletID *uint64) ([]domain.Product, error) {
+	var variants []domain.Product
+	if err := r.variantQuery(r.db.Preload("Options.Attribute"), outletID).
+		Where("products.parent_id = ?", parentID).
+		Find(&variants).Error; err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+	for i := range variants {
+		variants[i].OutletID = outletID
+	}
+	return variants, nil
+}
+
+func (r *productRepository) variantQuery(db *gorm.DB, outletID *uint64) *gorm.DB {
+	db = db.Where("products.deleted_at IS NULL").Order("products.id")
+	if outletID != nil {
+		db = db.Joins("LEFT JOIN product_stocks ON product_stocks.product_id = products.id AND product_stocks.outlet_id = ?", *outletID).
+			Select("products.*, COALESCE(product_stocks.stock, 0) AS stock")
+	}
+	return db
+}
+
+// SetVariantAttributes replaces the attributes of a product that has no
+// variants yet and marks it as having variants. A product that has stock
+// or variants in the meantime is reported as already processed.
+func (r *productRepository) SetVariantAttributes(product *domain.Product, attributes []domain.VariantAttribute) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var stock []int
+		if err := tx.Model(&domain.Product{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NULL", product.ID).Pluck("stock", &stock).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		if len(stock) == 0 {
+			return appError.ErrNotFound
+		}
+
+		var variants int64
+		if err := tx.Model(&domain.Product{}).Where("parent_id = ?", product.ID).Count(&variants).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		if stock[0] != 0 || variants > 0 {
+			return appError.ErrAlreadyProcessed
+		}
+
+		if err := tx.Where("product_id = ?", product.ID).Delete(&domain.VariantAttribute{}).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		for i := range attributes {
+			attributes[i].ID = 0
+			attributes[i].ProductID = product.ID
+		}
+		if err := tx.Create(&attributes).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		if err := tx.Model(&domain.Product{}).Where("id = ?", product.ID).
+			UpdateColumn("has_variants", true).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		product.Attributes = attributes
+		product.HasVariants = true
+		return nil
+	})
+}
+
+// SetUnits sets the base unit of the product and replaces its other units.
+func (r *productRepository) SetUnits(productID, baseUnitID uint64, units []domain.ProductUnit) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Product{}).Where("id = ? AND deleted_at IS NULL", productID).
+			UpdateColumn("base_unit_id", baseUnitID).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		if err := tx.Where("product_id = ?", productID).Delete(&domain.ProductUnit{}).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		for i := range units {
+			units[i].ID = 0
+			units[i].ProductID = productID
+		}
+		if len(units) > 0 {
+			if err := tx.Create(&units).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+		}
+
+		// Recipes may use the product in one of these units
+		return rollUpCost(tx, productID)
+	})
+}
+
+// FindRecipeUses lists the recipe lines that use the product as a
+// component, with the composite product they belong to.
+func (r *productRepository) FindRecipeUses(componentID uint64) ([]domain.RecipeItem, error) {
+	var items []domain.RecipeItem
+	if err := r.db.Preload("Product").Where("component_id = ?", componentID).
+		Order("product_id").Find(&items).Error; err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+	return items, nil
+}
+
+// SetRecipe replaces the recipe of a product and costs it. A product that
+// got stock in the meantime cannot become composite and is reported as
+// already processed.
+func (r *productRepository) SetRecipe(product *domain.Product, items []domain.RecipeItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var stock []int
+		if err := tx.Model(&domain.Product{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NULL", product.ID).Pluck("stock", &stock).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		if len(stock) == 0 {
+			return appError.ErrNotFound
+		}
+		if stock[0] != 0 && len(items) > 0 {
+			return appError.ErrAlreadyProcessed
+		}
+
+		if err := tx.Where("product_id = ?", product.ID).Delete(&domain.RecipeItem{}).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		for i := range items {
+			items[i].ID = 0
+			items[i].ProductID = product.ID
+		}
+		if len(items) > 0 {
+			if err := tx.Create(&items).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+		}
+
+		if err := tx.Model(&domain.Product{}).Where("id = ?", product.ID).
+			UpdateColumn("is_composite", len(items) > 0).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		product.IsComposite = len(items) > 0
+
+		if product.IsComposite {
+			return costRecipe(tx, product.ID)
+		}
+		return nil
+	})
+}
+
+// rollUpCost recosts every composite product that uses the component, after
+// its cost price or units changed.
+func rollUpCost(tx *gorm.DB, componentID uint64) error {
+	var composites []uint64
+	if err := tx.Model(&domain.RecipeItem{}).Where("component_id = ?", componentID).
+		Distinct().Pluck("product_id", &composites).Error; err != nil {
 		return appError.ParseMySQLError(err)
 	}
+
+	for _, id := range composites {
+		if err := costRecipe(tx, id); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (r *productRepository) Update(product *domain.Product) error {
-	if err := r.db.Model(&domain.Product{}).Where("id = ? AND deleted_at IS NULL", product.ID).Updates(product).Error; err != nil {
+// costRecipe sets the cost price of a composite product to the cost of the
+// components in its recipe.
+func costRecipe(tx *gorm.DB, productID uint64) error {
+	var cost float64
+	if err := tx.Table("recipe_items").
+		Select("COALESCE(SUM(products.cost_price * recipe_items.quantity * COALESCE(product_units.factor, 1)), 0)").
+		Joins("JOIN products ON products.id = recipe_items.component_id").
+		Joins("LEFT JOIN product_units ON product_units.product_id = recipe_items.component_id AND product_units.unit_id = recipe_items.unit_id").
+		Where("recipe_items.product_id = ?", productID).
+		Scan(&cost).Error; err != nil {
 		return appError.ParseMySQLError(err)
 	}
 
+	if err := tx.Model(&domain.Product{}).Where("id = ?", productID).
+		UpdateColumn("cost_price", utils.RoundMoney(cost)).Error; err != nil {
+		return appError.ParseMySQLError(err)
+	}
 	return nil
 }
 
-func (r *productRepository) Delete(product *domain.Product) error {
-	err := r.db.Delete(product).Error
-	if err != nil {
+// AddBarcode stores a barcode of a product. The first barcode of a product
+// is always its primary one, and a new primary barcode replaces the old.
+func (r *productRepository) AddBarcode(productBarcode *domain.ProductBarcode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var barcodes int64
+		if err := tx.Model(&domain.ProductBarcode{}).Where("product_id = ?", productBarcode.ProductID).
+			Count(&barcodes).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		if barcodes == 0 {
+			productBarcode.IsPrimary = true
+		}
+
+		if productBarcode.IsPrimary {
+			if err := tx.Model(&domain.ProductBarcode{}).Where("product_id = ?", productBarcode.ProductID).
+				UpdateColumn("is_primary", false).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+		}
+
+		if err := tx.Create(productBarcode).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		return nil
+	})
+}
+
+// RemoveBarcode deletes a barcode of a product. When it was the primary
+// one, the oldest barcode left takes its place.
+func (r *productRepository) RemoveBarcode(productID, barcodeID uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var productBarcode domain.ProductBarcode
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND product_id = ?", barcodeID, productID).First(&productBarcode).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return appError.ErrNotFound
+			}
+			return appError.ParseMySQLError(err)
+		}
+
+		if err := tx.Delete(&productBarcode).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		if productBarcode.IsPrimary {
+			var next []uint64
+			if err := tx.Model(&domain.ProductBarcode{}).Where("product_id = ?", productID).
+				Order("id").Limit(1).Pluck("id", &next).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+			if len(next) > 0 {
+				if err := tx.Model(&domain.ProductBarcode{}).Where("id = ?", next[0]).
+					UpdateColumn("is_primary", true).Error; err != nil {
+					return appError.ParseMySQLError(err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// SetStockLevel changes the product's default minimum stock and reorder
+// quantity, or with req.OutletID the override of that outlet. Outlets the
+// product never had stock in get a stock row of zero to hold the override.
+func (r *productRepository) SetStockLevel(productID uint64, req *domain.StockLevelRequest) error {
+	if req.OutletID == nil {
+		updates := map[string]interface{}{}
+		if req.MinStock != nil {
+			updates["min_stock"] = *req.MinStock
+		}
+		if req.ReorderQuantity != nil {
+			updates["reorder_quantity"] = *req.ReorderQuantity
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+		if err := r.db.Model(&domain.Product{}).Where("id = ? AND deleted_at IS NULL", productID).
+			UpdateColumns(updates).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		return nil
+	}
+
+	stock := domain.ProductStock{
+		ProductID:       productID,
+		OutletID:        *req.OutletID,
+		MinStock:        req.MinStock,
+		ReorderQuantity: req.ReorderQuantity,
+	}
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}, {Name: "outlet_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"min_stock", "reorder_quantity"}),
+	}).Create(&stock).Error; err != nil {
 		return appError.ParseMySQLError(err)
 	}
 	return nil
 }
+
+// Create stores the product and books its opening stock as an adjustment
+// in product.OutletID, so the stock ledger accounts for every unit from the
+// start. A product without barcodes gets the internal EAN-13 of its ID.
+func (r *productRepository) Create(product *domain.Product, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		opening := product.Stock
+		product.Stock = 0
+
+		if err := tx.Create(product).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		if err := tx.Create(&domain.ProductPriceHistory{ProductID: product.ID, Price: *product.Price, ChangedBy: &userID}).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		if len(product.Barcodes) == 0 {
+			internal, err := internalBarcode(product.ID)
+			if err != nil {
+				return err
+			}
+			if err := tx.Create(&internal).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+			product.Barcodes = []domain.ProductBarcode{internal}
+		}
+
+		return postOpeningStock(tx, product, opening, userID)
+	})
+}
+
+// importBatchSize is how many rows an import writes per statement.
+const importBatchSize = 200
+
+// Import writes a checked product import in one transaction, so either
+// every row lands or none does. New products are inserted in batches with
+// their first price history row, their barcode, or an internal one, and
+// their opening stock in OutletID. Existing products are upserted on their
+// ID in batches, changing only the columns an import may set, and their
+// price changes recorded.
+func (r *productRepository) Import(creates, updates []domain.Product, priceChanges []domain.ProductPriceHistory, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for start := 0; start < len(creates); start += importBatchSize {
+			batch := creates[start:min(start+importBatchSize, len(creates))]
+
+			openings := make([]int, len(batch))
+			for i := range batch {
+				openings[i] = batch[i].Stock
+				batch[i].Stock = 0
+			}
+
+			if err := tx.Omit(clause.Associations).Create(&batch).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+
+			history := make([]domain.ProductPriceHistory, 0, len(batch))
+			barcodes := make([]domain.ProductBarcode, 0, len(batch))
+			for i := range batch {
+				product := &batch[i]
+				history = append(history, domain.ProductPriceHistory{ProductID: product.ID, Price: *product.Price, ChangedBy: &userID})
+
+				if len(product.Barcodes) == 0 {
+					internal, err := internalBarcode(product.ID)
+					if err != nil {
+						return err
+					}
+					product.Barcodes = []domain.ProductBarcode{internal}
+				}
+				for _, productBarcode := range product.Barcodes {
+					productBarcode.ProductID = product.ID
+					barcodes = append(barcodes, productBarcode)
+				}
+			}
+			if err := tx.Create(&history).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+			if err := tx.Create(&barcodes).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+
+			for i := range batch {
+				if err := postOpeningStock(tx, &batch[i], openings[i], userID); err != nil {
+					return err
+				}
+			}
+		}
+
+		for start := 0; start < len(updates); start += importBatchSize {
+			batch := updates[start:min(start+importBatchSize, len(updates))]
+			if err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "id"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"name", "description", "category_id", "price", "min_stock", "reorder_quantity",
+					"track_expiry", "is_active", "updated_at",
+				}),
+			}).Create(&batch).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+		}
+
+		if len(priceChanges) > 0 {
+			if err := tx.CreateInBatches(&priceChanges, importBatchSize).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+		}
+		return nil
+	})
+}
+
+// internalBarcode returns the in-store EAN-13 a product without barcodes
+// gets.
+func internalBarcode(productID uint64) (domain.ProductBarcode, error) {
+	code, err := barcode.Internal(productID)
+	if err != nil {
+		return domain.ProductBarcode{}, err
+	}
+	return domain.ProductBarcode{ProductID: productID, Barcode: code, Type: barcode.EAN13, IsPrimary: true}, nil
+}
+
+// postOpeningStock books the opening stock of a new product to its
+// OutletID.
+func postOpeningStock(tx *gorm.DB, product *domain.Product, opening int, userID uint) error {
+	if opening == 0 {
+		return nil
+	}
+
+	movement := &domain.InventoryMovement{
+		ProductID:     product.ID,
+		OutletID:      *product.OutletID,
+		Type:          domain.MovementTypeAdjustment,
+		Quantity:      opening,
+		ReferenceType: "product",
+		ReferenceID:   &product.ID,
+		Note:          "Opening stock",
+		UserID:        &userID,
+	}
+	if err := postStock(tx, movement); err != nil {
+		return err
+	}
+	product.Stock = movement.BalanceAfter
+	product.Stocks = []domain.ProductStock{{ProductID: product.ID, OutletID: movement.OutletID, Stock: movement.BalanceAfter}}
+	return nil
+}
+
+// Update never touches stock, cost price or stock levels; those change
+// through inventory movements, goods receipts and SetStockLevel. A price
+// change is recorded in the price history in the same transaction.
+func (r *productRepository) Update(product *domain.Product, priceChange *domain.ProductPriceHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Product{}).Where("id = ? AND deleted_at IS NULL", product.ID).
+			Select("code", "name", "description", "category_id", "tax_rate_id", "price", "is_active", "track_expiry").
+			Updates(product).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		if priceChange != nil {
+			if err := tx.Create(priceChange).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *productRepository) FindPriceHistory(productID uint64, page, limit int, filters map[string]interface{}) ([]domain.ProductPriceHistory, int64, error) {
+	var history []domain.ProductPriceHistory
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&domain.ProductPriceHistory{}).Where("product_id = ?", productID)
+
+	for key, value := range filters {
+		switch key {
+		case "start_date":
+			query = query.Where("created_at >= ?", value)
+		case "end_date":
+			query = query.Where("created_at < ?", value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&history).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return history, total, nil
+}
+
+// Delete also drops the product's barcodes, so they can be given to another
+// product.
+func (r *productRepository) Delete(product *domain.Product) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", product.ID).Delete(&domain.ProductBarcode{}).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		if err := tx.Delete(product).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		return nil
+	})
+}
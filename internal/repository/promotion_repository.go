@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PromotionRepository interface {
+	FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.Promotion, int64, error)
+	FindByID(id uint64) (*domain.Promotion, error)
+	FindActive(at time.Time) ([]domain.Promotion, error)
+	Create(promotion *domain.Promotion) error
+	Update(promotion *domain.Promotion) error
+	Delete(promotion *domain.Promotion) error
+}
+
+type promotionRepository struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepository(db *gorm.DB) PromotionRepository {
+	return &promotionRepository{db}
+}
+
+func (r *promotionRepository) FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.Promotion, int64, error) {
+	var promotions []domain.Promotion
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&domain.Promotion{}).Where("deleted_at IS NULL")
+
+	for key, value := range filters {
+		switch key {
+		case "code":
+			query = query.Where("code LIKE ?", "%"+value.(string)+"%")
+		case "type":
+			query = query.Where("type = ?", value)
+		case "is_active":
+			query = query.Where("is_active = ?", value)
+		case "active_at":
+			query = query.Where("(starts_at IS NULL OR starts_at <= ?) AND (ends_at IS NULL OR ends_at > ?)", value, value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := query.Preload("Targets").Order("priority DESC, id").Limit(limit).Offset(offset).Find(&promotions).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return promotions, total, nil
+}
+
+func (r *promotionRepository) FindByID(id uint64) (*domain.Promotion, error) {
+	var promotion domain.Promotion
+	err := r.db.Preload("Targets").Where("deleted_at IS NULL").First(&promotion, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &promotion, nil
+}
+
+// FindActive returns the active promotions whose date window covers at.
+// Hours of the day and outlet scope are left to the promotion engine.
+func (r *promotionRepository) FindActive(at time.Time) ([]domain.Promotion, error) {
+	var promotions []domain.Promotion
+	err := r.db.Preload("Targets").
+		Where("deleted_at IS NULL AND is_active = ?", true).
+		Where("(starts_at IS NULL OR starts_at <= ?) AND (ends_at IS NULL OR ends_at > ?)", at, at).
+		Order("priority DESC, id").
+		Find(&promotions).Error
+	if err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+	return promotions, nil
+}
+
+func (r *promotionRepository) Create(promotion *domain.Promotion) error {
+	err := r.db.Create(promotion).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+// Update saves the promotion and replaces its targets.
+func (r *promotionRepository) Update(promotion *domain.Promotion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Select is used so zero values (is_active = false, no end date) are saved too
+		if err := tx.Model(&domain.Promotion{}).
+			Where("id = ? AND deleted_at IS NULL", promotion.ID).
+			Select("code", "name", "type", "scope", "value", "buy_quantity", "get_quantity", "bundle_quantity",
+				"min_spend", "stackable", "priority", "starts_at", "ends_at", "start_time", "end_time", "outlet_id", "is_active").
+			Updates(promotion).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		if err := tx.Where("promotion_id = ?", promotion.ID).Delete(&domain.PromotionTarget{}).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		for i := range promotion.Targets {
+			promotion.Targets[i].ID = 0
+			promotion.Targets[i].PromotionID = promotion.ID
+		}
+		if len(promotion.Targets) > 0 {
+			if err := tx.Create(&promotion.Targets).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *promotionRepository) Delete(promotion *domain.Promotion) error {
+	err := r.db.Delete(promotion).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
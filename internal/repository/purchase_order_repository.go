@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+	"gopos/pkg/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PurchaseOrderRepository interface {
+	FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.PurchaseOrder, int64, error)
+	FindByID(id uint64) (*domain.PurchaseOrder, error)
+	Create(order *domain.PurchaseOrder) error
+	Update(order *domain.PurchaseOrder) error
+	UpdateStatus(order *domain.PurchaseOrder, from []string, to string) error
+	FindReceipts(orderID uint64) ([]domain.GoodsReceipt, error)
+	Receive(order *domain.PurchaseOrder, receipt *domain.GoodsReceipt) error
+}
+
+type purchaseOrderRepository struct {
+	db        *gorm.DB
+	numbering NumberSequenceRepository
+}
+
+func NewPurchaseOrderRepository(db *gorm.DB, numbering NumberSequenceRepository) PurchaseOrderRepository {
+	return &purchaseOrderRepository{db: db, numbering: numbering}
+}
+
+func (r *purchaseOrderRepository) FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.PurchaseOrder, int64, error) {
+	var orders []domain.PurchaseOrder
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&domain.PurchaseOrder{})
+
+	for key, value := range filters {
+		switch key {
+		case "status":
+			query = query.Where("status = ?", value)
+		case "supplier_id":
+			query = query.Where("supplier_id = ?", value)
+		case "outlet_id":
+			query = query.Where("outlet_id = ?", value)
+		case "order_no":
+			query = query.Where("order_no LIKE ?", "%"+value.(string)+"%")
+		case "start_date":
+			query = query.Where("created_at >= ?", value)
+		case "end_date":
+			query = query.Where("created_at < ?", value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := query.Preload("Supplier").Order("id DESC").Limit(limit).Offset(offset).Find(&orders).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return orders, total, nil
+}
+
+func (r *purchaseOrderRepository) FindByID(id uint64) (*domain.PurchaseOrder, error) {
+	var order domain.PurchaseOrder
+	err := r.db.Preload("Supplier").Preload("Outlet").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Items.Product").
+		First(&order, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &order, nil
+}
+
+func (r *purchaseOrderRepository) Create(order *domain.PurchaseOrder) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		order.CreatedAt = time.Now()
+
+		orderNo, err := r.numbering.Next(tx, domain.DocTypePurchaseOrder, order.OutletID, order.CreatedAt)
+		if err != nil {
+			return err
+		}
+		order.OrderNo = orderNo
+
+		if err := tx.Create(order).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		return nil
+	})
+}
+
+// Update replaces the header and lines of a draft order. Orders that left
+// draft in the meantime are reported as already processed.
+func (r *purchaseOrderRepository) Update(order *domain.PurchaseOrder) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var status string
+		if err := tx.Model(&domain.PurchaseOrder{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", order.ID).Pluck("status", &status).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		if status != domain.PurchaseOrderStatusDraft {
+			return appError.ErrAlreadyProcessed
+		}
+
+		if err := tx.Model(&domain.PurchaseOrder{}).Where("id = ?", order.ID).
+			Select("supplier_id", "outlet_id", "expected_date", "note", "total").
+			Updates(order).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		if err := tx.Where("purchase_order_id = ?", order.ID).Delete(&domain.PurchaseOrderItem{}).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		for i := range order.Items {
+			order.Items[i].ID = 0
+			order.Items[i].PurchaseOrderID = order.ID
+		}
+		if len(order.Items) > 0 {
+			if err := tx.Create(&order.Items).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateStatus moves the order to status to, provided it is still in one
+// of the from statuses.
+func (r *purchaseOrderRepository) UpdateStatus(order *domain.PurchaseOrder, from []string, to string) error {
+	result := r.db.Model(&domain.PurchaseOrder{}).
+		Where("id = ? AND status IN ?", order.ID, from).
+		Update("status", to)
+	if result.Error != nil {
+		return appError.ParseMySQLError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appError.ErrAlreadyProcessed
+	}
+	order.Status = to
+	return nil
+}
+
+func (r *purchaseOrderRepository) FindReceipts(orderID uint64) ([]domain.GoodsReceipt, error) {
+	var receipts []domain.GoodsReceipt
+	if err := r.db.Preload("Items").Where("purchase_order_id = ?", orderID).
+		Order("id").Find(&receipts).Error; err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+	return receipts, nil
+}
+
+// Receive books the goods receipt: every line raises the received quantity
+// of its order line, moves the product's cost price to the weighted average
+// cost and posts the stock into the line's lot. The order ends up received
+// once every line is complete, partially received otherwise.
+func (r *purchaseOrderRepository) Receive(order *domain.PurchaseOrder, receipt *domain.GoodsReceipt) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the order so a concurrent cancel or receipt waits for this one
+		var status string
+		if err := tx.Model(&domain.PurchaseOrder{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", order.ID).Pluck("status", &status).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		if status != domain.PurchaseOrderStatusSent && status != domain.PurchaseOrderStatusPartiallyReceived {
+			return appError.ErrAlreadyProcessed
+		}
+
+		receipt.CreatedAt = time.Now()
+		receiptNo, err := r.numbering.Next(tx, domain.DocTypeGoodsReceipt, order.OutletID, receipt.CreatedAt)
+		if err != nil {
+			return err
+		}
+		receipt.ReceiptNo = receiptNo
+
+		if err := tx.Create(receipt).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		for _, item := range receipt.Items {
+			result := tx.Model(&domain.PurchaseOrderItem{}).
+				Where("id = ? AND purchase_order_id = ? AND quantity - received_quantity >= ?", item.PurchaseOrderItemID, order.ID, item.Quantity).
+				UpdateColumn("received_quantity", gorm.Expr("received_quantity + ?", item.Quantity))
+			if result.Error != nil {
+				return appError.ParseMySQLError(result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return appError.ErrAlreadyProcessed
+			}
+
+			var product domain.Product
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Select("id", "stock", "cost_price").
+				First(&product, item.ProductID).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+
+			// Stock and cost price are per base unit, the receipt line per ordered unit
+			quantity := item.Quantity * item.UnitFactor
+			cost := weightedAverageCost(product.Stock, product.CostPrice, quantity, item.UnitCost/float64(item.UnitFactor))
+			if err := tx.Model(&domain.Product{}).Where("id = ?", product.ID).
+				UpdateColumn("cost_price", cost).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+			if err := rollUpCost(tx, product.ID); err != nil {
+				return err
+			}
+
+			var lots []domain.LotAllocation
+			if item.LotNo != "" {
+				lots = []domain.LotAllocation{{LotNo: item.LotNo, ExpiryDate: item.ExpiryDate, Quantity: quantity}}
+			}
+
+			if err := postStock(tx, &domain.InventoryMovement{
+				ProductID:     item.ProductID,
+				OutletID:      order.OutletID,
+				Type:          domain.MovementTypePurchaseReceipt,
+				Quantity:      quantity,
+				ReferenceType: "goods_receipt",
+				ReferenceID:   &receipt.ID,
+				ReferenceNo:   receipt.ReceiptNo,
+				UserID:        &receipt.ReceivedBy,
+				Allocations:   lots,
+			}); err != nil {
+				return err
+			}
+		}
+
+		var open int64
+		if err := tx.Model(&domain.PurchaseOrderItem{}).
+			Where("purchase_order_id = ? AND received_quantity < quantity", order.ID).
+			Count(&open).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		status = domain.PurchaseOrderStatusPartiallyReceived
+		if open == 0 {
+			status = domain.PurchaseOrderStatusReceived
+		}
+		if err := tx.Model(&domain.PurchaseOrder{}).Where("id = ?", order.ID).
+			Update("status", status).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		order.Status = status
+		return nil
+	})
+}
+
+// weightedAverageCost values the stock on hand and the received goods at
+// their own cost. Stock at or below zero has no value left to average with,
+// so the receipt cost is taken as is.
+func weightedAverageCost(stock int, cost float64, quantity int, unitCost float64) float64 {
+	if stock <= 0 {
+		return unitCost
+	}
+	total := float64(stock)*cost + float64(quantity)*unitCost
+	return utils.RoundMoney(total / float64(stock+quantity))
+}
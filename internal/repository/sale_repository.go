@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SaleRepository interface {
+	FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.Sale, int64, error)
+	FindByID(id uint64) (*domain.Sale, error)
+	Create(sale *domain.Sale) error
+	Totals(filters map[string]interface{}) (count int64, total float64, err error)
+}
+
+type saleRepository struct {
+	db        *gorm.DB
+	numbering NumberSequenceRepository
+}
+
+func NewSaleRepository(db *gorm.DB, numbering NumberSequenceRepository) SaleRepository {
+	return &saleRepository{db: db, numbering: numbering}
+}
+
+func (r *saleRepository) FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.Sale, int64, error) {
+	var sales []domain.Sale
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&domain.Sale{})
+
+	for key, value := range filters {
+		switch key {
+		case "start_date":
+			query = query.Where("created_at >= ?", value)
+		case "end_date":
+			query = query.Where("created_at < ?", value)
+		case "cashier_id":
+			query = query.Where("cashier_id = ?", value)
+		case "shift_id":
+			query = query.Where("shift_id = ?", value)
+		case "outlet_id":
+			query = query.Where("outlet_id = ?", value)
+		case "invoice_no":
+			query = query.Where("invoice_no LIKE ?", "%"+value.(string)+"%")
+		case "status":
+			query = query.Where("status = ?", value)
+		}
+	}
+
+	// Hitung total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	// Ambil data
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&sales).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return sales, total, nil
+}
+
+func (r *saleRepository) FindByID(id uint64) (*domain.Sale, error) {
+	var sale domain.Sale
+	err := r.db.Preload("Items.Promotions").Preload("Items.Components").Preload("Taxes").Preload("Payments.PaymentMethod").Preload("Returns").Preload("Voids.Items").First(&sale, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &sale, nil
+}
+
+// Create stores the sale and posts its stock movements in one transaction.
+// Posting is conditional so concurrent checkouts can never push stock
+// below zero. A sale converted from a draft order also closes the
+// draft in the same transaction, so a draft can only be checked out once.
+// The invoice number is taken inside the same transaction too, after the
+// shift is locked and checked to be still open.
+func (r *saleRepository) Create(sale *domain.Sale) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if sale.ShiftID != nil {
+			if err := lockOpenShift(tx, *sale.ShiftID); err != nil {
+				return err
+			}
+		}
+		sale.CreatedAt = time.Now()
+
+		invoiceNo, err := r.numbering.Next(tx, domain.DocTypeInvoice, sale.OutletID, sale.CreatedAt)
+		if err != nil {
+			return err
+		}
+		sale.InvoiceNo = invoiceNo
+
+		if err := tx.Create(sale).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		for _, item := range sale.Items {
+			for _, part := range stockParts(item.ProductID, item.UnitFactor, item.Components) {
+				if err := postStock(tx, &domain.InventoryMovement{
+					ProductID:     part.ProductID,
+					OutletID:      sale.OutletID,
+					Type:          domain.MovementTypeSale,
+					Quantity:      -item.Quantity * part.Quantity,
+					ReferenceType: "sale",
+					ReferenceID:   &sale.ID,
+					ReferenceNo:   sale.InvoiceNo,
+					UserID:        &sale.CashierID,
+				}); err != nil {
+					return err
+				}
+			}
+		}
+
+		if sale.DraftOrderID != nil {
+			result := tx.Model(&domain.DraftOrder{}).
+				Where("id = ? AND status IN ?", *sale.DraftOrderID, []string{domain.DraftOrderStatusOpen, domain.DraftOrderStatusParked}).
+				Updates(map[string]interface{}{
+					"status":  domain.DraftOrderStatusCompleted,
+					"sale_id": sale.ID,
+				})
+			if result.Error != nil {
+				return appError.ParseMySQLError(result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return appError.ErrAlreadyProcessed
+			}
+		}
+		return nil
+	})
+}
+
+// restockSaleItem puts quantity units of a sale line back into stock, with
+// movement filled in for everything but the product and quantity. The units
+// go back into the lots the sale took them from, and for a composite line
+// into the components it used up.
+func restockSaleItem(tx *gorm.DB, saleID, saleItemID, productID uint64, quantity, unitFactor int, movement domain.InventoryMovement) error {
+	var components []domain.SaleItemComponent
+	if err := tx.Where("sale_item_id = ?", saleItemID).Order("id").Find(&components).Error; err != nil {
+		return appError.ParseMySQLError(err)
+	}
+
+	for _, part := range stockParts(productID, unitFactor, components) {
+		lots, err := reversedLots(tx, "sale", saleID, part.ProductID, quantity*part.Quantity)
+		if err != nil {
+			return err
+		}
+
+		restock := movement
+		restock.ProductID = part.ProductID
+		restock.Quantity = quantity * part.Quantity
+		restock.Allocations = lots
+		if err := postStock(tx, &restock); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stockParts returns the products one unit of a sale line moves and how
+// many base units of each: the components of a composite line, otherwise
+// the product itself.
+func stockParts(productID uint64, unitFactor int, components []domain.SaleItemComponent) []domain.SaleItemComponent {
+	if len(components) > 0 {
+		return components
+	}
+	return []domain.SaleItemComponent{{ProductID: productID, Quantity: unitFactor}}
+}
+
+// Totals returns the number of booked sales and their grand total.
+func (r *saleRepository) Totals(filters map[string]interface{}) (int64, float64, error) {
+	var totals struct {
+		Count int64
+		Total float64
+	}
+
+	query := r.db.Model(&domain.Sale{}).
+		Select("COUNT(id) AS count, COALESCE(SUM(total), 0) AS total").
+		Where("status IN ?", domain.SaleStatusesBooked)
+
+	for key, value := range filters {
+		switch key {
+		case "start_date":
+			query = query.Where("created_at >= ?", value)
+		case "end_date":
+			query = query.Where("created_at < ?", value)
+		case "cashier_id":
+			query = query.Where("cashier_id = ?", value)
+		case "shift_id":
+			query = query.Where("shift_id = ?", value)
+		case "outlet_id":
+			query = query.Where("outlet_id = ?", value)
+		}
+	}
+
+	if err := query.Scan(&totals).Error; err != nil {
+		return 0, 0, appError.ParseMySQLError(err)
+	}
+
+	return totals.Count, totals.Total, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SaleReturnRepository interface {
+	FindBySaleID(saleID uint64) ([]domain.SaleReturn, error)
+	Create(saleReturn *domain.SaleReturn) error
+}
+
+type saleReturnRepository struct {
+	db        *gorm.DB
+	numbering NumberSequenceRepository
+}
+
+func NewSaleReturnRepository(db *gorm.DB, numbering NumberSequenceRepository) SaleReturnRepository {
+	return &saleReturnRepository{db: db, numbering: numbering}
+}
+
+func (r *saleReturnRepository) FindBySaleID(saleID uint64) ([]domain.SaleReturn, error) {
+	var returns []domain.SaleReturn
+	err := r.db.Preload("Items").Preload("PaymentMethod").
+		Where("sale_id = ?", saleID).
+		Order("created_at").
+		Find(&returns).Error
+	if err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+	return returns, nil
+}
+
+// Create records the return, bumps the returned quantity on each sale line
+// and puts the goods back on stock in one transaction. The returned quantity
+// is guarded in the UPDATE itself so two concurrent returns of the same line
+// cannot both succeed.
+func (r *saleReturnRepository) Create(saleReturn *domain.SaleReturn) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if saleReturn.ShiftID != nil {
+			if err := lockOpenShift(tx, *saleReturn.ShiftID); err != nil {
+				return err
+			}
+		}
+		saleReturn.CreatedAt = time.Now()
+
+		// A refund is numbered in the series of the outlet that made the sale
+		var saleOutlets []uint64
+		if err := tx.Model(&domain.Sale{}).Where("id = ?", saleReturn.SaleID).Pluck("outlet_id", &saleOutlets).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		if len(saleOutlets) == 0 {
+			return appError.ErrNotFound
+		}
+
+		returnNo, err := r.numbering.Next(tx, domain.DocTypeRefund, saleOutlets[0], saleReturn.CreatedAt)
+		if err != nil {
+			return err
+		}
+		saleReturn.ReturnNo = returnNo
+
+		for _, item := range saleReturn.Items {
+			result := tx.Model(&domain.SaleItem{}).
+				Where("id = ? AND sale_id = ? AND quantity - returned_quantity - voided_quantity >= ?", item.SaleItemID, saleReturn.SaleID, item.Quantity).
+				UpdateColumn("returned_quantity", gorm.Expr("returned_quantity + ?", item.Quantity))
+			if result.Error != nil {
+				return appError.ParseMySQLError(result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return appError.ErrAlreadyProcessed
+			}
+
+		}
+
+		if err := tx.Create(saleReturn).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		for _, item := range saleReturn.Items {
+			if err := restockSaleItem(tx, saleReturn.SaleID, item.SaleItemID, item.ProductID, item.Quantity, item.UnitFactor, domain.InventoryMovement{
+				OutletID:      saleReturn.OutletID,
+				Type:          domain.MovementTypeReturn,
+				ReferenceType: "sale_return",
+				ReferenceID:   &saleReturn.ID,
+				ReferenceNo:   saleReturn.ReturnNo,
+				UserID:        &saleReturn.CashierID,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
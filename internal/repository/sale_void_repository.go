@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+type SaleVoidRepository interface {
+	FindBySaleID(saleID uint64) ([]domain.SaleVoid, error)
+	Create(saleVoid *domain.SaleVoid, voidSale bool) error
+}
+
+type saleVoidRepository struct {
+	db *gorm.DB
+}
+
+func NewSaleVoidRepository(db *gorm.DB) SaleVoidRepository {
+	return &saleVoidRepository{db}
+}
+
+func (r *saleVoidRepository) FindBySaleID(saleID uint64) ([]domain.SaleVoid, error) {
+	var voids []domain.SaleVoid
+	err := r.db.Preload("Items").Preload("PaymentMethod").
+		Where("sale_id = ?", saleID).
+		Order("created_at").
+		Find(&voids).Error
+	if err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+	return voids, nil
+}
+
+// Create records the void, bumps the voided quantity on each sale line and
+// puts the goods back on stock in one transaction. When voidSale is set the
+// sale itself is marked voided as well. Quantities are guarded in the UPDATE
+// so a void cannot race a return or another void of the same line.
+func (r *saleVoidRepository) Create(saleVoid *domain.SaleVoid, voidSale bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if saleVoid.ShiftID != nil {
+			if err := lockOpenShift(tx, *saleVoid.ShiftID); err != nil {
+				return err
+			}
+		}
+
+		if voidSale {
+			result := tx.Model(&domain.Sale{}).
+				Where("id = ? AND status = ?", saleVoid.SaleID, domain.SaleStatusCompleted).
+				Update("status", domain.SaleStatusVoided)
+			if result.Error != nil {
+				return appError.ParseMySQLError(result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return appError.ErrAlreadyProcessed
+			}
+		}
+
+		for _, item := range saleVoid.Items {
+			result := tx.Model(&domain.SaleItem{}).
+				Where("id = ? AND sale_id = ? AND quantity - returned_quantity - voided_quantity >= ?", item.SaleItemID, saleVoid.SaleID, item.Quantity).
+				UpdateColumn("voided_quantity", gorm.Expr("voided_quantity + ?", item.Quantity))
+			if result.Error != nil {
+				return appError.ParseMySQLError(result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return appError.ErrAlreadyProcessed
+			}
+
+		}
+
+		if err := tx.Create(saleVoid).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		for _, item := range saleVoid.Items {
+			if err := restockSaleItem(tx, saleVoid.SaleID, item.SaleItemID, item.ProductID, item.Quantity, item.UnitFactor, domain.InventoryMovement{
+				OutletID:      saleVoid.OutletID,
+				Type:          domain.MovementTypeVoid,
+				ReferenceType: "sale_void",
+				ReferenceID:   &saleVoid.ID,
+				Note:          saleVoid.Reason,
+				UserID:        &saleVoid.RequestedBy,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShiftRepository interface {
+	FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.Shift, int64, error)
+	FindByID(id uint64) (*domain.Shift, error)
+	FindOpenByUser(userID uint) (*domain.Shift, error)
+	Open(shift *domain.Shift) error
+	Close(shift *domain.Shift, settle func() error) error
+	CreateCashMovement(movement *domain.CashMovement) error
+	CashMovementTotals(shiftID uint64) (paidIn float64, paidOut float64, err error)
+}
+
+type shiftRepository struct {
+	db *gorm.DB
+}
+
+func NewShiftRepository(db *gorm.DB) ShiftRepository {
+	return &shiftRepository{db}
+}
+
+func (r *shiftRepository) FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.Shift, int64, error) {
+	var shifts []domain.Shift
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&domain.Shift{})
+
+	for key, value := range filters {
+		switch key {
+		case "user_id":
+			query = query.Where("user_id = ?", value)
+		case "outlet_id":
+			query = query.Where("outlet_id = ?", value)
+		case "status":
+			query = query.Where("status = ?", value)
+		case "start_date":
+			query = query.Where("opened_at >= ?", value)
+		case "end_date":
+			query = query.Where("opened_at < ?", value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := query.Order("opened_at DESC").Limit(limit).Offset(offset).Find(&shifts).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return shifts, total, nil
+}
+
+func (r *shiftRepository) FindByID(id uint64) (*domain.Shift, error) {
+	var shift domain.Shift
+	err := r.db.Preload("CashMovements").First(&shift, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &shift, nil
+}
+
+func (r *shiftRepository) FindOpenByUser(userID uint) (*domain.Shift, error) {
+	var shift domain.Shift
+	err := r.db.Where("user_id = ? AND status = ?", userID, domain.ShiftStatusOpen).First(&shift).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &shift, nil
+}
+
+// Open creates the shift unless the user already has one open. The lookup
+// locks the user's open shift rows so two open requests cannot race.
+func (r *shiftRepository) Open(shift *domain.Shift) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var open int64
+		if err := tx.Model(&domain.Shift{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND status = ?", shift.UserID, domain.ShiftStatusOpen).
+			Count(&open).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		if open > 0 {
+			return appError.ErrShiftAlreadyOpen
+		}
+
+		if err := tx.Create(shift).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		return nil
+	})
+}
+
+// Close locks the open shift, lets settle sum up its totals into shift and
+// then closes it. Sales, refunds and voids lock the shift before they are
+// booked, so none can land between the totals and the close.
+func (r *shiftRepository) Close(shift *domain.Shift, settle func() error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockOpenShift(tx, shift.ID); err != nil {
+			if appError.Is(err, appError.ErrShiftNotOpen) {
+				return appError.ErrAlreadyProcessed
+			}
+			return err
+		}
+
+		if err := settle(); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&domain.Shift{}).Where("id = ?", shift.ID).
+			Updates(map[string]interface{}{
+				"status":        domain.ShiftStatusClosed,
+				"expected_cash": shift.ExpectedCash,
+				"counted_cash":  shift.CountedCash,
+				"variance":      shift.Variance,
+				"note":          shift.Note,
+				"closed_at":     shift.ClosedAt,
+			}).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		return nil
+	})
+}
+
+// lockOpenShift locks the shift row until the transaction ends and checks
+// that the shift is still open.
+func lockOpenShift(tx *gorm.DB, shiftID uint64) error {
+	var statuses []string
+	if err := tx.Model(&domain.Shift{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", shiftID).Pluck("status", &statuses).Error; err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	if len(statuses) == 0 || statuses[0] != domain.ShiftStatusOpen {
+		return appError.ErrShiftNotOpen
+	}
+	return nil
+}
+
+func (r *shiftRepository) CreateCashMovement(movement *domain.CashMovement) error {
+	err := r.db.Create(movement).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *shiftRepository) CashMovementTotals(shiftID uint64) (float64, float64, error) {
+	var totals struct {
+		PaidIn  float64
+		PaidOut float64
+	}
+
+	err := r.db.Model(&domain.CashMovement{}).
+		Select(`COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE 0 END), 0) AS paid_in,
+			COALESCE(SUM(CASE WHEN type = ? THEN amount ELSE 0 END), 0) AS paid_out`,
+			domain.CashMovementPaidIn, domain.CashMovementPaidOut).
+		Where("shift_id = ?", shiftID).
+		Scan(&totals).Error
+	if err != nil {
+		return 0, 0, appError.ParseMySQLError(err)
+	}
+
+	return totals.PaidIn, totals.PaidOut, nil
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockTakeRepository interface {
+	FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.StockTake, int64, error)
+	FindByID(id uint64) (*domain.StockTake, error)
+	Create(take *domain.StockTake) error
+	SaveCount(take *domain.StockTake, item *domain.StockTakeItem, count *domain.StockTakeCount) error
+	Approve(take *domain.StockTake) error
+	Cancel(take *domain.StockTake) error
+}
+
+type stockTakeRepository struct {
+	db *gorm.DB
+}
+
+func NewStockTakeRepository(db *gorm.DB) StockTakeRepository {
+	return &stockTakeRepository{db}
+}
+
+func (r *stockTakeRepository) FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.StockTake, int64, error) {
+	var takes []domain.StockTake
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&domain.StockTake{})
+
+	for key, value := range filters {
+		switch key {
+		case "status":
+			query = query.Where("status = ?", value)
+		case "outlet_id":
+			query = query.Where("outlet_id = ?", value)
+		case "start_date":
+			query = query.Where("created_at >= ?", value)
+		case "end_date":
+			query = query.Where("created_at < ?", value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&takes).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return takes, total, nil
+}
+
+func (r *stockTakeRepository) FindByID(id uint64) (*domain.StockTake, error) {
+	var take domain.StockTake
+	err := r.db.Preload("Outlet").Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Items.Product").Preload("Items.Counts").
+		First(&take, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &take, nil
+}
+
+// Create opens the stock take with a snapshot of the outlet's current stock
+// and the cost of every active product, optionally limited to one category.
+func (r *stockTakeRepository) Create(take *domain.StockTake) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var products []domain.Product
+		query := tx.Model(&domain.Product{}).
+			Joins("LEFT JOIN product_stocks ON product_stocks.product_id = products.id AND product_stocks.outlet_id = ?", take.OutletID).
+			Select("products.id, products.cost_price, COALESCE(product_stocks.stock, 0) AS stock").
+			Where("products.deleted_at IS NULL AND products.is_active = ? AND products.has_variants = ? AND products.is_composite = ?", true, false, false)
+		if take.CategoryID != nil {
+			query = query.Where("products.category_id = ?", *take.CategoryID)
+		}
+		if err := query.Order("products.id").Find(&products).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		take.Items = make([]domain.StockTakeItem, 0, len(products))
+		for _, product := range products {
+			take.Items = append(take.Items, domain.StockTakeItem{
+				ProductID:      product.ID,
+				SystemQuantity: product.Stock,
+				CostPrice:      product.CostPrice,
+			})
+		}
+
+		if err := tx.Create(take).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		return nil
+	})
+}
+
+// SaveCount stores the counter's count for the item, replacing an earlier
+// count of the same counter, and refreshes the item's counted quantity.
+func (r *stockTakeRepository) SaveCount(take *domain.StockTake, item *domain.StockTakeItem, count *domain.StockTakeCount) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the document so counts cannot land after approval
+		var status string
+		if err := tx.Model(&domain.StockTake{}).Clauses(clause.Locking{Strength: "SHARE"}).
+			Where("id = ?", take.ID).Pluck("status", &status).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		if status != domain.StockTakeStatusOpen {
+			return appError.ErrAlreadyProcessed
+		}
+
+		count.StockTakeItemID = item.ID
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "stock_take_item_id"}, {Name: "counter_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"quantity", "updated_at"}),
+		}).Create(count).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		var counted int
+		if err := tx.Model(&domain.StockTakeCount{}).
+			Where("stock_take_item_id = ?", item.ID).
+			Select("COALESCE(SUM(quantity), 0)").Scan(&counted).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		if err := tx.Model(&domain.StockTakeItem{}).Where("id = ?", item.ID).
+			Update("counted_quantity", counted).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		item.CountedQuantity = &counted
+		return nil
+	})
+}
+
+// Approve closes the stock take and posts counted minus current stock of
+// every counted product as an adjustment, all in one transaction. The
+// current stock is read under lock rather than taken from the snapshot, so
+// sales and receipts since the take was opened are not counted twice; what
+// was posted is kept on the item.
+func (r *stockTakeRepository) Approve(take *domain.StockTake) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&domain.StockTake{}).
+			Where("id = ? AND status = ?", take.ID, domain.StockTakeStatusOpen).
+			Updates(map[string]interface{}{
+				"status":      domain.StockTakeStatusApproved,
+				"approved_by": take.ApprovedBy,
+				"approved_at": now,
+			})
+		if result.Error != nil {
+			return appError.ParseMySQLError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return appError.ErrAlreadyProcessed
+		}
+
+		// Re-read the counts inside the transaction, the caller's copy may be stale
+		var items []domain.StockTakeItem
+		if err := tx.Where("stock_take_id = ? AND counted_quantity IS NOT NULL", take.ID).
+			Order("id").Find(&items).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+
+		for _, item := range items {
+			// Products are always locked before their outlet rows, in that order
+			var locked []uint64
+			if err := tx.Model(&domain.Product{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id = ?", item.ProductID).Pluck("id", &locked).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+			var stocks []int
+			if err := tx.Model(&domain.ProductStock{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("product_id = ? AND outlet_id = ?", item.ProductID, take.OutletID).
+				Pluck("stock", &stocks).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+			current := 0
+			if len(stocks) > 0 {
+				current = stocks[0]
+			}
+
+			variance := *item.CountedQuantity - current
+			if err := tx.Model(&domain.StockTakeItem{}).Where("id = ?", item.ID).
+				Update("adjusted_quantity", variance).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+			if variance == 0 {
+				continue
+			}
+			if err := postStock(tx, &domain.InventoryMovement{
+				ProductID:     item.ProductID,
+				OutletID:      take.OutletID,
+				Type:          domain.MovementTypeAdjustment,
+				Quantity:      variance,
+				ReferenceType: "stock_take",
+				ReferenceID:   &take.ID,
+				Note:          "Stock take",
+				UserID:        take.ApprovedBy,
+			}); err != nil {
+				return err
+			}
+		}
+
+		take.Status = domain.StockTakeStatusApproved
+		take.ApprovedAt = &now
+		return nil
+	})
+}
+
+func (r *stockTakeRepository) Cancel(take *domain.StockTake) error {
+	result := r.db.Model(&domain.StockTake{}).
+		Where("id = ? AND status = ?", take.ID, domain.StockTakeStatusOpen).
+		Update("status", domain.StockTakeStatusCancelled)
+	if result.Error != nil {
+		return appError.ParseMySQLError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appError.ErrAlreadyProcessed
+	}
+	take.Status = domain.StockTakeStatusCancelled
+	return nil
+}
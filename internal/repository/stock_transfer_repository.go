@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type StockTransferRepository interface {
+	FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.StockTransfer, int64, error)
+	FindByID(id uint64) (*domain.StockTransfer, error)
+	InTransit(filters map[string]interface{}) ([]domain.InTransitQuantity, error)
+	Create(transfer *domain.StockTransfer) error
+	Ship(transfer *domain.StockTransfer) error
+	Receive(transfer *domain.StockTransfer) error
+	Cancel(transfer *domain.StockTransfer) error
+}
+
+type stockTransferRepository struct {
+	db        *gorm.DB
+	numbering NumberSequenceRepository
+}
+
+func NewStockTransferRepository(db *gorm.DB, numbering NumberSequenceRepository) StockTransferRepository {
+	return &stockTransferRepository{db: db, numbering: numbering}
+}
+
+func (r *stockTransferRepository) FindPaginatedWithFilter(page, limit int, filters map[string]interface{}) ([]domain.StockTransfer, int64, error) {
+	var transfers []domain.StockTransfer
+	var total int64
+
+	offset := (page - 1) * limit
+
+	query := r.db.Model(&domain.StockTransfer{})
+
+	for key, value := range filters {
+		switch key {
+		case "status":
+			query = query.Where("status = ?", value)
+		case "from_outlet_id":
+			query = query.Where("from_outlet_id = ?", value)
+		case "to_outlet_id":
+			query = query.Where("to_outlet_id = ?", value)
+		case "outlet_id":
+			query = query.Where("from_outlet_id = ? OR to_outlet_id = ?", value, value)
+		case "start_date":
+			query = query.Where("created_at >= ?", value)
+		case "end_date":
+			query = query.Where("created_at < ?", value)
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := query.Preload("FromOutlet").Preload("ToOutlet").
+		Order("id DESC").Limit(limit).Offset(offset).Find(&transfers).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return transfers, total, nil
+}
+
+func (r *stockTransferRepository) FindByID(id uint64) (*domain.StockTransfer, error) {
+	var transfer domain.StockTransfer
+	err := r.db.Preload("FromOutlet").Preload("ToOutlet").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Preload("Items.Product").
+		First(&transfer, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &transfer, nil
+}
+
+// InTransit sums the lines of every shipped transfer that has not been
+// received yet, per product and route.
+func (r *stockTransferRepository) InTransit(filters map[string]interface{}) ([]domain.InTransitQuantity, error) {
+	var quantities []domain.InTransitQuantity
+
+	query := r.db.Table("stock_transfer_items").
+		Select("stock_transfer_items.product_id, products.code AS product_code, products.name AS product_name, "+
+			"stock_transfers.from_outlet_id, stock_transfers.to_outlet_id, SUM(stock_transfer_items.quantity) AS quantity").
+		Joins("JOIN stock_transfers ON stock_transfers.id = stock_transfer_items.stock_transfer_id").
+		Joins("JOIN products ON products.id = stock_transfer_items.product_id").
+		Where("stock_transfers.status = ?", domain.StockTransferStatusInTransit)
+
+	for key, value := range filters {
+		switch key {
+		case "product_id":
+			query = query.Where("stock_transfer_items.product_id = ?", value)
+		case "outlet_id":
+			query = query.Where("stock_transfers.from_outlet_id = ? OR stock_transfers.to_outlet_id = ?", value, value)
+		}
+	}
+
+	if err := query.
+		Group("stock_transfer_items.product_id, products.code, products.name, stock_transfers.from_outlet_id, stock_transfers.to_outlet_id").
+		Order("stock_transfer_items.product_id").
+		Scan(&quantities).Error; err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+	return quantities, nil
+}
+
+func (r *stockTransferRepository) Create(transfer *domain.StockTransfer) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		transfer.CreatedAt = time.Now()
+
+		transferNo, err := r.numbering.Next(tx, domain.DocTypeStockTransfer, transfer.FromOutletID, transfer.CreatedAt)
+		if err != nil {
+			return err
+		}
+		transfer.TransferNo = transferNo
+
+		if err := tx.Create(transfer).Error; err != nil {
+			return appError.ParseMySQLError(err)
+		}
+		return nil
+	})
+}
+
+// Ship takes every line out of the source outlet. A line without enough
+// stock fails the whole shipment.
+func (r *stockTransferRepository) Ship(transfer *domain.StockTransfer) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&domain.StockTransfer{}).
+			Where("id = ? AND status = ?", transfer.ID, domain.StockTransferStatusDraft).
+			Updates(map[string]interface{}{
+				"status":     domain.StockTransferStatusInTransit,
+				"shipped_by": transfer.ShippedBy,
+				"shipped_at": now,
+			})
+		if result.Error != nil {
+			return appError.ParseMySQLError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return appError.ErrAlreadyProcessed
+		}
+
+		for _, item := range transfer.Items {
+			if err := postStock(tx, &domain.InventoryMovement{
+				ProductID:     item.ProductID,
+				OutletID:      transfer.FromOutletID,
+				Type:          domain.MovementTypeTransferOut,
+				Quantity:      -item.Quantity,
+				ReferenceType: "stock_transfer",
+				ReferenceID:   &transfer.ID,
+				ReferenceNo:   transfer.TransferNo,
+				UserID:        transfer.ShippedBy,
+			}); err != nil {
+				return err
+			}
+		}
+
+		transfer.Status = domain.StockTransferStatusInTransit
+		transfer.ShippedAt = &now
+		return nil
+	})
+}
+
+// Receive books every shipped line into the destination outlet, then the
+// discrepancy against it: a write-off for units that went missing on the
+// way, an adjustment for units over. The movements of a transfer thus add
+// up to what the destination actually got.
+func (r *stockTransferRepository) Receive(transfer *domain.StockTransfer) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&domain.StockTransfer{}).
+			Where("id = ? AND status = ?", transfer.ID, domain.StockTransferStatusInTransit).
+			Updates(map[string]interface{}{
+				"status":      domain.StockTransferStatusReceived,
+				"received_by": transfer.ReceivedBy,
+				"received_at": now,
+			})
+		if result.Error != nil {
+			return appError.ParseMySQLError(result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return appError.ErrAlreadyProcessed
+		}
+
+		for _, item := range transfer.Items {
+			if err := tx.Model(&domain.StockTransferItem{}).Where("id = ?", item.ID).
+				Updates(map[string]interface{}{
+					"received_quantity":  item.ReceivedQuantity,
+					"discrepancy":        item.Discrepancy,
+					"discrepancy_reason": item.DiscrepancyReason,
+				}).Error; err != nil {
+				return appError.ParseMySQLError(err)
+			}
+
+			// Everything shipped arrives first, keeping the lot and expiry
+			// it was shipped with
+			lots, err := reversedLots(tx, "stock_transfer", transfer.ID, item.ProductID, item.Quantity)
+			if err != nil {
+				return err
+			}
+
+			arrival := &domain.InventoryMovement{
+				ProductID:     item.ProductID,
+				OutletID:      transfer.ToOutletID,
+				Type:          domain.MovementTypeTransferIn,
+				Quantity:      item.Quantity,
+				ReferenceType: "stock_transfer",
+				ReferenceID:   &transfer.ID,
+				ReferenceNo:   transfer.TransferNo,
+				UserID:        transfer.ReceivedBy,
+				Allocations:   lots,
+			}
+			if err := postStock(tx, arrival); err != nil {
+				return err
+			}
+			if item.Discrepancy == 0 {
+				continue
+			}
+
+			// then the discrepancy is booked on its own: units missing are
+			// written off from the lots that just arrived, units over are
+			// an adjustment
+			correction := &domain.InventoryMovement{
+				ProductID:     item.ProductID,
+				OutletID:      transfer.ToOutletID,
+				Type:          domain.MovementTypeAdjustment,
+				Quantity:      item.Discrepancy,
+				ReferenceType: "stock_transfer",
+				ReferenceID:   &transfer.ID,
+				ReferenceNo:   transfer.TransferNo,
+				Note:          item.DiscrepancyReason,
+				UserID:        transfer.ReceivedBy,
+			}
+			if item.Discrepancy < 0 {
+				correction.Type = domain.MovementTypeWriteOff
+				missing := -item.Discrepancy
+				for i := len(arrival.Lots) - 1; i >= 0 && missing > 0; i-- {
+					take := min(arrival.Lots[i].Quantity, missing)
+					correction.Allocations = append(correction.Allocations, domain.LotAllocation{LotID: arrival.Lots[i].LotID, Quantity: take})
+					missing -= take
+				}
+			}
+			if err := postStock(tx, correction); err != nil {
+				return err
+			}
+		}
+
+		transfer.Status = domain.StockTransferStatusReceived
+		transfer.ReceivedAt = &now
+		return nil
+	})
+}
+
+func (r *stockTransferRepository) Cancel(transfer *domain.StockTransfer) error {
+	result := r.db.Model(&domain.StockTransfer{}).
+		Where("id = ? AND status = ?", transfer.ID, domain.StockTransferStatusDraft).
+		Update("status", domain.StockTransferStatusCancelled)
+	if result.Error != nil {
+		return appError.ParseMySQLError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return appError.ErrAlreadyProcessed
+	}
+	transfer.Status = domain.StockTransferStatusCancelled
+	return nil
+}
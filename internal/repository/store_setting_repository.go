@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+type StoreSettingRepository interface {
+	Get() (*domain.StoreSetting, error)
+	Save(setting *domain.StoreSetting) error
+}
+
+type storeSettingRepository struct {
+	db *gorm.DB
+}
+
+func NewStoreSettingRepository(db *gorm.DB) StoreSettingRepository {
+	return &storeSettingRepository{db}
+}
+
+// Get returns the store settings, or nil when they were never saved.
+func (r *storeSettingRepository) Get() (*domain.StoreSetting, error) {
+	var setting domain.StoreSetting
+	err := r.db.Order("id").First(&setting).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &setting, nil
+}
+
+func (r *storeSettingRepository) Save(setting *domain.StoreSetting) error {
+	err := r.db.Save(setting).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
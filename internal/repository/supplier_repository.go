@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+type SupplierRepository interface {
+	FindPaginated(page, limit int) ([]domain.Supplier, int64, error)
+	FindByID(id uint64) (*domain.Supplier, error)
+	Create(supplier *domain.Supplier) error
+	Update(supplier *domain.Supplier) error
+	Delete(supplier *domain.Supplier) error
+}
+
+type supplierRepository struct {
+	db *gorm.DB
+}
+
+func NewSupplierRepository(db *gorm.DB) SupplierRepository {
+	return &supplierRepository{db}
+}
+
+func (r *supplierRepository) FindPaginated(page, limit int) ([]domain.Supplier, int64, error) {
+	var suppliers []domain.Supplier
+	var total int64
+
+	offset := (page - 1) * limit
+	if err := r.db.Model(&domain.Supplier{}).Where("deleted_at IS NULL").Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := r.db.Where("deleted_at IS NULL").Limit(limit).Offset(offset).Find(&suppliers).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return suppliers, total, nil
+}
+
+func (r *supplierRepository) FindByID(id uint64) (*domain.Supplier, error) {
+	var supplier domain.Supplier
+	err := r.db.Where("deleted_at IS NULL").First(&supplier, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &supplier, nil
+}
+
+func (r *supplierRepository) Create(supplier *domain.Supplier) error {
+	err := r.db.Create(supplier).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *supplierRepository) Update(supplier *domain.Supplier) error {
+	// Select is used so a supplier can be deactivated (is_active = false)
+	if err := r.db.Model(&domain.Supplier{}).
+		Where("id = ? AND deleted_at IS NULL", supplier.ID).
+		Select("code", "name", "contact_name", "phone", "email", "address", "is_active").
+		Updates(supplier).Error; err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *supplierRepository) Delete(supplier *domain.Supplier) error {
+	err := r.db.Delete(supplier).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
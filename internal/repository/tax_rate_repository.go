@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+	"gopos/pkg/utils"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+type TaxRateRepository interface {
+	FindPaginated(page, limit int) ([]domain.TaxRate, int64, error)
+	FindByID(id uint64) (*domain.TaxRate, error)
+	Create(rate *domain.TaxRate) error
+	Update(rate *domain.TaxRate) error
+	Delete(rate *domain.TaxRate) error
+	Totals(filters map[string]interface{}) ([]domain.TaxTotal, error)
+}
+
+type taxRateRepository struct {
+	db *gorm.DB
+}
+
+func NewTaxRateRepository(db *gorm.DB) TaxRateRepository {
+	return &taxRateRepository{db}
+}
+
+func (r *taxRateRepository) FindPaginated(page, limit int) ([]domain.TaxRate, int64, error) {
+	var rates []domain.TaxRate
+	var total int64
+
+	offset := (page - 1) * limit
+	if err := r.db.Model(&domain.TaxRate{}).Where("deleted_at IS NULL").Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := r.db.Where("deleted_at IS NULL").Limit(limit).Offset(offset).Find(&rates).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return rates, total, nil
+}
+
+func (r *taxRateRepository) FindByID(id uint64) (*domain.TaxRate, error) {
+	var rate domain.TaxRate
+	err := r.db.Where("deleted_at IS NULL").First(&rate, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &rate, nil
+}
+
+func (r *taxRateRepository) Create(rate *domain.TaxRate) error {
+	err := r.db.Create(rate).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *taxRateRepository) Update(rate *domain.TaxRate) error {
+	// Select is used so a rate can be deactivated (is_active = false)
+	if err := r.db.Model(&domain.TaxRate{}).
+		Where("id = ? AND deleted_at IS NULL", rate.ID).
+		Select("code", "name", "rate", "is_active").
+		Updates(rate).Error; err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *taxRateRepository) Delete(rate *domain.TaxRate) error {
+	err := r.db.Delete(rate).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+// Totals sums the tax collected on booked sales per tax rate, less the tax
+// on lines returned or voided in the period.
+func (r *taxRateRepository) Totals(filters map[string]interface{}) ([]domain.TaxTotal, error) {
+	var totals []domain.TaxTotal
+
+	query := r.db.Table("sale_taxes").
+		Select(`sale_taxes.tax_rate_id, sale_taxes.tax_code AS code, sale_taxes.tax_name AS name, sale_taxes.rate,
+			COUNT(DISTINCT sale_taxes.sale_id) AS count, COALESCE(SUM(sale_taxes.taxable_amount), 0) AS taxable_amount,
+			COALESCE(SUM(sale_taxes.tax_amount), 0) AS tax_amount`).
+		Joins("JOIN sales ON sales.id = sale_taxes.sale_id").
+		Where("sales.status IN ?", domain.SaleStatusesBooked)
+
+	for key, value := range filters {
+		switch key {
+		case "start_date":
+			query = query.Where("sales.created_at >= ?", value)
+		case "end_date":
+			query = query.Where("sales.created_at < ?", value)
+		case "cashier_id":
+			query = query.Where("sales.cashier_id = ?", value)
+		case "shift_id":
+			query = query.Where("sales.shift_id = ?", value)
+		}
+	}
+
+	err := query.
+		Group("sale_taxes.tax_rate_id, sale_taxes.tax_code, sale_taxes.tax_name, sale_taxes.rate").
+		Order("sale_taxes.tax_rate_id").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+
+	returned, err := r.reversedTotals("sale_return_items", "sale_returns", "sale_return_id", "cashier_id", filters)
+	if err != nil {
+		return nil, err
+	}
+	voided, err := r.reversedTotals("sale_void_items", "sale_voids", "sale_void_id", "requested_by", filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return netTaxTotals(totals, returned, voided), nil
+}
+
+// reversedTotals sums the tax on the lines of refund documents per tax
+// rate, named as on the original sale. The period, cashier and shift are
+// the refund's own.
+func (r *taxRateRepository) reversedTotals(itemTable, table, foreignKey, cashierColumn string, filters map[string]interface{}) ([]domain.TaxTotal, error) {
+	var totals []domain.TaxTotal
+
+	query := r.db.Table(itemTable).
+		Select(`sale_taxes.tax_rate_id, sale_taxes.tax_code AS code, sale_taxes.tax_name AS name, sale_taxes.rate,
+			COALESCE(SUM(` + itemTable + `.taxable_amount), 0) AS taxable_amount,
+			COALESCE(SUM(` + itemTable + `.tax_amount), 0) AS tax_amount`).
+		Joins("JOIN " + table + " ON " + table + ".id = " + itemTable + "." + foreignKey).
+		Joins("JOIN sale_taxes ON sale_taxes.sale_id = " + table + ".sale_id AND sale_taxes.tax_rate_id = " + itemTable + ".tax_rate_id")
+
+	for key, value := range filters {
+		switch key {
+		case "start_date":
+			query = query.Where(table+".created_at >= ?", value)
+		case "end_date":
+			query = query.Where(table+".created_at < ?", value)
+		case "cashier_id":
+			query = query.Where(table+"."+cashierColumn+" = ?", value)
+		case "shift_id":
+			query = query.Where(table+".shift_id = ?", value)
+		}
+	}
+
+	if err := query.
+		Group("sale_taxes.tax_rate_id, sale_taxes.tax_code, sale_taxes.tax_name, sale_taxes.rate").
+		Scan(&totals).Error; err != nil {
+		return nil, appError.ParseMySQLError(err)
+	}
+	return totals, nil
+}
+
+// netTaxTotals takes reversed tax off the booked tax of the same rate. A
+// rate only reversed in the period, for sales made before it, comes out
+// negative. Count stays the number of sales.
+func netTaxTotals(booked []domain.TaxTotal, reversals ...[]domain.TaxTotal) []domain.TaxTotal {
+	totals := make([]domain.TaxTotal, len(booked))
+	copy(totals, booked)
+
+	byRate := make(map[uint64]int, len(totals))
+	for i, total := range totals {
+		byRate[total.TaxRateID] = i
+	}
+	for _, reversed := range reversals {
+		for _, total := range reversed {
+			i, ok := byRate[total.TaxRateID]
+			if !ok {
+				totals = append(totals, domain.TaxTotal{TaxRateID: total.TaxRateID, Code: total.Code, Name: total.Name, Rate: total.Rate})
+				i = len(totals) - 1
+				byRate[total.TaxRateID] = i
+			}
+			totals[i].TaxableAmount -= total.TaxableAmount
+			totals[i].TaxAmount -= total.TaxAmount
+		}
+	}
+
+	for i := range totals {
+		totals[i].TaxableAmount = utils.RoundMoney(totals[i].TaxableAmount)
+		totals[i].TaxAmount = utils.RoundMoney(totals[i].TaxAmount)
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].TaxRateID < totals[j].TaxRateID })
+	return totals
+}
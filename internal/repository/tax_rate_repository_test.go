@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"gopos/internal/domain"
+	"reflect"
+	"testing"
+)
+
+func TestNetTaxTotals(t *testing.T) {
+	ppn := domain.TaxTotal{TaxRateID: 1, Code: "PPN", Name: "PPN", Rate: 11}
+	pb1 := domain.TaxTotal{TaxRateID: 2, Code: "PB1", Name: "PB1", Rate: 10}
+
+	with := func(total domain.TaxTotal, count int64, taxable, tax float64) domain.TaxTotal {
+		total.Count, total.TaxableAmount, total.TaxAmount = count, taxable, tax
+		return total
+	}
+
+	tests := []struct {
+		name     string
+		booked   []domain.TaxTotal
+		returned []domain.TaxTotal
+		voided   []domain.TaxTotal
+		want     []domain.TaxTotal
+	}{
+		{
+			name:   "no refunds",
+			booked: []domain.TaxTotal{with(ppn, 3, 300, 33)},
+			want:   []domain.TaxTotal{with(ppn, 3, 300, 33)},
+		},
+		{
+			name:     "returns and voids are netted",
+			booked:   []domain.TaxTotal{with(ppn, 3, 300, 33), with(pb1, 1, 50, 5)},
+			returned: []domain.TaxTotal{with(ppn, 0, 100, 11)},
+			voided:   []domain.TaxTotal{with(ppn, 0, 45.05, 4.95), with(pb1, 0, 50, 5)},
+			want:     []domain.TaxTotal{with(ppn, 3, 154.95, 17.05), with(pb1, 1, 0, 0)},
+		},
+		{
+			name:     "refund of an earlier sale",
+			booked:   []domain.TaxTotal{with(pb1, 2, 100, 10)},
+			returned: []domain.TaxTotal{with(ppn, 0, 100, 11)},
+			want:     []domain.TaxTotal{with(ppn, 0, -100, -11), with(pb1, 2, 100, 10)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := netTaxTotals(tt.booked, tt.returned, tt.voided)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("netTaxTotals = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
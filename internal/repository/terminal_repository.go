@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+type TerminalRepository interface {
+	FindPaginated(page, limit int) ([]domain.Terminal, int64, error)
+	FindByID(id uint64) (*domain.Terminal, error)
+	Create(terminal *domain.Terminal) error
+	Update(terminal *domain.Terminal) error
+	Delete(terminal *domain.Terminal) error
+}
+
+type terminalRepository struct {
+	db *gorm.DB
+}
+
+func NewTerminalRepository(db *gorm.DB) TerminalRepository {
+	return &terminalRepository{db}
+}
+
+func (r *terminalRepository) FindPaginated(page, limit int) ([]domain.Terminal, int64, error) {
+	var terminals []domain.Terminal
+	var total int64
+
+	offset := (page - 1) * limit
+	if err := r.db.Model(&domain.Terminal{}).Where("deleted_at IS NULL").Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := r.db.Preload("Outlet").Where("deleted_at IS NULL").Limit(limit).Offset(offset).Find(&terminals).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return terminals, total, nil
+}
+
+func (r *terminalRepository) FindByID(id uint64) (*domain.Terminal, error) {
+	var terminal domain.Terminal
+	err := r.db.Preload("Outlet").Where("deleted_at IS NULL").First(&terminal, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &terminal, nil
+}
+
+func (r *terminalRepository) Create(terminal *domain.Terminal) error {
+	err := r.db.Create(terminal).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *terminalRepository) Update(terminal *domain.Terminal) error {
+	// Select is used so a terminal can be deactivated (is_active = false)
+	if err := r.db.Model(&domain.Terminal{}).
+		Where("id = ? AND deleted_at IS NULL", terminal.ID).
+		Select("code", "name", "outlet_id", "is_active").
+		Updates(terminal).Error; err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *terminalRepository) Delete(terminal *domain.Terminal) error {
+	err := r.db.Delete(terminal).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
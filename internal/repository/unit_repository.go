@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"errors"
+	"gopos/internal/domain"
+	appError "gopos/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+type UnitRepository interface {
+	FindPaginated(page, limit int) ([]domain.Unit, int64, error)
+	FindByID(id uint64) (*domain.Unit, error)
+	Create(unit *domain.Unit) error
+	Update(unit *domain.Unit) error
+	Delete(unit *domain.Unit) error
+}
+
+type unitRepository struct {
+	db *gorm.DB
+}
+
+func NewUnitRepository(db *gorm.DB) UnitRepository {
+	return &unitRepository{db}
+}
+
+func (r *unitRepository) FindPaginated(page, limit int) ([]domain.Unit, int64, error) {
+	var units []domain.Unit
+	var total int64
+
+	offset := (page - 1) * limit
+	if err := r.db.Model(&domain.Unit{}).Where("deleted_at IS NULL").Count(&total).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	if err := r.db.Where("deleted_at IS NULL").Limit(limit).Offset(offset).Find(&units).Error; err != nil {
+		return nil, 0, appError.ParseMySQLError(err)
+	}
+
+	return units, total, nil
+}
+
+func (r *unitRepository) FindByID(id uint64) (*domain.Unit, error) {
+	var unit domain.Unit
+	err := r.db.Where("deleted_at IS NULL").First(&unit, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, appError.ParseMySQLError(err)
+	}
+	return &unit, nil
+}
+
+func (r *unitRepository) Create(unit *domain.Unit) error {
+	err := r.db.Create(unit).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *unitRepository) Update(unit *domain.Unit) error {
+	// Select is used so a unit can be deactivated (is_active = false)
+	if err := r.db.Model(&domain.Unit{}).
+		Where("id = ? AND deleted_at IS NULL", unit.ID).
+		Select("code", "name", "is_active").
+		Updates(unit).Error; err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
+func (r *unitRepository) Delete(unit *domain.Unit) error {
+	err := r.db.Delete(unit).Error
+	if err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
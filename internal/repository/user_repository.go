@@ -112,6 +112,13 @@ func (r *userRepository) Update(user *domain.User) (*domain.User, error) {
 	return &updatedUser, nil
 }
 
+func (r *userRepository) UpdatePin(id uint, pin string) error {
+	if err := r.db.Model(&domain.User{}).Where("id = ?", id).Update("pin", pin).Error; err != nil {
+		return appError.ParseMySQLError(err)
+	}
+	return nil
+}
+
 // ✅ Soft delete user
 func (r *userRepository) Delete(user *domain.User) error {
 	err := r.db.Delete(user).Error
@@ -1,11 +1,14 @@
 package router
 
 import (
+	"gopos/internal/config"
 	"gopos/internal/delivery/http/handler"
 	"gopos/internal/delivery/http/middleware"
 	"gopos/internal/repository"
 	"gopos/internal/usecase"
 	"gopos/pkg/casbin"
+	"gopos/pkg/storage"
+	"log"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -42,6 +45,7 @@ func LoadRoutes(r *gin.Engine, db *gorm.DB) {
 			users.GET("/:id", userHandler.Detail)
 			users.POST("", userHandler.Create)
 			users.PUT("/:id", userHandler.Update)
+			users.PUT("/:id/pin", userHandler.UpdatePin)
 			users.DELETE("/:id", userHandler.Delete)
 
 		}
@@ -67,22 +71,141 @@ func LoadRoutes(r *gin.Engine, db *gorm.DB) {
 			// authorize.GET("/permission", authorizeHandler.GetAllPermissions)
 		}
 
+		outletRepo := repository.NewOutletRepository(db)
+		outletUC := usecase.NewOutletUsecase(outletRepo)
+		outletHandler := handler.NewOutletHandler(outletUC)
+		outlets := api.Group("/outlets")
+		outlets.Use(middleware.AuthMiddleware())
+		outlets.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			outlets.GET("", outletHandler.FindAll)
+			outlets.GET("/:id", outletHandler.FindByID)
+			outlets.POST("", outletHandler.Create)
+			outlets.PUT("/:id", outletHandler.Update)
+			outlets.DELETE("/:id", outletHandler.Delete)
+		}
+
+		terminalRepo := repository.NewTerminalRepository(db)
+		terminalUC := usecase.NewTerminalUsecase(terminalRepo, outletRepo)
+		terminalHandler := handler.NewTerminalHandler(terminalUC)
+		terminals := api.Group("/terminals")
+		terminals.Use(middleware.AuthMiddleware())
+		terminals.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			terminals.GET("", terminalHandler.FindAll)
+			terminals.GET("/:id", terminalHandler.FindByID)
+			terminals.POST("", terminalHandler.Create)
+			terminals.PUT("/:id", terminalHandler.Update)
+			terminals.DELETE("/:id", terminalHandler.Delete)
+		}
+
+		barcodeLookups := usecase.NewBarcodeLookupCache()
+		unitRepo := repository.NewUnitRepository(db)
+		unitUC := usecase.NewUnitUsecase(unitRepo, barcodeLookups)
+		unitHandler := handler.NewUnitHandler(unitUC)
+		units := api.Group("/units")
+		units.Use(middleware.AuthMiddleware())
+		units.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			units.GET("", unitHandler.FindAll)
+			units.GET("/:id", unitHandler.FindByID)
+			units.POST("", unitHandler.Create)
+			units.PUT("/:id", unitHandler.Update)
+			units.DELETE("/:id", unitHandler.Delete)
+		}
+
 		productRepo := repository.NewProductRepository(db)
-		productUC := usecase.NewProductUsecase(productRepo)
+		categoryRepo := repository.NewCategoryRepository(db)
+		productUC := usecase.NewProductUsecase(productRepo, outletRepo, unitRepo, categoryRepo, barcodeLookups)
 		productHandler := handler.NewProductHandler(productUC)
+		fileStorage, err := storage.NewLocal(config.StorageDir())
+		if err != nil {
+			log.Fatal("Failed to open file storage:", err)
+		}
+		productImageRepo := repository.NewProductImageRepository(db)
+		productImageUC := usecase.NewProductImageUsecase(productImageRepo, productRepo, fileStorage, config.MaxImageSize())
+		productImageHandler := handler.NewProductImageHandler(productImageUC)
+		inventoryRepo := repository.NewInventoryRepository(db)
+		inventoryUC := usecase.NewInventoryUsecase(inventoryRepo, productRepo, outletRepo)
+		inventoryHandler := handler.NewInventoryHandler(inventoryUC)
 		products := api.Group("/products")
 		products.Use(middleware.AuthMiddleware())
 		products.Use(middleware.CasbinMiddleware(enforcer, db))
 		{
 			products.GET("", productHandler.FindAll)
+			products.GET("/barcode/:barcode", productHandler.LookupBarcode)
+			products.GET("/export", productHandler.Export)
+			products.POST("/import", productHandler.Import)
 			products.GET("/:id", productHandler.FindByID)
 			products.POST("", productHandler.Create)
 			products.PUT("/:id", productHandler.Update)
 			products.DELETE("/:id", productHandler.Delete)
+			products.PUT("/:id/variant-attributes", productHandler.SetVariantAttributes)
+			products.POST("/:id/variants", productHandler.CreateVariant)
+			products.PUT("/:id/units", productHandler.SetUnits)
+			products.PUT("/:id/recipe", productHandler.SetRecipe)
+			products.GET("/:id/price-history", productHandler.PriceHistory)
+			products.POST("/:id/barcodes", productHandler.AddBarcode)
+			products.DELETE("/:id/barcodes/:barcode_id", productHandler.RemoveBarcode)
+			products.POST("/:id/images", productImageHandler.Upload)
+			products.GET("/:id/images/:image_id", productImageHandler.Show)
+			products.DELETE("/:id/images/:image_id", productImageHandler.Delete)
+			products.GET("/:id/stock-card", inventoryHandler.StockCard)
+			products.PUT("/:id/stock-levels", inventoryHandler.SetStockLevel)
+			products.GET("/:id/lots", inventoryHandler.FindLots)
 
 		}
 
-		categoryRepo := repository.NewCategoryRepository(db)
+		inventory := api.Group("/inventory")
+		inventory.Use(middleware.AuthMiddleware())
+		inventory.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			inventory.GET("/movements", inventoryHandler.FindAll)
+			inventory.POST("/adjustments", inventoryHandler.Adjust)
+			inventory.GET("/low-stock", inventoryHandler.LowStock)
+			inventory.GET("/alerts", inventoryHandler.FindAlerts)
+			inventory.POST("/alerts/:id/acknowledge", inventoryHandler.AcknowledgeAlert)
+			inventory.GET("/near-expiry", inventoryHandler.NearExpiry)
+		}
+
+		stockTakeRepo := repository.NewStockTakeRepository(db)
+		stockTakeUC := usecase.NewStockTakeUsecase(stockTakeRepo, productRepo, outletRepo)
+		stockTakeHandler := handler.NewStockTakeHandler(stockTakeUC)
+		stockTakes := api.Group("/stock-takes")
+		stockTakes.Use(middleware.AuthMiddleware())
+		stockTakes.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			stockTakes.GET("", stockTakeHandler.FindAll)
+			stockTakes.GET("/:id", stockTakeHandler.FindByID)
+			stockTakes.POST("", stockTakeHandler.Create)
+			stockTakes.POST("/:id/counts", stockTakeHandler.Count)
+			stockTakes.GET("/:id/variances", stockTakeHandler.Variances)
+			stockTakes.POST("/:id/approve", stockTakeHandler.Approve)
+			stockTakes.POST("/:id/cancel", stockTakeHandler.Cancel)
+		}
+
+		numberFormats, err := config.LoadNumberFormats()
+		if err != nil {
+			log.Fatal("Invalid document number format:", err)
+		}
+		numberSequenceRepo := repository.NewNumberSequenceRepository(numberFormats)
+
+		stockTransferRepo := repository.NewStockTransferRepository(db, numberSequenceRepo)
+		stockTransferUC := usecase.NewStockTransferUsecase(stockTransferRepo, productRepo, outletRepo)
+		stockTransferHandler := handler.NewStockTransferHandler(stockTransferUC)
+		stockTransfers := api.Group("/stock-transfers")
+		stockTransfers.Use(middleware.AuthMiddleware())
+		stockTransfers.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			stockTransfers.GET("", stockTransferHandler.FindAll)
+			stockTransfers.GET("/in-transit", stockTransferHandler.InTransit)
+			stockTransfers.GET("/:id", stockTransferHandler.FindByID)
+			stockTransfers.POST("", stockTransferHandler.Create)
+			stockTransfers.POST("/:id/ship", stockTransferHandler.Ship)
+			stockTransfers.POST("/:id/receive", stockTransferHandler.Receive)
+			stockTransfers.POST("/:id/cancel", stockTransferHandler.Cancel)
+		}
+
 		categoryUC := usecase.NewCategoryUsecase(categoryRepo)
 		categoryHandler := handler.NewCategoryHandler(categoryUC)
 		category := api.Group("/category")
@@ -90,12 +213,198 @@ func LoadRoutes(r *gin.Engine, db *gorm.DB) {
 		category.Use(middleware.CasbinMiddleware(enforcer, db))
 		{
 			category.GET("", categoryHandler.FindAll)
+			category.GET("/tree", categoryHandler.Tree)
 			category.GET("/:id", categoryHandler.FindByID)
 			category.POST("", categoryHandler.Create)
 			category.PUT("/:id", categoryHandler.Update)
+			category.PUT("/:id/move", categoryHandler.Move)
 			category.DELETE("/:id", categoryHandler.Delete)
 
 		}
+
+		supplierRepo := repository.NewSupplierRepository(db)
+		supplierUC := usecase.NewSupplierUsecase(supplierRepo)
+		supplierHandler := handler.NewSupplierHandler(supplierUC)
+		suppliers := api.Group("/suppliers")
+		suppliers.Use(middleware.AuthMiddleware())
+		suppliers.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			suppliers.GET("", supplierHandler.FindAll)
+			suppliers.GET("/:id", supplierHandler.FindByID)
+			suppliers.POST("", supplierHandler.Create)
+			suppliers.PUT("/:id", supplierHandler.Update)
+			suppliers.DELETE("/:id", supplierHandler.Delete)
+		}
+
+		purchaseOrderRepo := repository.NewPurchaseOrderRepository(db, numberSequenceRepo)
+		purchaseOrderUC := usecase.NewPurchaseOrderUsecase(purchaseOrderRepo, supplierRepo, productRepo, outletRepo)
+		purchaseOrderHandler := handler.NewPurchaseOrderHandler(purchaseOrderUC)
+		purchaseOrders := api.Group("/purchase-orders")
+		purchaseOrders.Use(middleware.AuthMiddleware())
+		purchaseOrders.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			purchaseOrders.GET("", purchaseOrderHandler.FindAll)
+			purchaseOrders.GET("/:id", purchaseOrderHandler.FindByID)
+			purchaseOrders.POST("", purchaseOrderHandler.Create)
+			purchaseOrders.PUT("/:id", purchaseOrderHandler.Update)
+			purchaseOrders.POST("/:id/send", purchaseOrderHandler.Send)
+			purchaseOrders.POST("/:id/cancel", purchaseOrderHandler.Cancel)
+			purchaseOrders.GET("/:id/receipts", purchaseOrderHandler.FindReceipts)
+			purchaseOrders.POST("/:id/receipts", purchaseOrderHandler.Receive)
+		}
+
+		paymentMethodRepo := repository.NewPaymentMethodRepository(db)
+		paymentMethodUC := usecase.NewPaymentMethodUsecase(paymentMethodRepo)
+		paymentMethodHandler := handler.NewPaymentMethodHandler(paymentMethodUC)
+		paymentMethods := api.Group("/payment-methods")
+		paymentMethods.Use(middleware.AuthMiddleware())
+		paymentMethods.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			paymentMethods.GET("", paymentMethodHandler.FindAll)
+			paymentMethods.GET("/:id", paymentMethodHandler.FindByID)
+			paymentMethods.POST("", paymentMethodHandler.Create)
+			paymentMethods.PUT("/:id", paymentMethodHandler.Update)
+			paymentMethods.DELETE("/:id", paymentMethodHandler.Delete)
+		}
+
+		taxRateRepo := repository.NewTaxRateRepository(db)
+		taxRateUC := usecase.NewTaxRateUsecase(taxRateRepo)
+		taxRateHandler := handler.NewTaxRateHandler(taxRateUC)
+		taxRates := api.Group("/tax-rates")
+		taxRates.Use(middleware.AuthMiddleware())
+		taxRates.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			taxRates.GET("", taxRateHandler.FindAll)
+			taxRates.GET("/summary", taxRateHandler.Summary)
+			taxRates.GET("/:id", taxRateHandler.FindByID)
+			taxRates.POST("", taxRateHandler.Create)
+			taxRates.PUT("/:id", taxRateHandler.Update)
+			taxRates.DELETE("/:id", taxRateHandler.Delete)
+		}
+
+		priceListRepo := repository.NewPriceListRepository(db)
+		customerGroupRepo := repository.NewCustomerGroupRepository(db)
+		priceListUC := usecase.NewPriceListUsecase(priceListRepo, customerGroupRepo, outletRepo, productRepo)
+		priceListHandler := handler.NewPriceListHandler(priceListUC)
+		priceLists := api.Group("/price-lists")
+		priceLists.Use(middleware.AuthMiddleware())
+		priceLists.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			priceLists.GET("", priceListHandler.FindAll)
+			priceLists.GET("/:id", priceListHandler.FindByID)
+			priceLists.POST("", priceListHandler.Create)
+			priceLists.PUT("/:id", priceListHandler.Update)
+			priceLists.PUT("/:id/items", priceListHandler.SetItems)
+			priceLists.DELETE("/:id", priceListHandler.Delete)
+		}
+
+		customerGroupUC := usecase.NewCustomerGroupUsecase(customerGroupRepo)
+		customerGroupHandler := handler.NewCustomerGroupHandler(customerGroupUC)
+		customerGroups := api.Group("/customer-groups")
+		customerGroups.Use(middleware.AuthMiddleware())
+		customerGroups.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			customerGroups.GET("", customerGroupHandler.FindAll)
+			customerGroups.GET("/:id", customerGroupHandler.FindByID)
+			customerGroups.POST("", customerGroupHandler.Create)
+			customerGroups.PUT("/:id", customerGroupHandler.Update)
+			customerGroups.DELETE("/:id", customerGroupHandler.Delete)
+		}
+
+		promotionRepo := repository.NewPromotionRepository(db)
+		promotionUC := usecase.NewPromotionUsecase(promotionRepo, productRepo, priceListUC)
+		promotionHandler := handler.NewPromotionHandler(promotionUC)
+		promotions := api.Group("/promotions")
+		promotions.Use(middleware.AuthMiddleware())
+		promotions.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			promotions.GET("", promotionHandler.FindAll)
+			promotions.GET("/:id", promotionHandler.FindByID)
+			promotions.POST("", promotionHandler.Create)
+			promotions.POST("/evaluate", promotionHandler.Evaluate)
+			promotions.PUT("/:id", promotionHandler.Update)
+			promotions.DELETE("/:id", promotionHandler.Delete)
+		}
+
+		paymentRepo := repository.NewPaymentRepository(db)
+		paymentUC := usecase.NewPaymentUsecase(paymentRepo)
+		paymentHandler := handler.NewPaymentHandler(paymentUC)
+		payments := api.Group("/payments")
+		payments.Use(middleware.AuthMiddleware())
+		payments.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			payments.GET("/summary", paymentHandler.Summary)
+		}
+
+		saleRepo := repository.NewSaleRepository(db, numberSequenceRepo)
+		shiftRepo := repository.NewShiftRepository(db)
+		shiftUC := usecase.NewShiftUsecase(shiftRepo, saleRepo, paymentRepo, terminalRepo, outletRepo)
+		shiftHandler := handler.NewShiftHandler(shiftUC)
+		shifts := api.Group("/shifts")
+		shifts.Use(middleware.AuthMiddleware())
+		shifts.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			shifts.GET("", shiftHandler.FindAll)
+			shifts.GET("/current", shiftHandler.Current)
+			shifts.POST("/open", shiftHandler.Open)
+			shifts.POST("/close", shiftHandler.Close)
+			shifts.POST("/cash-movements", shiftHandler.AddCashMovement)
+			shifts.GET("/:id", shiftHandler.FindByID)
+			shifts.GET("/:id/summary", shiftHandler.Summary)
+		}
+
+		storeSettingRepo := repository.NewStoreSettingRepository(db)
+		saleUC := usecase.NewSaleUsecase(saleRepo, productRepo, paymentMethodRepo, shiftRepo, taxRateRepo, storeSettingRepo, promotionRepo, priceListUC)
+		saleHandler := handler.NewSaleHandler(saleUC)
+		saleReturnRepo := repository.NewSaleReturnRepository(db, numberSequenceRepo)
+		saleReturnUC := usecase.NewSaleReturnUsecase(saleReturnRepo, saleRepo, paymentMethodRepo, shiftRepo)
+		saleReturnHandler := handler.NewSaleReturnHandler(saleReturnUC)
+		saleVoidRepo := repository.NewSaleVoidRepository(db)
+		saleVoidUC := usecase.NewSaleVoidUsecase(saleVoidRepo, saleRepo, paymentMethodRepo, shiftRepo, userRepo, authorizeRepo)
+		saleVoidHandler := handler.NewSaleVoidHandler(saleVoidUC)
+		receiptUC := usecase.NewReceiptUsecase(saleRepo, userRepo, outletRepo, storeSettingRepo)
+		receiptHandler := handler.NewReceiptHandler(receiptUC)
+		sales := api.Group("/sales")
+		sales.Use(middleware.AuthMiddleware())
+		sales.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			sales.GET("", saleHandler.FindAll)
+			sales.GET("/:id", saleHandler.FindByID)
+			sales.POST("", saleHandler.Create)
+			sales.GET("/:id/returns", saleReturnHandler.FindBySale)
+			sales.POST("/:id/returns", saleReturnHandler.Create)
+			sales.GET("/:id/voids", saleVoidHandler.FindBySale)
+			sales.POST("/:id/void", saleVoidHandler.Create)
+			sales.GET("/:id/receipt", receiptHandler.Show)
+		}
+
+		storeSettingUC := usecase.NewStoreSettingUsecase(storeSettingRepo)
+		storeSettingHandler := handler.NewStoreSettingHandler(storeSettingUC)
+		storeSettings := api.Group("/store-settings")
+		storeSettings.Use(middleware.AuthMiddleware())
+		storeSettings.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			storeSettings.GET("", storeSettingHandler.Get)
+			storeSettings.PUT("", storeSettingHandler.Update)
+		}
+
+		draftOrderRepo := repository.NewDraftOrderRepository(db)
+		draftOrderUC := usecase.NewDraftOrderUsecase(draftOrderRepo, productRepo, saleUC)
+		draftOrderHandler := handler.NewDraftOrderHandler(draftOrderUC)
+		draftOrders := api.Group("/draft-orders")
+		draftOrders.Use(middleware.AuthMiddleware())
+		draftOrders.Use(middleware.CasbinMiddleware(enforcer, db))
+		{
+			draftOrders.GET("", draftOrderHandler.FindAll)
+			draftOrders.GET("/:id", draftOrderHandler.FindByID)
+			draftOrders.POST("", draftOrderHandler.Create)
+			draftOrders.POST("/:id/items", draftOrderHandler.AddItem)
+			draftOrders.DELETE("/:id/items/:item_id", draftOrderHandler.RemoveItem)
+			draftOrders.POST("/:id/park", draftOrderHandler.Park)
+			draftOrders.POST("/:id/resume", draftOrderHandler.Resume)
+			draftOrders.POST("/:id/checkout", draftOrderHandler.Checkout)
+			draftOrders.DELETE("/:id", draftOrderHandler.Discard)
+		}
 	}
 
 }
@@ -4,17 +4,24 @@ import (
 	"gopos/internal/domain"
 	"gopos/internal/repository"
 	appErr "gopos/pkg/errors"
+	"strings"
 )
 
 type CategoryUsecase interface {
 	FindPaginated(page, limit int) ([]domain.Category, int64, error)
 	FindAll() ([]domain.Category, error)
 	FindByID(id uint64) (*domain.Category, error)
+	Tree(rootID *uint64) ([]domain.Category, error)
 	Create(category *domain.Category) error
 	Update(category *domain.Category) error
+	Move(id uint64, req *domain.CategoryMoveRequest) (*domain.Category, error)
 	Delete(category *domain.Category) error
 }
 
+// categoryPathSeparator joins the names of a category path, as in
+// Beverages > Coffee > Espresso.
+const categoryPathSeparator = " > "
+
 type categoryUsecase struct {
 	categoryRepo repository.CategoryRepository
 }
@@ -33,30 +40,185 @@ func (u *categoryUsecase) FindAll() ([]domain.Category, error) {
 	return u.categoryRepo.FindAll()
 }
 
+// FindByID returns the category with its breadcrumbs and path.
 func (u *categoryUsecase) FindByID(id uint64) (*domain.Category, error) {
 	category, err := u.categoryRepo.FindByID(id)
 	if err != nil || category == nil {
 		return nil, appErr.Get(appErr.ErrCategoryShow, err)
 	}
+
+	categories, err := u.categoryRepo.FindAll()
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrCategoryShow, err)
+	}
+	category.Breadcrumbs = categoryBreadcrumbs(categories, category.ID)
+	category.Path = categoryPath(category.Breadcrumbs)
 	return category, nil
 }
 
+// Tree returns the top categories with their subcategories nested in
+// Children, or with rootID only that category and what lies below it.
+// Siblings are sorted by name.
+func (u *categoryUsecase) Tree(rootID *uint64) ([]domain.Category, error) {
+	categories, err := u.categoryRepo.FindAll()
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrCategoryList, err)
+	}
+
+	byID := make(map[uint64]domain.Category, len(categories))
+	children := make(map[uint64][]uint64, len(categories))
+	var roots []uint64
+	for _, category := range categories {
+		byID[category.ID] = category
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category.ID)
+		} else {
+			roots = append(roots, category.ID)
+		}
+	}
+
+	if rootID != nil {
+		if _, ok := byID[*rootID]; !ok {
+			return nil, appErr.Get(appErr.ErrCategoryShow, nil)
+		}
+		roots = []uint64{*rootID}
+	}
+
+	var build func(id uint64) domain.Category
+	build = func(id uint64) domain.Category {
+		category := byID[id]
+		for _, child := range children[id] {
+			category.Children = append(category.Children, build(child))
+		}
+		return category
+	}
+
+	tree := make([]domain.Category, 0, len(roots))
+	for _, id := range roots {
+		tree = append(tree, build(id))
+	}
+	return tree, nil
+}
+
 func (u *categoryUsecase) Create(category *domain.Category) error {
-	return u.categoryRepo.Create(category)
+	if err := u.checkParent(category.ParentID); err != nil {
+		return err
+	}
+	if err := u.categoryRepo.Create(category); err != nil {
+		return appErr.Get(appErr.ErrCategoryCreate, err)
+	}
+	return nil
 }
 
+// Update changes the name and tax rate of a category; Move changes where
+// it sits.
 func (u *categoryUsecase) Update(category *domain.Category) error {
-	category, err := u.categoryRepo.FindByID(category.ID)
-	if err != nil || category == nil {
+	existing, err := u.categoryRepo.FindByID(category.ID)
+	if err != nil || existing == nil {
 		return appErr.Get(appErr.ErrCategoryShow, err)
 	}
-	return u.categoryRepo.Update(category)
+	category.ParentID = existing.ParentID
+
+	if err := u.categoryRepo.Update(category); err != nil {
+		return appErr.Get(appErr.ErrCategoryUpdate, err)
+	}
+	return nil
 }
 
+// Move puts a category, with everything below it, under another category
+// or at the top. A category cannot go under itself or its own
+// subcategories.
+func (u *categoryUsecase) Move(id uint64, req *domain.CategoryMoveRequest) (*domain.Category, error) {
+	category, err := u.categoryRepo.FindByID(id)
+	if err != nil || category == nil {
+		return nil, appErr.Get(appErr.ErrCategoryShow, err)
+	}
+	if err := u.checkParent(req.ParentID); err != nil {
+		return nil, err
+	}
+
+	if err := u.categoryRepo.Move(category.ID, req.ParentID); err != nil {
+		if appErr.Is(err, appErr.ErrCategoryCycle) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrCategoryUpdate, err)
+	}
+
+	return u.FindByID(category.ID)
+}
+
+// Delete refuses a category with subcategories; they are moved or deleted
+// first.
 func (u *categoryUsecase) Delete(category *domain.Category) error {
 	category, err := u.categoryRepo.FindByID(category.ID)
 	if err != nil || category == nil {
 		return appErr.Get(appErr.ErrCategoryShow, err)
 	}
-	return u.categoryRepo.Delete(category)
+
+	children, err := u.categoryRepo.CountChildren(category.ID)
+	if err != nil {
+		return appErr.Get(appErr.ErrCategoryDelete, err)
+	}
+	if children > 0 {
+		return appErr.Get(appErr.ErrCategoryHasChildren, nil)
+	}
+
+	if err := u.categoryRepo.Delete(category); err != nil {
+		return appErr.Get(appErr.ErrCategoryDelete, err)
+	}
+	return nil
+}
+
+func (u *categoryUsecase) checkParent(parentID *uint64) error {
+	if parentID == nil {
+		return nil
+	}
+	parent, err := u.categoryRepo.FindByID(*parentID)
+	if err != nil || parent == nil {
+		return appErr.Get(appErr.ErrCategoryShow, err)
+	}
+	return nil
+}
+
+// categoryBreadcrumbs returns the path from the top category down to id.
+func categoryBreadcrumbs(categories []domain.Category, id uint64) []domain.CategoryBreadcrumb {
+	return breadcrumbsOf(categoriesByID(categories), id)
+}
+
+// categoryPaths returns the path of every category, by ID.
+func categoryPaths(categories []domain.Category) map[uint64]string {
+	byID := categoriesByID(categories)
+	paths := make(map[uint64]string, len(categories))
+	for _, category := range categories {
+		paths[category.ID] = categoryPath(breadcrumbsOf(byID, category.ID))
+	}
+	return paths
+}
+
+func categoryPath(breadcrumbs []domain.CategoryBreadcrumb) string {
+	names := make([]string, len(breadcrumbs))
+	for i, breadcrumb := range breadcrumbs {
+		names[i] = breadcrumb.Name
+	}
+	return strings.Join(names, categoryPathSeparator)
+}
+
+func categoriesByID(categories []domain.Category) map[uint64]*domain.Category {
+	byID := make(map[uint64]*domain.Category, len(categories))
+	for i := range categories {
+		byID[categories[i].ID] = &categories[i]
+	}
+	return byID
+}
+
+func breadcrumbsOf(byID map[uint64]*domain.Category, id uint64) []domain.CategoryBreadcrumb {
+	var breadcrumbs []domain.CategoryBreadcrumb
+	for category := byID[id]; category != nil && len(breadcrumbs) < len(byID); {
+		breadcrumbs = append([]domain.CategoryBreadcrumb{{ID: category.ID, Name: category.Name}}, breadcrumbs...)
+		if category.ParentID == nil {
+			break
+		}
+		category = byID[*category.ParentID]
+	}
+	return breadcrumbs
 }
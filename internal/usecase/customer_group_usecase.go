@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+)
+
+type CustomerGroupUsecase interface {
+	FindPaginated(page, limit int) ([]domain.CustomerGroup, int64, error)
+	FindByID(id uint64) (*domain.CustomerGroup, error)
+	Create(customerGroup *domain.CustomerGroup) error
+	Update(customerGroup *domain.CustomerGroup) error
+	Delete(id uint64) error
+}
+
+type customerGroupUsecase struct {
+	customerGroupRepo repository.CustomerGroupRepository
+}
+
+func NewCustomerGroupUsecase(customerGroupRepo repository.CustomerGroupRepository) CustomerGroupUsecase {
+	return &customerGroupUsecase{
+		customerGroupRepo: customerGroupRepo,
+	}
+}
+
+func (u *customerGroupUsecase) FindPaginated(page, limit int) ([]domain.CustomerGroup, int64, error) {
+	customerGroups, total, err := u.customerGroupRepo.FindPaginated(page, limit)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrCustomerGroupList, err)
+	}
+	return customerGroups, total, nil
+}
+
+func (u *customerGroupUsecase) FindByID(id uint64) (*domain.CustomerGroup, error) {
+	customerGroup, err := u.customerGroupRepo.FindByID(id)
+	if err != nil || customerGroup == nil {
+		return nil, appErr.Get(appErr.ErrCustomerGroupShow, err)
+	}
+	return customerGroup, nil
+}
+
+func (u *customerGroupUsecase) Create(customerGroup *domain.CustomerGroup) error {
+	if err := u.customerGroupRepo.Create(customerGroup); err != nil {
+		return appErr.Get(appErr.ErrCustomerGroupCreate, err)
+	}
+	return nil
+}
+
+func (u *customerGroupUsecase) Update(customerGroup *domain.CustomerGroup) error {
+	existing, err := u.customerGroupRepo.FindByID(customerGroup.ID)
+	if err != nil || existing == nil {
+		return appErr.Get(appErr.ErrCustomerGroupShow, err)
+	}
+
+	if err := u.customerGroupRepo.Update(customerGroup); err != nil {
+		return appErr.Get(appErr.ErrCustomerGroupUpdate, err)
+	}
+	return nil
+}
+
+func (u *customerGroupUsecase) Delete(id uint64) error {
+	customerGroup, err := u.customerGroupRepo.FindByID(id)
+	if err != nil || customerGroup == nil {
+		return appErr.Get(appErr.ErrCustomerGroupShow, err)
+	}
+
+	if err := u.customerGroupRepo.Delete(customerGroup); err != nil {
+		return appErr.Get(appErr.ErrCustomerGroupDelete, err)
+	}
+	return nil
+}
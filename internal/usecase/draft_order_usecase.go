@@ -0,0 +1,295 @@
+package usecase
+
+import (
+	"fmt"
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DraftOrderUsecase interface {
+	FindPaginated(c *gin.Context) ([]domain.DraftOrder, int64, error)
+	FindByID(id uint64) (*domain.DraftOrder, error)
+	Create(req *domain.CreateDraftOrderRequest) (*domain.DraftOrder, error)
+	AddItem(orderID uint64, req *domain.SaleItemRequest) (*domain.DraftOrder, error)
+	RemoveItem(orderID, itemID uint64) (*domain.DraftOrder, error)
+	Park(id uint64, userID uint) (*domain.DraftOrder, error)
+	Resume(id uint64, userID uint) (*domain.DraftOrder, error)
+	Discard(id uint64, userID uint) error
+	Checkout(id uint64, req *domain.CheckoutDraftOrderRequest) (*domain.Sale, error)
+}
+
+type draftOrderUsecase struct {
+	draftOrderRepo repository.DraftOrderRepository
+	productRepo    repository.ProductRepository
+	saleUC         SaleUsecase
+}
+
+func NewDraftOrderUsecase(draftOrderRepo repository.DraftOrderRepository, productRepo repository.ProductRepository, saleUC SaleUsecase) DraftOrderUsecase {
+	return &draftOrderUsecase{
+		draftOrderRepo: draftOrderRepo,
+		productRepo:    productRepo,
+		saleUC:         saleUC,
+	}
+}
+
+func (u *draftOrderUsecase) FindPaginated(c *gin.Context) ([]domain.DraftOrder, int64, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	filters := map[string]interface{}{}
+
+	// Query params: ?status=open,parked&cashier_id=1&label=table
+	status := c.DefaultQuery("status", domain.DraftOrderStatusParked)
+	filters["status"] = strings.Split(status, ",")
+
+	if cashierID := c.Query("cashier_id"); cashierID != "" {
+		if id, err := strconv.Atoi(cashierID); err == nil {
+			filters["cashier_id"] = id
+		}
+	}
+
+	if label := c.Query("label"); label != "" {
+		filters["label"] = label
+	}
+
+	orders, total, err := u.draftOrderRepo.FindPaginatedWithFilter(page, limit, filters)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrDraftOrderList, err)
+	}
+
+	return orders, total, nil
+}
+
+func (u *draftOrderUsecase) FindByID(id uint64) (*domain.DraftOrder, error) {
+	order, err := u.draftOrderRepo.FindByID(id)
+	if err != nil || order == nil {
+		return nil, appErr.Get(appErr.ErrDraftOrderShow, err)
+	}
+	return order, nil
+}
+
+func (u *draftOrderUsecase) Create(req *domain.CreateDraftOrderRequest) (*domain.DraftOrder, error) {
+	order := &domain.DraftOrder{
+		CashierID: req.CashierID,
+		Status:    domain.DraftOrderStatusOpen,
+		Label:     req.Label,
+		Note:      req.Note,
+	}
+
+	// Repeated products in the same unit are merged into one line
+	type lineKey struct {
+		productID uint64
+		unitID    uint64
+	}
+	lines := make(map[lineKey]int, len(req.Items))
+	for _, line := range req.Items {
+		key := lineKey{productID: line.ProductID}
+		if line.UnitID != nil {
+			key.unitID = *line.UnitID
+		}
+		if i, ok := lines[key]; ok {
+			order.Items[i].Quantity += line.Quantity
+			continue
+		}
+
+		if err := u.checkProduct(line.ProductID, line.UnitID); err != nil {
+			return nil, err
+		}
+		lines[key] = len(order.Items)
+		order.Items = append(order.Items, domain.DraftOrderItem{
+			ProductID: line.ProductID,
+			UnitID:    line.UnitID,
+			Quantity:  line.Quantity,
+		})
+	}
+
+	if err := u.draftOrderRepo.Create(order); err != nil {
+		return nil, appErr.Get(appErr.ErrDraftOrderCreate, err)
+	}
+
+	return u.FindByID(order.ID)
+}
+
+func (u *draftOrderUsecase) AddItem(orderID uint64, req *domain.SaleItemRequest) (*domain.DraftOrder, error) {
+	if _, err := u.findOpen(orderID); err != nil {
+		return nil, err
+	}
+
+	if err := u.checkProduct(req.ProductID, req.UnitID); err != nil {
+		return nil, err
+	}
+
+	item := &domain.DraftOrderItem{
+		DraftOrderID: orderID,
+		ProductID:    req.ProductID,
+		UnitID:       req.UnitID,
+		Quantity:     req.Quantity,
+	}
+	if err := u.draftOrderRepo.AddItem(item); err != nil {
+		return nil, appErr.Get(appErr.ErrDraftOrderUpdate, err)
+	}
+
+	return u.FindByID(orderID)
+}
+
+func (u *draftOrderUsecase) RemoveItem(orderID, itemID uint64) (*domain.DraftOrder, error) {
+	if _, err := u.findOpen(orderID); err != nil {
+		return nil, err
+	}
+
+	if err := u.draftOrderRepo.RemoveItem(orderID, itemID); err != nil {
+		return nil, appErr.Get(appErr.ErrDraftOrderUpdate, err)
+	}
+
+	return u.FindByID(orderID)
+}
+
+func (u *draftOrderUsecase) Park(id uint64, userID uint) (*domain.DraftOrder, error) {
+	order, err := u.findOpen(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(order.Items) == 0 {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("draft order %d has no items", order.ID))
+	}
+
+	parkedAt := time.Now()
+	order.Status = domain.DraftOrderStatusParked
+	order.CashierID = userID
+	order.ParkedAt = &parkedAt
+
+	if err := u.draftOrderRepo.UpdateStatus(order, domain.DraftOrderStatusOpen); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrDraftOrderUpdate, err)
+	}
+
+	return order, nil
+}
+
+// Resume reopens a parked draft for the cashier resuming it, which may be a
+// different cashier on another terminal than the one who parked it.
+func (u *draftOrderUsecase) Resume(id uint64, userID uint) (*domain.DraftOrder, error) {
+	order, err := u.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != domain.DraftOrderStatusParked {
+		return nil, appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("draft order %d is %s", order.ID, order.Status))
+	}
+
+	order.Status = domain.DraftOrderStatusOpen
+	order.CashierID = userID
+	order.ParkedAt = nil
+
+	if err := u.draftOrderRepo.UpdateStatus(order, domain.DraftOrderStatusParked); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrDraftOrderUpdate, err)
+	}
+
+	return order, nil
+}
+
+func (u *draftOrderUsecase) Discard(id uint64, userID uint) error {
+	order, err := u.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != domain.DraftOrderStatusOpen && order.Status != domain.DraftOrderStatusParked {
+		return appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("draft order %d is %s", order.ID, order.Status))
+	}
+
+	order.Status = domain.DraftOrderStatusDiscarded
+	order.CashierID = userID
+
+	if err := u.draftOrderRepo.UpdateStatus(order, domain.DraftOrderStatusOpen, domain.DraftOrderStatusParked); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) {
+			return err
+		}
+		return appErr.Get(appErr.ErrDraftOrderUpdate, err)
+	}
+
+	return nil
+}
+
+// Checkout converts the draft into a completed sale. Stock is only taken
+// here, through the regular sale flow.
+func (u *draftOrderUsecase) Checkout(id uint64, req *domain.CheckoutDraftOrderRequest) (*domain.Sale, error) {
+	order, err := u.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != domain.DraftOrderStatusOpen && order.Status != domain.DraftOrderStatusParked {
+		return nil, appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("draft order %d is %s", order.ID, order.Status))
+	}
+
+	if len(order.Items) == 0 {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("draft order %d has no items", order.ID))
+	}
+
+	note := req.Note
+	if note == "" {
+		note = order.Note
+	}
+
+	saleReq := &domain.CreateSaleRequest{
+		Payments:        req.Payments,
+		CustomerGroupID: req.CustomerGroupID,
+		Note:            note,
+		CashierID:       req.CashierID,
+		DraftOrderID:    &order.ID,
+	}
+	for _, item := range order.Items {
+		saleReq.Items = append(saleReq.Items, domain.SaleItemRequest{
+			ProductID: item.ProductID,
+			UnitID:    item.UnitID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	return u.saleUC.Create(saleReq)
+}
+
+func (u *draftOrderUsecase) findOpen(id uint64) (*domain.DraftOrder, error) {
+	order, err := u.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != domain.DraftOrderStatusOpen {
+		return nil, appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("draft order %d is %s", order.ID, order.Status))
+	}
+
+	return order, nil
+}
+
+func (u *draftOrderUsecase) checkProduct(productID uint64, unitID *uint64) error {
+	product, err := u.productRepo.FindByID(productID)
+	if err != nil || product == nil {
+		return appErr.Get(appErr.ErrProductShow, err)
+	}
+
+	if !product.IsActive {
+		return appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("product %s is not active", product.Code))
+	}
+
+	if err := requireSellable(product); err != nil {
+		return err
+	}
+
+	_, err = resolveUnit(product, unitID)
+	return err
+}
@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	"fmt"
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type InventoryUsecase interface {
+	FindPaginated(c *gin.Context) ([]domain.InventoryMovement, int64, error)
+	StockCard(c *gin.Context, productID uint64) ([]domain.InventoryMovement, int64, error)
+	Adjust(req *domain.StockAdjustmentRequest) (*domain.InventoryMovement, error)
+	LowStock(c *gin.Context) ([]domain.LowStockItem, int64, error)
+	FindAlerts(c *gin.Context) ([]domain.StockAlert, int64, error)
+	AcknowledgeAlert(id uint64, userID uint) (*domain.StockAlert, error)
+	SetStockLevel(productID uint64, req *domain.StockLevelRequest) (*domain.Product, error)
+	FindLots(c *gin.Context, productID uint64) ([]domain.ProductLot, error)
+	NearExpiry(c *gin.Context) ([]domain.NearExpiryItem, int64, error)
+}
+
+type inventoryUsecase struct {
+	inventoryRepo repository.InventoryRepository
+	productRepo   repository.ProductRepository
+	outletRepo    repository.OutletRepository
+}
+
+func NewInventoryUsecase(inventoryRepo repository.InventoryRepository, productRepo repository.ProductRepository, outletRepo repository.OutletRepository) InventoryUsecase {
+	return &inventoryUsecase{
+		inventoryRepo: inventoryRepo,
+		productRepo:   productRepo,
+		outletRepo:    outletRepo,
+	}
+}
+
+func (u *inventoryUsecase) FindPaginated(c *gin.Context) ([]domain.InventoryMovement, int64, error) {
+	filters := map[string]interface{}{}
+
+	if productID := c.Query("product_id"); productID != "" {
+		if id, err := strconv.Atoi(productID); err == nil {
+			filters["product_id"] = id
+		}
+	}
+
+	return u.findMovements(c, filters)
+}
+
+// StockCard lists the movements of one product in the order they were
+// posted, each with the stock balance it left behind in its outlet. Pass
+// outlet_id for the card of a single outlet.
+func (u *inventoryUsecase) StockCard(c *gin.Context, productID uint64) ([]domain.InventoryMovement, int64, error) {
+	product, err := u.productRepo.FindByID(productID)
+	if err != nil || product == nil {
+		return nil, 0, appErr.Get(appErr.ErrProductShow, err)
+	}
+
+	return u.findMovements(c, map[string]interface{}{"product_id": product.ID})
+}
+
+func (u *inventoryUsecase) findMovements(c *gin.Context, filters map[string]interface{}) ([]domain.InventoryMovement, int64, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	// Query params: ?start_date=2025-01-01&end_date=2025-01-31&type=sale&outlet_id=1
+	dateRangeFilters(c, filters)
+
+	if outletID := c.Query("outlet_id"); outletID != "" {
+		if id, err := strconv.Atoi(outletID); err == nil {
+			filters["outlet_id"] = id
+		}
+	}
+
+	if movementType := c.Query("type"); movementType != "" {
+		filters["type"] = movementType
+	}
+
+	movements, total, err := u.inventoryRepo.FindPaginatedWithFilter(page, limit, filters)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrInventoryList, err)
+	}
+
+	return movements, total, nil
+}
+
+func (u *inventoryUsecase) Adjust(req *domain.StockAdjustmentRequest) (*domain.InventoryMovement, error) {
+	product, err := u.productRepo.FindByID(req.ProductID)
+	if err != nil || product == nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
+	if err := requireStockItem(product); err != nil {
+		return nil, err
+	}
+
+	outlet, err := resolveOutlet(u.outletRepo, req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity := req.Quantity
+	if req.Type == domain.MovementTypeWriteOff {
+		if quantity < 0 {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("write-off quantity must be positive"))
+		}
+		quantity = -quantity
+	}
+
+	movement := &domain.InventoryMovement{
+		ProductID:     product.ID,
+		OutletID:      outlet.ID,
+		Type:          req.Type,
+		Quantity:      quantity,
+		ReferenceType: req.Type,
+		Note:          req.Note,
+		UserID:        &req.UserID,
+	}
+
+	if req.LotID != nil {
+		lot, err := u.inventoryRepo.FindLotByID(*req.LotID)
+		if err != nil || lot == nil {
+			return nil, appErr.Get(appErr.ErrLotShow, err)
+		}
+		if lot.ProductID != product.ID || lot.OutletID != outlet.ID {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("lot %d does not hold product %s in outlet %s", lot.ID, product.Code, outlet.Code))
+		}
+
+		allocation := domain.LotAllocation{LotID: lot.ID, LotNo: lot.LotNo, ExpiryDate: lot.ExpiryDate, Quantity: quantity}
+		if quantity < 0 {
+			allocation.Quantity = -quantity
+		}
+		movement.Allocations = []domain.LotAllocation{allocation}
+	}
+
+	if err := u.inventoryRepo.Post(movement); err != nil {
+		if appErr.Is(err, appErr.ErrInsufficientStock) || appErr.Is(err, appErr.ErrStockExpired) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrInventoryAdjust, err)
+	}
+
+	return movement, nil
+}
+
+// LowStock reports the products at or below their minimum stock in every
+// outlet, with the quantity to reorder.
+func (u *inventoryUsecase) LowStock(c *gin.Context) ([]domain.LowStockItem, int64, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	filters := map[string]interface{}{}
+
+	// Query params: ?outlet_id=1&category_id=1
+	for _, key := range []string{"outlet_id", "category_id"} {
+		if value := c.Query(key); value != "" {
+			if id, err := strconv.ParseUint(value, 10, 64); err == nil {
+				filters[key] = id
+			}
+		}
+	}
+
+	items, total, err := u.inventoryRepo.LowStock(page, limit, filters)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrLowStockReport, err)
+	}
+
+	return items, total, nil
+}
+
+func (u *inventoryUsecase) FindAlerts(c *gin.Context) ([]domain.StockAlert, int64, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	filters := map[string]interface{}{}
+
+	// Query params: ?status=open&product_id=1&outlet_id=1&start_date=2025-01-01&end_date=2025-01-31
+	dateRangeFilters(c, filters)
+
+	if status := c.Query("status"); status != "" {
+		filters["status"] = status
+	}
+
+	for _, key := range []string{"product_id", "outlet_id"} {
+		if value := c.Query(key); value != "" {
+			if id, err := strconv.ParseUint(value, 10, 64); err == nil {
+				filters[key] = id
+			}
+		}
+	}
+
+	alerts, total, err := u.inventoryRepo.FindAlerts(page, limit, filters)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrStockAlertList, err)
+	}
+
+	return alerts, total, nil
+}
+
+func (u *inventoryUsecase) AcknowledgeAlert(id uint64, userID uint) (*domain.StockAlert, error) {
+	alert, err := u.inventoryRepo.FindAlertByID(id)
+	if err != nil || alert == nil {
+		return nil, appErr.Get(appErr.ErrStockAlertShow, err)
+	}
+
+	if alert.Status != domain.StockAlertStatusOpen {
+		return nil, appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("stock alert %d is %s", alert.ID, alert.Status))
+	}
+
+	alert.AcknowledgedBy = &userID
+	if err := u.inventoryRepo.AcknowledgeAlert(alert); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrStockAlertUpdate, err)
+	}
+
+	return alert, nil
+}
+
+// SetStockLevel changes the minimum stock and reorder quantity of a product,
+// for every outlet or for the one in req.OutletID. Existing alerts are left
+// as they are; the new minimum applies from the next movement on.
+func (u *inventoryUsecase) SetStockLevel(productID uint64, req *domain.StockLevelRequest) (*domain.Product, error) {
+	product, err := u.productRepo.FindByID(productID)
+	if err != nil || product == nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
+
+	if req.OutletID != nil {
+		outlet, err := u.outletRepo.FindByID(*req.OutletID)
+		if err != nil || outlet == nil {
+			return nil, appErr.Get(appErr.ErrOutletShow, err)
+		}
+	}
+
+	if err := u.productRepo.SetStockLevel(product.ID, req); err != nil {
+		return nil, appErr.Get(appErr.ErrStockLevelUpdate, err)
+	}
+
+	if product, err = u.productRepo.FindByID(product.ID); err != nil || product == nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
+	if product.Stocks, err = u.productRepo.FindStocks(product.ID); err != nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
+	return product, nil
+}
+
+// FindLots lists the lots of a product, soonest to expire first. Pass
+// outlet_id for one outlet and in_stock=true to skip empty lots.
+func (u *inventoryUsecase) FindLots(c *gin.Context, productID uint64) ([]domain.ProductLot, error) {
+	product, err := u.productRepo.FindByID(productID)
+	if err != nil || product == nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
+
+	filters := map[string]interface{}{}
+
+	// Query params: ?outlet_id=1&in_stock=true
+	if outletID := c.Query("outlet_id"); outletID != "" {
+		if id, err := strconv.ParseUint(outletID, 10, 64); err == nil {
+			filters["outlet_id"] = id
+		}
+	}
+	if inStock, err := strconv.ParseBool(c.Query("in_stock")); err == nil && inStock {
+		filters["in_stock"] = true
+	}
+
+	lots, err := u.inventoryRepo.FindLots(product.ID, filters)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrLotList, err)
+	}
+	return lots, nil
+}
+
+// NearExpiry reports the lots in stock that expire within the given number
+// of days, 30 by default, together with the ones already expired.
+func (u *inventoryUsecase) NearExpiry(c *gin.Context) ([]domain.NearExpiryItem, int64, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	filters := map[string]interface{}{}
+
+	// Query params: ?days=30&outlet_id=1&product_id=1&category_id=1
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 0 {
+		return nil, 0, appErr.Get(appErr.ErrValidation, fmt.Errorf("days cannot be negative"))
+	}
+	today := time.Now()
+	filters["before"] = time.Date(today.Year(), today.Month(), today.Day()+days+1, 0, 0, 0, 0, time.Local).Format("2006-01-02")
+
+	for _, key := range []string{"outlet_id", "product_id", "category_id"} {
+		if value := c.Query(key); value != "" {
+			if id, err := strconv.ParseUint(value, 10, 64); err == nil {
+				filters[key] = id
+			}
+		}
+	}
+
+	items, total, err := u.inventoryRepo.NearExpiry(page, limit, filters)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrNearExpiryReport, err)
+	}
+	return items, total, nil
+}
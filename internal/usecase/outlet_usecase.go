@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"fmt"
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+)
+
+type OutletUsecase interface {
+	FindPaginated(page, limit int) ([]domain.Outlet, int64, error)
+	FindByID(id uint64) (*domain.Outlet, error)
+	Create(outlet *domain.Outlet) error
+	Update(outlet *domain.Outlet) error
+	Delete(id uint64) error
+}
+
+type outletUsecase struct {
+	outletRepo repository.OutletRepository
+}
+
+func NewOutletUsecase(outletRepo repository.OutletRepository) OutletUsecase {
+	return &outletUsecase{
+		outletRepo: outletRepo,
+	}
+}
+
+func (u *outletUsecase) FindPaginated(page, limit int) ([]domain.Outlet, int64, error) {
+	outlets, total, err := u.outletRepo.FindPaginated(page, limit)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrOutletList, err)
+	}
+	return outlets, total, nil
+}
+
+func (u *outletUsecase) FindByID(id uint64) (*domain.Outlet, error) {
+	outlet, err := u.outletRepo.FindByID(id)
+	if err != nil || outlet == nil {
+		return nil, appErr.Get(appErr.ErrOutletShow, err)
+	}
+	return outlet, nil
+}
+
+func (u *outletUsecase) Create(outlet *domain.Outlet) error {
+	if outlet.Type == "" {
+		outlet.Type = domain.OutletTypeStore
+	}
+
+	if err := u.outletRepo.Create(outlet); err != nil {
+		return appErr.Get(appErr.ErrOutletCreate, err)
+	}
+	return nil
+}
+
+func (u *outletUsecase) Update(outlet *domain.Outlet) error {
+	existing, err := u.outletRepo.FindByID(outlet.ID)
+	if err != nil || existing == nil {
+		return appErr.Get(appErr.ErrOutletShow, err)
+	}
+
+	if outlet.Type == "" {
+		outlet.Type = existing.Type
+	}
+
+	// There is always a default outlet; it moves by making another one default
+	if existing.IsDefault && !outlet.IsDefault {
+		return appErr.Get(appErr.ErrValidation, fmt.Errorf("outlet %s is the default outlet", existing.Code))
+	}
+
+	if err := u.outletRepo.Update(outlet); err != nil {
+		return appErr.Get(appErr.ErrOutletUpdate, err)
+	}
+	return nil
+}
+
+func (u *outletUsecase) Delete(id uint64) error {
+	outlet, err := u.outletRepo.FindByID(id)
+	if err != nil || outlet == nil {
+		return appErr.Get(appErr.ErrOutletShow, err)
+	}
+
+	if outlet.IsDefault {
+		return appErr.Get(appErr.ErrValidation, fmt.Errorf("outlet %s is the default outlet", outlet.Code))
+	}
+
+	if err := u.outletRepo.Delete(outlet); err != nil {
+		return appErr.Get(appErr.ErrOutletDelete, err)
+	}
+	return nil
+}
+
+// resolveOutlet returns the active outlet with the given ID, or the default
+// outlet when no ID is given.
+func resolveOutlet(outletRepo repository.OutletRepository, id *uint64) (*domain.Outlet, error) {
+	var outlet *domain.Outlet
+	var err error
+	if id != nil {
+		outlet, err = outletRepo.FindByID(*id)
+	} else {
+		outlet, err = outletRepo.FindDefault()
+	}
+	if err != nil || outlet == nil {
+		return nil, appErr.Get(appErr.ErrOutletShow, err)
+	}
+
+	if !outlet.IsActive {
+		return nil, appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("outlet %s is not active", outlet.Code))
+	}
+	return outlet, nil
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+)
+
+type PaymentMethodUsecase interface {
+	FindPaginated(page, limit int) ([]domain.PaymentMethod, int64, error)
+	FindByID(id uint64) (*domain.PaymentMethod, error)
+	Create(method *domain.PaymentMethod) error
+	Update(method *domain.PaymentMethod) error
+	Delete(id uint64) error
+}
+
+type paymentMethodUsecase struct {
+	paymentMethodRepo repository.PaymentMethodRepository
+}
+
+func NewPaymentMethodUsecase(paymentMethodRepo repository.PaymentMethodRepository) PaymentMethodUsecase {
+	return &paymentMethodUsecase{
+		paymentMethodRepo: paymentMethodRepo,
+	}
+}
+
+func (u *paymentMethodUsecase) FindPaginated(page, limit int) ([]domain.PaymentMethod, int64, error) {
+	methods, total, err := u.paymentMethodRepo.FindPaginated(page, limit)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrPaymentMethodList, err)
+	}
+	return methods, total, nil
+}
+
+func (u *paymentMethodUsecase) FindByID(id uint64) (*domain.PaymentMethod, error) {
+	method, err := u.paymentMethodRepo.FindByID(id)
+	if err != nil || method == nil {
+		return nil, appErr.Get(appErr.ErrPaymentMethodShow, err)
+	}
+	return method, nil
+}
+
+func (u *paymentMethodUsecase) Create(method *domain.PaymentMethod) error {
+	if err := u.paymentMethodRepo.Create(method); err != nil {
+		return appErr.Get(appErr.ErrPaymentMethodCreate, err)
+	}
+	return nil
+}
+
+func (u *paymentMethodUsecase) Update(method *domain.PaymentMethod) error {
+	existing, err := u.paymentMethodRepo.FindByID(method.ID)
+	if err != nil || existing == nil {
+		return appErr.Get(appErr.ErrPaymentMethodShow, err)
+	}
+
+	if err := u.paymentMethodRepo.Update(method); err != nil {
+		return appErr.Get(appErr.ErrPaymentMethodUpdate, err)
+	}
+	return nil
+}
+
+func (u *paymentMethodUsecase) Delete(id uint64) error {
+	method, err := u.paymentMethodRepo.FindByID(id)
+	if err != nil || method == nil {
+		return appErr.Get(appErr.ErrPaymentMethodShow, err)
+	}
+
+	if err := u.paymentMethodRepo.Delete(method); err != nil {
+		return appErr.Get(appErr.ErrPaymentMethodDelete, err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentUsecase interface {
+	Summary(c *gin.Context) ([]domain.PaymentMethodTotal, error)
+}
+
+type paymentUsecase struct {
+	paymentRepo repository.PaymentRepository
+}
+
+func NewPaymentUsecase(paymentRepo repository.PaymentRepository) PaymentUsecase {
+	return &paymentUsecase{
+		paymentRepo: paymentRepo,
+	}
+}
+
+func (u *paymentUsecase) Summary(c *gin.Context) ([]domain.PaymentMethodTotal, error) {
+	filters := map[string]interface{}{}
+
+	// Query params: ?start_date=2025-01-01&end_date=2025-01-31&cashier_id=1&shift_id=1
+	dateRangeFilters(c, filters)
+
+	if cashierID := c.Query("cashier_id"); cashierID != "" {
+		if id, err := strconv.Atoi(cashierID); err == nil {
+			filters["cashier_id"] = id
+		}
+	}
+
+	if shiftID := c.Query("shift_id"); shiftID != "" {
+		if id, err := strconv.Atoi(shiftID); err == nil {
+			filters["shift_id"] = id
+		}
+	}
+
+	totals, err := u.paymentRepo.TotalsByMethod(filters)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrPaymentSummary, err)
+	}
+
+	return totals, nil
+}
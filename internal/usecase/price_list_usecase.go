@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"fmt"
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+	"gopos/pkg/utils"
+)
+
+type PriceListUsecase interface {
+	FindPaginated(page, limit int) ([]domain.PriceList, int64, error)
+	FindByID(id uint64) (*domain.PriceList, error)
+	Create(priceList *domain.PriceList) error
+	Update(priceList *domain.PriceList) error
+	Delete(id uint64) error
+	SetItems(id uint64, req *domain.PriceListItemsRequest) (*domain.PriceList, error)
+	Applicable(outletID, customerGroupID *uint64) (*domain.PriceList, error)
+	UnitPrice(priceList *domain.PriceList, productID uint64, factor, quantity int) (*float64, error)
+}
+
+type priceListUsecase struct {
+	priceListRepo     repository.PriceListRepository
+	customerGroupRepo repository.CustomerGroupRepository
+	outletRepo        repository.OutletRepository
+	productRepo       repository.ProductRepository
+}
+
+func NewPriceListUsecase(priceListRepo repository.PriceListRepository, customerGroupRepo repository.CustomerGroupRepository, outletRepo repository.OutletRepository, productRepo repository.ProductRepository) PriceListUsecase {
+	return &priceListUsecase{
+		priceListRepo:     priceListRepo,
+		customerGroupRepo: customerGroupRepo,
+		outletRepo:        outletRepo,
+		productRepo:       productRepo,
+	}
+}
+
+func (u *priceListUsecase) FindPaginated(page, limit int) ([]domain.PriceList, int64, error) {
+	priceLists, total, err := u.priceListRepo.FindPaginated(page, limit)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrPriceListList, err)
+	}
+	return priceLists, total, nil
+}
+
+func (u *priceListUsecase) FindByID(id uint64) (*domain.PriceList, error) {
+	priceList, err := u.priceListRepo.FindByID(id)
+	if err != nil || priceList == nil {
+		return nil, appErr.Get(appErr.ErrPriceListShow, err)
+	}
+	return priceList, nil
+}
+
+func (u *priceListUsecase) Create(priceList *domain.PriceList) error {
+	// Prices are only set through SetItems
+	priceList.Items = nil
+
+	if err := u.priceListRepo.Create(priceList); err != nil {
+		return appErr.Get(appErr.ErrPriceListCreate, err)
+	}
+	return nil
+}
+
+func (u *priceListUsecase) Update(priceList *domain.PriceList) error {
+	existing, err := u.priceListRepo.FindByID(priceList.ID)
+	if err != nil || existing == nil {
+		return appErr.Get(appErr.ErrPriceListShow, err)
+	}
+
+	if err := u.priceListRepo.Update(priceList); err != nil {
+		return appErr.Get(appErr.ErrPriceListUpdate, err)
+	}
+	return nil
+}
+
+func (u *priceListUsecase) Delete(id uint64) error {
+	priceList, err := u.priceListRepo.FindByID(id)
+	if err != nil || priceList == nil {
+		return appErr.Get(appErr.ErrPriceListShow, err)
+	}
+
+	if err := u.priceListRepo.Delete(priceList); err != nil {
+		return appErr.Get(appErr.ErrPriceListDelete, err)
+	}
+	return nil
+}
+
+// SetItems replaces the prices of a price list. Each product can have one
+// price per minimum quantity.
+func (u *priceListUsecase) SetItems(id uint64, req *domain.PriceListItemsRequest) (*domain.PriceList, error) {
+	priceList, err := u.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	type tier struct {
+		productID   uint64
+		minQuantity int
+	}
+	seen := make(map[tier]bool, len(req.Items))
+	items := make([]domain.PriceListItem, 0, len(req.Items))
+	for _, line := range req.Items {
+		product, err := u.productRepo.FindByID(line.ProductID)
+		if err != nil || product == nil {
+			return nil, appErr.Get(appErr.ErrProductShow, err)
+		}
+		if err := requireSellable(product); err != nil {
+			return nil, err
+		}
+
+		minQuantity := line.MinQuantity
+		if minQuantity == 0 {
+			minQuantity = 1
+		}
+		if seen[tier{product.ID, minQuantity}] {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s has more than one price from %d", product.Code, minQuantity))
+		}
+		seen[tier{product.ID, minQuantity}] = true
+
+		items = append(items, domain.PriceListItem{ProductID: product.ID, MinQuantity: minQuantity, Price: *line.Price})
+	}
+
+	if err := u.priceListRepo.SetItems(priceList.ID, items); err != nil {
+		return nil, appErr.Get(appErr.ErrPriceListItemUpdate, err)
+	}
+
+	return u.FindByID(priceList.ID)
+}
+
+// Applicable returns the price list a sale in the outlet for the customer
+// group is priced with: the group's when it has an active one, otherwise
+// the outlet's, otherwise none. An unknown or inactive customer group is
+// refused rather than silently priced as a walk-in.
+func (u *priceListUsecase) Applicable(outletID, customerGroupID *uint64) (*domain.PriceList, error) {
+	if customerGroupID != nil {
+		group, err := u.customerGroupRepo.FindByID(*customerGroupID)
+		if err != nil || group == nil {
+			return nil, appErr.Get(appErr.ErrCustomerGroupShow, err)
+		}
+		if !group.IsActive {
+			return nil, appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("customer group %s is not active", group.Code))
+		}
+		if group.PriceList != nil && group.PriceList.IsActive {
+			return group.PriceList, nil
+		}
+	}
+
+	if outletID == nil {
+		return nil, nil
+	}
+	outlet, err := u.outletRepo.FindByID(*outletID)
+	if err != nil || outlet == nil {
+		return nil, appErr.Get(appErr.ErrOutletShow, err)
+	}
+	if outlet.PriceListID == nil {
+		return nil, nil
+	}
+
+	priceList, err := u.priceListRepo.FindActive(*outlet.PriceListID)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrPriceListShow, err)
+	}
+	return priceList, nil
+}
+
+// UnitPrice returns the price list's price for one unit of factor base
+// units of the product, on a line of quantity such units. It is nil when
+// there is no price list or the line reaches none of the product's tiers.
+func (u *priceListUsecase) UnitPrice(priceList *domain.PriceList, productID uint64, factor, quantity int) (*float64, error) {
+	if priceList == nil {
+		return nil, nil
+	}
+
+	item, err := u.priceListRepo.FindPrice(priceList.ID, productID, quantity*factor)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrPriceListShow, err)
+	}
+	if item == nil {
+		return nil, nil
+	}
+
+	price := utils.RoundMoney(item.Price * float64(factor))
+	return &price, nil
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+	"gopos/pkg/imaging"
+	"gopos/pkg/storage"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+type ProductImageUsecase interface {
+	Upload(productID uint64, file *multipart.FileHeader, isPrimary bool) (*domain.Product, error)
+	Open(productID, imageID uint64, thumbnail bool) (*domain.ProductImage, io.ReadCloser, error)
+	Delete(productID, imageID uint64) (*domain.Product, error)
+}
+
+const (
+	// thumbnailSize is the longest side of a thumbnail, enough for a tile
+	// on the tablet's product grid.
+	thumbnailSize = 320
+	// maxImagePixels refuses images that are small on disk but would take
+	// gigabytes of memory to decode.
+	maxImagePixels = 40_000_000
+)
+
+// imageExtensions are the image types accepted, by sniffed content type.
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+type productImageUsecase struct {
+	imageRepo   repository.ProductImageRepository
+	productRepo repository.ProductRepository
+	storage     storage.Storage
+	maxSize     int64
+}
+
+func NewProductImageUsecase(imageRepo repository.ProductImageRepository, productRepo repository.ProductRepository, fileStorage storage.Storage, maxSize int64) ProductImageUsecase {
+	return &productImageUsecase{
+		imageRepo:   imageRepo,
+		productRepo: productRepo,
+		storage:     fileStorage,
+		maxSize:     maxSize,
+	}
+}
+
+// Upload stores a JPEG, PNG or GIF image of a product with a JPEG
+// thumbnail. The type is taken from the file's content, not its name. Files
+// are named after their content, so the same picture uploaded twice to a
+// product is refused.
+func (u *productImageUsecase) Upload(productID uint64, file *multipart.FileHeader, isPrimary bool) (*domain.Product, error) {
+	product, err := u.findProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if file.Size > u.maxSize {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("image must not be larger than %d bytes", u.maxSize))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrFileUploadFailed, err)
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, u.maxSize+1))
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrFileUploadFailed, err)
+	}
+	if int64(len(data)) > u.maxSize {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("image must not be larger than %d bytes", u.maxSize))
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("image must be JPEG, PNG or GIF, got %s", contentType))
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("image cannot be read: %w", err))
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("image of %dx%d pixels is too large", config.Width, config.Height))
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("image cannot be read: %w", err))
+	}
+	var thumbnail bytes.Buffer
+	if err := jpeg.Encode(&thumbnail, imaging.Thumbnail(img, thumbnailSize), &jpeg.Options{Quality: 85}); err != nil {
+		return nil, appErr.Get(appErr.ErrFileUploadFailed, err)
+	}
+
+	sum := sha256.Sum256(data)
+	productImage := &domain.ProductImage{
+		ProductID:     product.ID,
+		Path:          fmt.Sprintf("products/%d/%x%s", product.ID, sum[:8], ext),
+		ThumbnailPath: fmt.Sprintf("products/%d/%x_thumb.jpg", product.ID, sum[:8]),
+		ContentType:   contentType,
+		Size:          int64(len(data)),
+		ThumbnailSize: int64(thumbnail.Len()),
+		Width:         config.Width,
+		Height:        config.Height,
+		IsPrimary:     isPrimary,
+	}
+
+	for _, existing := range product.Images {
+		if existing.Path == productImage.Path {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s already has this image", product.Code))
+		}
+	}
+
+	if err := u.storage.Put(productImage.Path, bytes.NewReader(data)); err != nil {
+		return nil, appErr.Get(appErr.ErrFileUploadFailed, err)
+	}
+	if err := u.storage.Put(productImage.ThumbnailPath, &thumbnail); err != nil {
+		u.storage.Delete(productImage.Path)
+		return nil, appErr.Get(appErr.ErrFileUploadFailed, err)
+	}
+
+	if err := u.imageRepo.Create(productImage); err != nil {
+		// A concurrent upload of the same picture stored its row first; the
+		// files are that image's now, so they stay
+		if appErr.Is(err, appErr.ErrDuplicateEntry) {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s already has this image", product.Code))
+		}
+		u.storage.Delete(productImage.Path)
+		u.storage.Delete(productImage.ThumbnailPath)
+		return nil, appErr.Get(appErr.ErrProductImageCreate, err)
+	}
+
+	return u.findProduct(product.ID)
+}
+
+// Open returns an image of a product with its file, or its thumbnail's
+// file. The caller closes the file.
+func (u *productImageUsecase) Open(productID, imageID uint64, thumbnail bool) (*domain.ProductImage, io.ReadCloser, error) {
+	productImage, err := u.imageRepo.FindByID(productID, imageID)
+	if err != nil || productImage == nil {
+		return nil, nil, appErr.Get(appErr.ErrFileNotFound, err)
+	}
+
+	path := productImage.Path
+	if thumbnail {
+		path = productImage.ThumbnailPath
+	}
+
+	file, err := u.storage.Open(path)
+	if err != nil {
+		return nil, nil, appErr.Get(appErr.ErrFileNotFound, err)
+	}
+	return productImage, file, nil
+}
+
+func (u *productImageUsecase) Delete(productID, imageID uint64) (*domain.Product, error) {
+	product, err := u.findProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	productImage, err := u.imageRepo.Delete(product.ID, imageID)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrProductImageDelete, err)
+	}
+
+	// The image is gone once its row is; a file left behind only takes
+	// up space
+	u.storage.Delete(productImage.Path)
+	u.storage.Delete(productImage.ThumbnailPath)
+
+	return u.findProduct(product.ID)
+}
+
+func (u *productImageUsecase) findProduct(id uint64) (*domain.Product, error) {
+	product, err := u.productRepo.FindByID(id)
+	if err != nil || product == nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
+	return product, nil
+}
@@ -0,0 +1,513 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"gopos/internal/domain"
+	"gopos/pkg/barcode"
+	appErr "gopos/pkg/errors"
+	"gopos/pkg/xlsx"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// productColumns are the columns of a product file, in export order. An
+// import needs code, name and price; the other columns may be left out.
+// category holds the category path, such as Beverages > Coffee.
+// cost_price, stock and barcode only apply to new products: afterwards
+// cost follows goods receipts, stock moves through inventory and barcodes
+// are managed on the product.
+var productColumns = []string{
+	"code", "name", "description", "category", "price", "cost_price", "stock",
+	"min_stock", "reorder_quantity", "track_expiry", "is_active", "barcode",
+}
+
+const (
+	// maxImportSize and maxImportRows bound one import file.
+	maxImportSize = 10 << 20
+	maxImportRows = 10000
+	// maxImportColumns bounds the header of a spreadsheet, leaving room
+	// for blank columns between the product columns.
+	maxImportColumns = 64
+	// exportPageSize is how many products an export reads at a time.
+	exportPageSize = 500
+)
+
+// importRow is one row of an import file, with its cells by column.
+// decimalComma is set for files written with a decimal comma, as in
+// 15000,50.
+type importRow struct {
+	number       int
+	cells        map[string]string
+	decimalComma bool
+}
+
+// Import creates the products of a CSV or XLSX file whose codes are new and
+// updates the others. Every row is checked before anything is written, and
+// a file with errors writes nothing; the result lists the errors by row.
+// Products with variants are left out: their variants are imported as
+// products of their own.
+func (u *productUsecase) Import(file *multipart.FileHeader, req *domain.ProductImportRequest, userID uint) (*domain.ProductImportResult, error) {
+	rows, err := readImportFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	outlet, err := resolveOutlet(u.outletRepo, req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := u.categoryRepo.FindAll()
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrCategoryList, err)
+	}
+	// A category is named by its path, as exported, or by its own name
+	categoryIDs := make(map[string]uint64, 2*len(categories))
+	for id, path := range categoryPaths(categories) {
+		categoryIDs[strings.ToLower(path)] = id
+	}
+	for _, category := range categories {
+		categoryIDs[strings.ToLower(category.Name)] = category.ID
+	}
+
+	codes := make([]string, 0, len(rows))
+	values := make([]string, 0, len(rows))
+	for _, row := range rows {
+		codes = append(codes, row.cells["code"])
+		if value := row.cells["barcode"]; value != "" {
+			values = append(values, value)
+		}
+	}
+
+	products, err := u.productRepo.FindByCodes(codes)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrProductImport, err)
+	}
+	existing := make(map[string]*domain.Product, len(products))
+	for i := range products {
+		existing[strings.ToLower(products[i].Code)] = &products[i]
+	}
+
+	takenBarcodes, err := u.productRepo.FindBarcodes(values)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrProductImport, err)
+	}
+	taken := make(map[string]bool, len(takenBarcodes))
+	for _, productBarcode := range takenBarcodes {
+		taken[productBarcode.Barcode] = true
+	}
+
+	result := &domain.ProductImportResult{DryRun: req.DryRun, Rows: len(rows)}
+	var creates, updates []domain.Product
+	var priceChanges []domain.ProductPriceHistory
+	seenCodes := make(map[string]int, len(rows))
+	seenBarcodes := make(map[string]int)
+
+	for _, row := range rows {
+		code := row.cells["code"]
+		fail := func(format string, args ...any) {
+			result.Errors = append(result.Errors, domain.ProductImportError{Row: row.number, Code: code, Message: fmt.Sprintf(format, args...)})
+		}
+
+		if code == "" {
+			fail("code is required")
+			continue
+		}
+		if len(code) > 50 {
+			fail("code must not be longer than 50 characters")
+			continue
+		}
+		if first, ok := seenCodes[strings.ToLower(code)]; ok {
+			fail("code %s is already on row %d", code, first)
+			continue
+		}
+		seenCodes[strings.ToLower(code)] = row.number
+
+		product := existing[strings.ToLower(code)]
+		isNew := product == nil
+		if isNew {
+			product = &domain.Product{Code: code, IsActive: true, OutletID: &outlet.ID}
+		} else if product.DeletedAt.Valid {
+			fail("code %s belongs to a deleted product", code)
+			continue
+		} else if product.HasVariants {
+			fail("product %s has variants; import its variants instead", code)
+			continue
+		}
+
+		rowErrors := len(result.Errors)
+		if name, ok := row.cells["name"]; ok {
+			if name == "" {
+				fail("name is required")
+			} else if len(name) > 100 {
+				fail("name must not be longer than 100 characters")
+			}
+			product.Name = name
+		}
+		if description, ok := row.cells["description"]; ok {
+			product.Description = description
+		}
+		if category, ok := row.cells["category"]; ok {
+			product.CategoryID = nil
+			if category != "" {
+				if id, ok := categoryIDs[strings.ToLower(category)]; ok {
+					product.CategoryID = &id
+				} else {
+					fail("category %s does not exist", category)
+				}
+			}
+		}
+
+		price, err := parseAmount(row.cells["price"], row.decimalComma)
+		if err != nil || price == nil {
+			fail("price must be a number of at least 0")
+		} else if isNew {
+			product.Price = price
+		} else if *price != *product.Price {
+			priceChanges = append(priceChanges, domain.ProductPriceHistory{
+				ProductID: product.ID,
+				OldPrice:  product.Price,
+				Price:     *price,
+				ChangedBy: &userID,
+			})
+			product.Price = price
+		}
+
+		for _, field := range []struct {
+			column string
+			value  *int
+		}{{"min_stock", &product.MinStock}, {"reorder_quantity", &product.ReorderQuantity}} {
+			if value := row.cells[field.column]; value != "" {
+				if n, err := strconv.Atoi(value); err != nil || n < 0 {
+					fail("%s must be a whole number of at least 0", field.column)
+				} else {
+					*field.value = n
+				}
+			}
+		}
+
+		for _, field := range []struct {
+			column string
+			value  *bool
+		}{{"track_expiry", &product.TrackExpiry}, {"is_active", &product.IsActive}} {
+			if value := row.cells[field.column]; value != "" {
+				if b, ok := parseFlag(value); !ok {
+					fail("%s must be true or false", field.column)
+				} else {
+					*field.value = b
+				}
+			}
+		}
+
+		if isNew {
+			if costPrice, err := parseAmount(row.cells["cost_price"], row.decimalComma); err != nil {
+				fail("cost_price must be a number of at least 0")
+			} else if costPrice != nil {
+				product.CostPrice = *costPrice
+			}
+
+			if value := row.cells["stock"]; value != "" {
+				if n, err := strconv.Atoi(value); err != nil || n < 0 {
+					fail("stock must be a whole number of at least 0")
+				} else {
+					product.Stock = n
+				}
+			}
+
+			if value := row.cells["barcode"]; value != "" {
+				if symbology, err := barcode.Detect(value); err != nil {
+					fail("%v", err)
+				} else if first, ok := seenBarcodes[value]; ok {
+					fail("barcode %s is already on row %d", value, first)
+				} else if taken[value] {
+					fail("barcode %s belongs to another product", value)
+				} else {
+					product.Barcodes = []domain.ProductBarcode{{Barcode: value, Type: symbology, IsPrimary: true}}
+					seenBarcodes[value] = row.number
+				}
+			}
+		}
+
+		if len(result.Errors) > rowErrors {
+			continue
+		}
+		if isNew {
+			creates = append(creates, *product)
+		} else {
+			updates = append(updates, *product)
+		}
+	}
+
+	result.Created, result.Updated = len(creates), len(updates)
+	if len(result.Errors) > 0 {
+		return result, appErr.Get(appErr.ErrValidation, fmt.Errorf("%d errors in %d rows", len(result.Errors), len(rows)))
+	}
+	if req.DryRun {
+		return result, nil
+	}
+
+	if err := u.productRepo.Import(creates, updates, priceChanges, userID); err != nil {
+		return nil, appErr.Get(appErr.ErrProductImport, err)
+	}
+	u.lookups.Clear()
+
+	return result, nil
+}
+
+// Export writes the products the list filters select to a CSV file, or an
+// XLSX file with ?format=xlsx, in the columns Import reads. Variants are
+// listed as products of their own, the products grouping them are left
+// out. With outlet_id the stock is that outlet's.
+func (u *productUsecase) Export(c *gin.Context) (*domain.ProductExport, error) {
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "xlsx" {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("format must be csv or xlsx"))
+	}
+
+	filters := productFilters(c)
+	filters["flat"] = true
+
+	categories, err := u.categoryRepo.FindAll()
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrCategoryList, err)
+	}
+	paths := categoryPaths(categories)
+
+	rows := [][]any{}
+	header := make([]any, len(productColumns))
+	for i, column := range productColumns {
+		header[i] = column
+	}
+	rows = append(rows, header)
+
+	// Pages are read by keyset, after the last product of the page before
+	for {
+		products, _, err := u.productRepo.FindPaginatedWithFilter(1, exportPageSize, filters)
+		if err != nil {
+			return nil, appErr.Get(appErr.ErrProductExport, err)
+		}
+
+		for _, product := range products {
+			category := ""
+			if product.CategoryID != nil {
+				category = paths[*product.CategoryID]
+			}
+			productBarcode := ""
+			if len(product.Barcodes) > 0 {
+				productBarcode = product.Barcodes[0].Barcode
+			}
+			rows = append(rows, []any{
+				escapeCell(product.Code), escapeCell(product.Name), escapeCell(product.Description), escapeCell(category),
+				*product.Price, product.CostPrice, product.Stock, product.MinStock, product.ReorderQuantity,
+				product.TrackExpiry, product.IsActive, escapeCell(productBarcode),
+			})
+		}
+
+		if len(products) < exportPageSize {
+			break
+		}
+		filters["after_id"] = products[len(products)-1].ID
+	}
+
+	var buf bytes.Buffer
+	export := &domain.ProductExport{Filename: "products-" + time.Now().Format("20060102") + "." + format}
+	if format == "xlsx" {
+		export.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		if err := xlsx.Write(&buf, "Products", rows); err != nil {
+			return nil, appErr.Get(appErr.ErrProductExport, err)
+		}
+	} else {
+		export.ContentType = "text/csv; charset=utf-8"
+		w := csv.NewWriter(&buf)
+		for _, row := range rows {
+			record := make([]string, len(row))
+			for i, value := range row {
+				switch v := value.(type) {
+				case float64:
+					record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+				default:
+					record[i] = fmt.Sprint(v)
+				}
+			}
+			w.Write(record)
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return nil, appErr.Get(appErr.ErrProductExport, err)
+		}
+	}
+	export.Data = buf.Bytes()
+
+	return export, nil
+}
+
+// readImportFile reads the rows of an uploaded CSV or XLSX file below its
+// header row, with cells trimmed and keyed by column. Empty rows are
+// skipped.
+func readImportFile(file *multipart.FileHeader) ([]importRow, error) {
+	if file.Size > maxImportSize {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("file must not be larger than %d bytes", maxImportSize))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrFileUploadFailed, err)
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, maxImportSize+1))
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrFileUploadFailed, err)
+	}
+	if len(data) > maxImportSize {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("file must not be larger than %d bytes", maxImportSize))
+	}
+
+	var records [][]string
+	var decimalComma bool
+	if strings.EqualFold(filepath.Ext(file.Filename), ".xlsx") || bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		records, err = xlsx.Read(bytes.NewReader(data), int64(len(data)), maxImportRows+1, maxImportColumns)
+	} else {
+		records, decimalComma, err = readCSV(data)
+	}
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrValidation, err)
+	}
+	if len(records) == 0 {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("file is empty"))
+	}
+
+	known := make(map[string]bool, len(productColumns))
+	for _, column := range productColumns {
+		known[column] = true
+	}
+	header := make([]string, len(records[0]))
+	seen := map[string]bool{}
+	for i, cell := range records[0] {
+		column := strings.ToLower(strings.TrimSpace(cell))
+		if column == "" {
+			continue
+		}
+		if !known[column] {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("unknown column %q, expected %s", cell, strings.Join(productColumns, ", ")))
+		}
+		if seen[column] {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("column %s is listed more than once", column))
+		}
+		seen[column] = true
+		header[i] = column
+	}
+	for _, column := range []string{"code", "name", "price"} {
+		if !seen[column] {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("column %s is required", column))
+		}
+	}
+
+	rows := make([]importRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		row := importRow{number: i + 2, cells: make(map[string]string, len(header)), decimalComma: decimalComma}
+		empty := true
+		for j, column := range header {
+			if column == "" {
+				continue
+			}
+			value := ""
+			if j < len(record) {
+				value = unescapeCell(strings.TrimSpace(record[j]))
+			}
+			row.cells[column] = value
+			empty = empty && value == ""
+		}
+		if empty {
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("file has no products"))
+	}
+	if len(rows) > maxImportRows {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("file must not have more than %d products", maxImportRows))
+	}
+	return rows, nil
+}
+
+// readCSV reads comma or semicolon separated values; spreadsheet programs
+// in locales with a decimal comma save the latter, so for those
+// decimalComma is set.
+func readCSV(data []byte) (records [][]string, decimalComma bool, err error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		r.Comma = ';'
+		decimalComma = true
+	}
+
+	records, err = r.ReadAll()
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid CSV: %w", err)
+	}
+	return records, decimalComma, nil
+}
+
+// parseAmount reads a money cell; an empty cell is nil. With decimalComma
+// the comma separates the decimals and dots group the thousands, as in
+// 15.000,50.
+func parseAmount(value string, decimalComma bool) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if decimalComma && strings.Contains(value, ",") {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || amount < 0 {
+		return nil, fmt.Errorf("invalid amount %q", value)
+	}
+	return &amount, nil
+}
+
+// formulaPrefixes start a formula in spreadsheet programs.
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeCell keeps a spreadsheet from running text that looks like a
+// formula, such as a product named =HYPERLINK(...), by putting a quote in
+// front of it; spreadsheet programs show the text as typed.
+func escapeCell(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeCell undoes escapeCell, so an exported file imports unchanged.
+func unescapeCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+// parseFlag reads a yes/no cell as written by people and by spreadsheets.
+func parseFlag(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "true", "yes", "y", "1":
+		return true, true
+	case "false", "no", "n", "0":
+		return false, true
+	}
+	return false, false
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		value        string
+		decimalComma bool
+		want         float64
+		wantNil      bool
+		wantErr      bool
+	}{
+		{value: "15000.50", want: 15000.5},
+		{value: "15000", want: 15000},
+		{value: "", wantNil: true},
+		{value: "15000,50", wantErr: true},
+		{value: "-1", wantErr: true},
+		{value: "abc", wantErr: true},
+		{value: "15000,50", decimalComma: true, want: 15000.5},
+		{value: "15.000,50", decimalComma: true, want: 15000.5},
+		{value: "1.234.567,8", decimalComma: true, want: 1234567.8},
+		{value: "15000", decimalComma: true, want: 15000},
+		{value: "12.5", decimalComma: true, want: 12.5},
+		{value: "1,2,3", decimalComma: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseAmount(tt.value, tt.decimalComma)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseAmount(%q, %v) error = %v, want error %v", tt.value, tt.decimalComma, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if (got == nil) != tt.wantNil || (got != nil && *got != tt.want) {
+			t.Errorf("parseAmount(%q, %v) = %v, want %v", tt.value, tt.decimalComma, got, tt.want)
+		}
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name             string
+		data             string
+		wantDecimalComma bool
+		wantPrice        string
+	}{
+		{"comma separated", "code,name,price\nA1,Kopi,15000.50\n", false, "15000.50"},
+		{"semicolon separated", "\xef\xbb\xbfcode;name;price\nA1;Kopi;15000,50\n", true, "15000,50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, decimalComma, err := readCSV([]byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decimalComma != tt.wantDecimalComma {
+				t.Errorf("decimalComma = %v, want %v", decimalComma, tt.wantDecimalComma)
+			}
+			if records[0][0] != "code" || records[1][2] != tt.wantPrice {
+				t.Errorf("records = %q", records)
+			}
+			price, err := parseAmount(records[1][2], decimalComma)
+			if err != nil || *price != 15000.5 {
+				t.Errorf("price = %v, %v, want 15000.5", price, err)
+			}
+		})
+	}
+}
+
+func TestEscapeCell(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"Kopi Susu", "Kopi Susu"},
+		{"=HYPERLINK(\"http://x\")", "'=HYPERLINK(\"http://x\")"},
+		{"+62812", "'+62812"},
+		{"-5", "'-5"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"'quoted", "'quoted"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		got := escapeCell(tt.value)
+		if got != tt.want {
+			t.Errorf("escapeCell(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+		if back := unescapeCell(got); back != tt.value {
+			t.Errorf("unescapeCell(%q) = %q, want %q", got, back, tt.value)
+		}
+	}
+}
@@ -1,10 +1,17 @@
 package usecase
 
 import (
+	"fmt"
 	"gopos/internal/domain"
 	"gopos/internal/repository"
+	"gopos/pkg/barcode"
+	"gopos/pkg/cache"
 	appErr "gopos/pkg/errors"
+	"gopos/pkg/utils"
+	"mime/multipart"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,19 +19,47 @@ import (
 type ProductUsecase interface {
 	FindPaginated(c *gin.Context) ([]domain.Product, int64, error)
 	FindAll() ([]domain.Product, error)
-	FindByID(id uint64) (*domain.Product, error)
-	Create(product *domain.Product) error
+	FindByID(id uint64, outletID *uint64) (*domain.Product, error)
+	Create(product *domain.Product, userID uint) error
 	Update(req *domain.ProductUpdate) (*domain.Product, error)
+	FindPriceHistory(c *gin.Context, productID uint64) ([]domain.ProductPriceHistory, int64, error)
 	Delete(product *domain.Product) error
+	SetVariantAttributes(id uint64, req *domain.VariantAttributesRequest) (*domain.Product, error)
+	CreateVariant(parentID uint64, req *domain.CreateVariantRequest) (*domain.Product, error)
+	SetUnits(id uint64, req *domain.ProductUnitsRequest) (*domain.Product, error)
+	SetRecipe(id uint64, req *domain.RecipeRequest) (*domain.Product, error)
+	LookupBarcode(value string) (*domain.BarcodeLookup, error)
+	AddBarcode(id uint64, req *domain.ProductBarcodeRequest) (*domain.Product, error)
+	RemoveBarcode(id, barcodeID uint64) (*domain.Product, error)
+	Import(file *multipart.FileHeader, req *domain.ProductImportRequest, userID uint) (*domain.ProductImportResult, error)
+	Export(c *gin.Context) (*domain.ProductExport, error)
+}
+
+// barcodeLookupTTL bounds how long a scan can be answered from memory after
+// the product changed through another instance of the server.
+const barcodeLookupTTL = time.Minute
+
+// NewBarcodeLookupCache returns the cache of barcode lookups, shared by the
+// usecases whose writes change what a scan rings up.
+func NewBarcodeLookupCache() *cache.Cache[string, *domain.BarcodeLookup] {
+	return cache.New[string, *domain.BarcodeLookup](barcodeLookupTTL)
 }
 
 type productUsecase struct {
-	productRepo repository.ProductRepository
+	productRepo  repository.ProductRepository
+	outletRepo   repository.OutletRepository
+	unitRepo     repository.UnitRepository
+	categoryRepo repository.CategoryRepository
+	lookups      *cache.Cache[string, *domain.BarcodeLookup]
 }
 
-func NewProductUsecase(productRepo repository.ProductRepository) ProductUsecase {
+func NewProductUsecase(productRepo repository.ProductRepository, outletRepo repository.OutletRepository, unitRepo repository.UnitRepository, categoryRepo repository.CategoryRepository, lookups *cache.Cache[string, *domain.BarcodeLookup]) ProductUsecase {
 	return &productUsecase{
-		productRepo: productRepo,
+		productRepo:  productRepo,
+		outletRepo:   outletRepo,
+		unitRepo:     unitRepo,
+		categoryRepo: categoryRepo,
+		lookups:      lookups,
 	}
 }
 
@@ -32,13 +67,34 @@ func (u *productUsecase) FindPaginated(c *gin.Context) ([]domain.Product, int64,
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
+	filters := productFilters(c)
+
+	products, total, err := u.productRepo.FindPaginatedWithFilter(page, limit, filters)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrProductList, err)
+	}
+
+	return products, total, nil
+}
+
+// productFilters reads the product list filters, shared by the list and the
+// export.
+func productFilters(c *gin.Context) map[string]interface{} {
 	filters := map[string]interface{}{}
 
-	// Query params: ?name=kopi&category_id=1&min_price=10000&max_price=50000
+	// Query params: ?name=kopi&code=KP&barcode=899123&category_id=1&include_subcategories=true&min_price=10000&max_price=50000&outlet_id=1&stock_min=0&stock_max=10&low_stock=true&flat=true
 	if code := c.Query("code"); code != "" {
 		filters["code"] = code
 	}
 
+	if barcode := c.Query("barcode"); barcode != "" {
+		filters["barcode"] = barcode
+	}
+
+	if flat, err := strconv.ParseBool(c.Query("flat")); err == nil && flat {
+		filters["flat"] = true
+	}
+
 	if name := c.Query("name"); name != "" {
 		filters["name"] = name
 	}
@@ -49,6 +105,10 @@ func (u *productUsecase) FindPaginated(c *gin.Context) ([]domain.Product, int64,
 		}
 	}
 
+	if include, err := strconv.ParseBool(c.Query("include_subcategories")); err == nil && include {
+		filters["include_subcategories"] = true
+	}
+
 	if minPrice := c.Query("min_price"); minPrice != "" {
 		if price, err := strconv.Atoi(minPrice); err == nil {
 			filters["min_price"] = price
@@ -61,29 +121,128 @@ func (u *productUsecase) FindPaginated(c *gin.Context) ([]domain.Product, int64,
 		}
 	}
 
-	products, total, err := u.productRepo.FindPaginatedWithFilter(page, limit, filters)
-	if err != nil {
-		return nil, 0, appErr.Get(appErr.ErrProductList, err)
+	if outletID := c.Query("outlet_id"); outletID != "" {
+		if id, err := strconv.ParseUint(outletID, 10, 64); err == nil {
+			filters["outlet_id"] = id
+		}
 	}
 
-	return products, total, nil
+	for _, key := range []string{"stock_min", "stock_max"} {
+		if value := c.Query(key); value != "" {
+			if stock, err := strconv.Atoi(value); err == nil {
+				filters[key] = stock
+			}
+		}
+	}
+
+	if lowStock, err := strconv.ParseBool(c.Query("low_stock")); err == nil && lowStock {
+		filters["low_stock"] = true
+	}
+
+	return filters
 }
 
 func (u *productUsecase) FindAll() ([]domain.Product, error) {
 	return u.productRepo.FindAll()
 }
 
-func (u *productUsecase) FindByID(id uint64) (*domain.Product, error) {
+// FindByID returns the product with its stock per outlet, or with Stock set
+// to the stock of one outlet when outletID is given. A product with
+// variants comes with its variants, read the same way.
+func (u *productUsecase) FindByID(id uint64, outletID *uint64) (*domain.Product, error) {
 
 	product, err := u.productRepo.FindByID(id)
 	if err != nil || product == nil {
 		return nil, appErr.Get(appErr.ErrProductShow, err)
 	}
+
+	if product.HasVariants {
+		if outletID != nil {
+			outlet, err := u.outletRepo.FindByID(*outletID)
+			if err != nil || outlet == nil {
+				return nil, appErr.Get(appErr.ErrOutletShow, err)
+			}
+		}
+		if product.Variants, err = u.productRepo.FindVariants(product.ID, outletID); err != nil {
+			return nil, appErr.Get(appErr.ErrProductShow, err)
+		}
+		product.OutletID = outletID
+		return product, nil
+	}
+
+	if outletID != nil {
+		outlet, err := u.outletRepo.FindByID(*outletID)
+		if err != nil || outlet == nil {
+			return nil, appErr.Get(appErr.ErrOutletShow, err)
+		}
+		if product.Stock, err = u.productRepo.OutletStock(product.ID, outlet.ID); err != nil {
+			return nil, appErr.Get(appErr.ErrProductShow, err)
+		}
+		product.OutletID = &outlet.ID
+		return product, nil
+	}
+
+	if product.Stocks, err = u.productRepo.FindStocks(product.ID); err != nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
 	return product, nil
 }
 
-func (u *productUsecase) Create(product *domain.Product) error {
-	return u.productRepo.Create(product)
+func (u *productUsecase) Create(product *domain.Product, userID uint) error {
+	if product.Stock < 0 {
+		return appErr.Get(appErr.ErrValidation, fmt.Errorf("opening stock cannot be negative"))
+	}
+
+	outlet, err := resolveOutlet(u.outletRepo, product.OutletID)
+	if err != nil {
+		return err
+	}
+	product.OutletID = &outlet.ID
+	product.Stocks = nil
+
+	// Variants are only made through CreateVariant, units through SetUnits
+	// and recipes through SetRecipe
+	product.ParentID = nil
+	product.HasVariants = false
+	product.Attributes = nil
+	product.Options = nil
+	product.Variants = nil
+	product.Units = nil
+	product.IsComposite = false
+	product.Recipe = nil
+
+	if product.BaseUnitID != nil {
+		if _, err := u.findActiveUnit(*product.BaseUnitID); err != nil {
+			return err
+		}
+	}
+
+	// The product has no other units yet, so its barcodes are all for the
+	// base unit
+	seen := make(map[string]bool, len(product.Barcodes))
+	primary := false
+	for i := range product.Barcodes {
+		productBarcode := &product.Barcodes[i]
+		if productBarcode.UnitID != nil {
+			return appErr.Get(appErr.ErrValidation, fmt.Errorf("barcodes for other units are added after the units are set"))
+		}
+		if err := checkBarcode(productBarcode); err != nil {
+			return err
+		}
+		if seen[productBarcode.Barcode] {
+			return appErr.Get(appErr.ErrValidation, fmt.Errorf("barcode %s is listed more than once", productBarcode.Barcode))
+		}
+		seen[productBarcode.Barcode] = true
+
+		productBarcode.ID = 0
+		productBarcode.IsPrimary = productBarcode.IsPrimary && !primary
+		primary = primary || productBarcode.IsPrimary
+	}
+	if len(product.Barcodes) > 0 && !primary {
+		product.Barcodes[0].IsPrimary = true
+	}
+
+	return u.productRepo.Create(product, userID)
 }
 
 func (u *productUsecase) Update(req *domain.ProductUpdate) (*domain.Product, error) {
@@ -98,13 +257,568 @@ func (u *productUsecase) Update(req *domain.ProductUpdate) (*domain.Product, err
 	if req.Name != "" {
 		product.Name = req.Name
 	}
-	if req.Price != nil {
+	var priceChange *domain.ProductPriceHistory
+	if req.Price != nil && *req.Price != *product.Price {
+		priceChange = &domain.ProductPriceHistory{
+			ProductID: product.ID,
+			OldPrice:  product.Price,
+			Price:     *req.Price,
+			ChangedBy: &req.UpdatedBy,
+		}
 		product.Price = req.Price
 	}
+	if req.TaxRateID != nil {
+		product.TaxRateID = req.TaxRateID
+	}
+	if req.TrackExpiry != nil {
+		product.TrackExpiry = *req.TrackExpiry
+	}
 
-	return product, u.productRepo.Update(product)
+	if err := u.productRepo.Update(product, priceChange); err != nil {
+		return product, err
+	}
+	u.lookups.Clear()
+	return product, nil
+}
+
+// FindPriceHistory lists the price changes of a product, newest first.
+func (u *productUsecase) FindPriceHistory(c *gin.Context, productID uint64) ([]domain.ProductPriceHistory, int64, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	product, err := u.productRepo.FindByID(productID)
+	if err != nil || product == nil {
+		return nil, 0, appErr.Get(appErr.ErrProductShow, err)
+	}
+
+	filters := map[string]interface{}{}
+
+	// Query params: ?start_date=2025-01-01&end_date=2025-01-31
+	dateRangeFilters(c, filters)
+
+	history, total, err := u.productRepo.FindPriceHistory(product.ID, page, limit, filters)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrPriceHistoryList, err)
+	}
+	return history, total, nil
 }
 
 func (u *productUsecase) Delete(product *domain.Product) error {
-	return u.productRepo.Delete(product)
+	if product.HasVariants {
+		variants, err := u.productRepo.FindVariants(product.ID, nil)
+		if err != nil {
+			return appErr.Get(appErr.ErrProductShow, err)
+		}
+		if len(variants) > 0 {
+			return appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s still has %d variants", product.Code, len(variants)))
+		}
+	}
+
+	uses, err := u.productRepo.FindRecipeUses(product.ID)
+	if err != nil {
+		return appErr.Get(appErr.ErrProductShow, err)
+	}
+	if len(uses) > 0 {
+		return appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s is still used in the recipe of %s", product.Code, recipeProducts(uses)))
+	}
+
+	if err := u.productRepo.Delete(product); err != nil {
+		return err
+	}
+	u.lookups.Clear()
+	return nil
+}
+
+// SetVariantAttributes turns a product into a group of variants that
+// differ in the given attributes. It is only allowed while the product has
+// no stock and no variants, so no stock is ever stranded on the group.
+func (u *productUsecase) SetVariantAttributes(id uint64, req *domain.VariantAttributesRequest) (*domain.Product, error) {
+	product, err := u.productRepo.FindByID(id)
+	if err != nil || product == nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
+
+	if product.ParentID != nil {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s is a variant itself", product.Code))
+	}
+	if product.Stock != 0 {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s still has %d in stock", product.Code, product.Stock))
+	}
+
+	seen := make(map[string]bool, len(req.Attributes))
+	attributes := make([]domain.VariantAttribute, 0, len(req.Attributes))
+	for i, name := range req.Attributes {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("attribute %q is empty or listed more than once", name))
+		}
+		seen[key] = true
+		attributes = append(attributes, domain.VariantAttribute{Name: name, Position: i})
+	}
+
+	if err := u.productRepo.SetVariantAttributes(product, attributes); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrVariantAttributeUpdate, err)
+	}
+	u.lookups.Clear()
+
+	return product, nil
+}
+
+// CreateVariant adds a variant under a product with variant attributes.
+// The variant takes its category, tax rate, description and stock settings
+// from the parent, and must differ from its siblings in at least one option.
+func (u *productUsecase) CreateVariant(parentID uint64, req *domain.CreateVariantRequest) (*domain.Product, error) {
+	parent, err := u.productRepo.FindByID(parentID)
+	if err != nil || parent == nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
+	if !parent.HasVariants {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s has no variant attributes", parent.Code))
+	}
+
+	if len(req.Options) != len(parent.Attributes) {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("a variant of %s needs exactly one value for each of its %d attributes", parent.Code, len(parent.Attributes)))
+	}
+
+	options := make([]domain.VariantOption, 0, len(parent.Attributes))
+	values := make([]string, 0, len(parent.Attributes))
+	for _, attribute := range parent.Attributes {
+		value, ok := req.Options[attribute.Name]
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("option %s is required", attribute.Name))
+		}
+		options = append(options, domain.VariantOption{AttributeID: attribute.ID, Value: value})
+		values = append(values, value)
+	}
+
+	siblings, err := u.productRepo.FindVariants(parent.ID, nil)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
+	for _, sibling := range siblings {
+		if sameOptions(sibling.Options, options) {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("variant %s already has %s", sibling.Code, strings.Join(values, " / ")))
+		}
+	}
+
+	name := req.Name
+	if name == "" {
+		name = parent.Name + " - " + strings.Join(values, " / ")
+	}
+
+	variant := &domain.Product{
+		Code:            req.Code,
+		ParentID:        &parent.ID,
+		Name:            name,
+		Description:     parent.Description,
+		CategoryID:      parent.CategoryID,
+		TaxRateID:       parent.TaxRateID,
+		Price:           req.Price,
+		CostPrice:       req.CostPrice,
+		Stock:           req.Stock,
+		MinStock:        parent.MinStock,
+		ReorderQuantity: parent.ReorderQuantity,
+		TrackExpiry:     parent.TrackExpiry,
+		BaseUnitID:      parent.BaseUnitID,
+		IsActive:        true,
+		Options:         options,
+	}
+	if req.Barcode != "" {
+		productBarcode := domain.ProductBarcode{Barcode: req.Barcode, IsPrimary: true}
+		if err := checkBarcode(&productBarcode); err != nil {
+			return nil, err
+		}
+		variant.Barcodes = []domain.ProductBarcode{productBarcode}
+	}
+
+	outlet, err := resolveOutlet(u.outletRepo, req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+	variant.OutletID = &outlet.ID
+
+	if err := u.productRepo.Create(variant, req.UserID); err != nil {
+		return nil, appErr.Get(appErr.ErrVariantCreate, err)
+	}
+
+	return u.FindByID(variant.ID, nil)
+}
+
+// SetUnits sets the base unit of a product and replaces the other units it
+// is bought or sold in. Stock stays in base units, so changing the base unit
+// only changes its name, never the quantities.
+func (u *productUsecase) SetUnits(id uint64, req *domain.ProductUnitsRequest) (*domain.Product, error) {
+	product, err := u.productRepo.FindByID(id)
+	if err != nil || product == nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
+
+	if _, err := u.findActiveUnit(req.BaseUnitID); err != nil {
+		return nil, err
+	}
+
+	seen := map[uint64]bool{req.BaseUnitID: true}
+	units := make([]domain.ProductUnit, 0, len(req.Units))
+	for _, line := range req.Units {
+		unit, err := u.findActiveUnit(line.UnitID)
+		if err != nil {
+			return nil, err
+		}
+		if seen[unit.ID] {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("unit %s is listed more than once or is the base unit", unit.Code))
+		}
+		seen[unit.ID] = true
+
+		units = append(units, domain.ProductUnit{UnitID: unit.ID, Factor: line.Factor, Price: line.Price})
+	}
+
+	uses, err := u.productRepo.FindRecipeUses(product.ID)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
+	for _, use := range uses {
+		if use.UnitID != nil && !seen[*use.UnitID] {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("the recipe of %s uses product %s in unit %d", recipeProducts([]domain.RecipeItem{use}), product.Code, *use.UnitID))
+		}
+	}
+
+	if err := u.productRepo.SetUnits(product.ID, req.BaseUnitID, units); err != nil {
+		return nil, appErr.Get(appErr.ErrProductUnitUpdate, err)
+	}
+	u.lookups.Clear()
+
+	return u.FindByID(product.ID, nil)
+}
+
+// SetRecipe replaces the components a product is made from. A product with
+// a recipe becomes composite: it must have no stock of its own, and it
+// cannot itself be a component of another recipe.
+func (u *productUsecase) SetRecipe(id uint64, req *domain.RecipeRequest) (*domain.Product, error) {
+	product, err := u.productRepo.FindByID(id)
+	if err != nil || product == nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
+	if err := requireSellable(product); err != nil {
+		return nil, err
+	}
+
+	if len(req.Items) > 0 {
+		if product.Stock != 0 {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s still has %d in stock", product.Code, product.Stock))
+		}
+		uses, err := u.productRepo.FindRecipeUses(product.ID)
+		if err != nil {
+			return nil, appErr.Get(appErr.ErrProductShow, err)
+		}
+		if len(uses) > 0 {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s is a component of %s", product.Code, recipeProducts(uses)))
+		}
+	}
+
+	seen := make(map[uint64]bool, len(req.Items))
+	items := make([]domain.RecipeItem, 0, len(req.Items))
+	for _, line := range req.Items {
+		component, err := u.productRepo.FindByID(line.ComponentID)
+		if err != nil || component == nil {
+			return nil, appErr.Get(appErr.ErrProductShow, err)
+		}
+		if component.ID == product.ID {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s cannot be a component of itself", product.Code))
+		}
+		if err := requireStockItem(component); err != nil {
+			return nil, err
+		}
+		if seen[component.ID] {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("component %s is listed more than once", component.Code))
+		}
+		seen[component.ID] = true
+
+		unit, err := resolveUnit(component, line.UnitID)
+		if err != nil {
+			return nil, err
+		}
+		item := domain.RecipeItem{ComponentID: component.ID, Quantity: line.Quantity}
+		if unit.Factor != 1 {
+			item.UnitID = unit.ID
+		}
+		items = append(items, item)
+	}
+
+	if err := u.productRepo.SetRecipe(product, items); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrRecipeUpdate, err)
+	}
+
+	return u.FindByID(product.ID, nil)
+}
+
+// LookupBarcode finds what a scanned barcode rings up at the till. Answers
+// are kept in memory, so a till scanning the same products over and over
+// does not go to the database each time. Callers get their own copy of the
+// cached answer.
+func (u *productUsecase) LookupBarcode(value string) (*domain.BarcodeLookup, error) {
+	if lookup, ok := u.lookups.Get(value); ok {
+		return copyLookup(lookup), nil
+	}
+
+	productBarcode, err := u.productRepo.FindByBarcode(barcode.Candidates(value))
+	if err != nil || productBarcode == nil {
+		return nil, appErr.Get(appErr.ErrBarcodeLookup, err)
+	}
+
+	product := productBarcode.Product
+	if err := requireSellable(product); err != nil {
+		return nil, err
+	}
+	unit, err := resolveUnit(product, productBarcode.UnitID)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := &domain.BarcodeLookup{
+		Barcode:     productBarcode.Barcode,
+		Type:        productBarcode.Type,
+		ProductID:   product.ID,
+		ProductCode: product.Code,
+		ProductName: product.Name,
+		CategoryID:  product.CategoryID,
+		TaxRateID:   product.TaxRateID,
+		UnitID:      unit.ID,
+		UnitCode:    unit.Code,
+		UnitFactor:  unit.Factor,
+		Price:       unit.Price,
+		TrackExpiry: product.TrackExpiry,
+		IsActive:    product.IsActive,
+	}
+	u.lookups.Set(value, lookup)
+	return copyLookup(lookup), nil
+}
+
+func copyLookup(lookup *domain.BarcodeLookup) *domain.BarcodeLookup {
+	copied := *lookup
+	copied.CategoryID = copyID(lookup.CategoryID)
+	copied.TaxRateID = copyID(lookup.TaxRateID)
+	copied.UnitID = copyID(lookup.UnitID)
+	return &copied
+}
+
+func copyID(id *uint64) *uint64 {
+	if id == nil {
+		return nil
+	}
+	copied := *id
+	return &copied
+}
+
+// AddBarcode gives a product another barcode, or its internal EAN-13 when
+// req.Barcode is empty. A barcode for one of the product's other units
+// scans the product in that unit.
+func (u *productUsecase) AddBarcode(id uint64, req *domain.ProductBarcodeRequest) (*domain.Product, error) {
+	product, err := u.productRepo.FindByID(id)
+	if err != nil || product == nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
+	if err := requireSellable(product); err != nil {
+		return nil, err
+	}
+
+	productBarcode := &domain.ProductBarcode{
+		ProductID: product.ID,
+		Barcode:   req.Barcode,
+		Type:      req.Type,
+		IsPrimary: req.IsPrimary,
+	}
+
+	if req.UnitID != nil {
+		unit, err := resolveUnit(product, req.UnitID)
+		if err != nil {
+			return nil, err
+		}
+		// The base unit is what a barcode without a unit scans
+		if unit.Factor != 1 {
+			productBarcode.UnitID = unit.ID
+		}
+	}
+
+	if productBarcode.Barcode == "" {
+		if productBarcode.Barcode, err = barcode.Internal(product.ID); err != nil {
+			return nil, appErr.Get(appErr.ErrValidation, err)
+		}
+		productBarcode.Type = barcode.EAN13
+	}
+	if err := checkBarcode(productBarcode); err != nil {
+		return nil, err
+	}
+
+	for _, existing := range product.Barcodes {
+		if existing.Barcode == productBarcode.Barcode {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s already has barcode %s", product.Code, existing.Barcode))
+		}
+	}
+
+	if err := u.productRepo.AddBarcode(productBarcode); err != nil {
+		return nil, appErr.Get(appErr.ErrBarcodeCreate, err)
+	}
+	u.lookups.Clear()
+
+	return u.FindByID(product.ID, nil)
+}
+
+func (u *productUsecase) RemoveBarcode(id, barcodeID uint64) (*domain.Product, error) {
+	product, err := u.productRepo.FindByID(id)
+	if err != nil || product == nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
+
+	if err := u.productRepo.RemoveBarcode(product.ID, barcodeID); err != nil {
+		return nil, appErr.Get(appErr.ErrBarcodeDelete, err)
+	}
+	u.lookups.Clear()
+
+	return u.FindByID(product.ID, nil)
+}
+
+func (u *productUsecase) findActiveUnit(id uint64) (*domain.Unit, error) {
+	unit, err := u.unitRepo.FindByID(id)
+	if err != nil || unit == nil {
+		return nil, appErr.Get(appErr.ErrUnitShow, err)
+	}
+	if !unit.IsActive {
+		return nil, appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("unit %s is not active", unit.Code))
+	}
+	return unit, nil
+}
+
+// lineUnit is the unit a sale or purchase line is in.
+type lineUnit struct {
+	ID     *uint64
+	Code   string
+	Factor int
+	Price  float64
+}
+
+// resolveUnit finds the unit a line of the product is in: its base unit
+// when unitID is nil or names the base unit, otherwise one of its other
+// units. Price is the selling price of one such unit.
+func resolveUnit(product *domain.Product, unitID *uint64) (*lineUnit, error) {
+	if unitID == nil || (product.BaseUnitID != nil && *unitID == *product.BaseUnitID) {
+		unit := &lineUnit{ID: product.BaseUnitID, Factor: 1, Price: *product.Price}
+		if product.BaseUnit != nil {
+			unit.Code = product.BaseUnit.Code
+		}
+		return unit, nil
+	}
+
+	for _, productUnit := range product.Units {
+		if productUnit.UnitID != *unitID {
+			continue
+		}
+		unit := &lineUnit{
+			ID:     &productUnit.UnitID,
+			Factor: productUnit.Factor,
+			Price:  utils.RoundMoney(*product.Price * float64(productUnit.Factor)),
+		}
+		if productUnit.Unit != nil {
+			unit.Code = productUnit.Unit.Code
+		}
+		if productUnit.Price != nil {
+			unit.Price = *productUnit.Price
+		}
+		return unit, nil
+	}
+	return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s has no unit %d", product.Code, *unitID))
+}
+
+// requireSellable refuses products that only group variants; sales always
+// go to one of the variants.
+func requireSellable(product *domain.Product) error {
+	if product.HasVariants {
+		return appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s has variants, use one of them instead", product.Code))
+	}
+	return nil
+}
+
+// requireStockItem refuses products that hold no stock of their own: those
+// that group variants and composite products, whose stock is that of their
+// components.
+func requireStockItem(product *domain.Product) error {
+	if err := requireSellable(product); err != nil {
+		return err
+	}
+	if product.IsComposite {
+		return appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s is made from a recipe, use its components instead", product.Code))
+	}
+	return nil
+}
+
+// recipeComponents returns what one unit of a composite product, in a unit
+// of factor base units, takes out of the stock of each component. It is
+// empty for products that are not composite.
+func recipeComponents(product *domain.Product, factor int) ([]domain.SaleItemComponent, error) {
+	components := make([]domain.SaleItemComponent, 0, len(product.Recipe))
+	for _, item := range product.Recipe {
+		if item.Component == nil {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("a component of %s no longer exists", product.Code))
+		}
+		unit, err := resolveUnit(item.Component, item.UnitID)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, domain.SaleItemComponent{
+			ProductID:   item.ComponentID,
+			ProductCode: item.Component.Code,
+			Quantity:    item.Quantity * unit.Factor * factor,
+		})
+	}
+	return components, nil
+}
+
+func recipeProducts(uses []domain.RecipeItem) string {
+	codes := make([]string, 0, len(uses))
+	for _, use := range uses {
+		if use.Product != nil {
+			codes = append(codes, use.Product.Code)
+		}
+	}
+	return strings.Join(codes, ", ")
+}
+
+// checkBarcode trims the barcode and checks it against its type, detecting
+// the type when none is given.
+func checkBarcode(productBarcode *domain.ProductBarcode) error {
+	productBarcode.Barcode = strings.TrimSpace(productBarcode.Barcode)
+
+	var err error
+	if productBarcode.Type == "" {
+		productBarcode.Type, err = barcode.Detect(productBarcode.Barcode)
+	} else {
+		err = barcode.Validate(productBarcode.Barcode, productBarcode.Type)
+	}
+	if err != nil {
+		return appErr.Get(appErr.ErrValidation, err)
+	}
+	return nil
+}
+
+func sameOptions(a, b []domain.VariantOption) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	values := make(map[uint64]string, len(a))
+	for _, option := range a {
+		values[option.AttributeID] = strings.ToLower(option.Value)
+	}
+	for _, option := range b {
+		if value, ok := values[option.AttributeID]; !ok || value != strings.ToLower(option.Value) {
+			return false
+		}
+	}
+	return true
 }
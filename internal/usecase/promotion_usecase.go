@@ -0,0 +1,283 @@
+package usecase
+
+import (
+	"fmt"
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+	"gopos/pkg/promotion"
+	"gopos/pkg/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromotionUsecase interface {
+	FindPaginated(c *gin.Context) ([]domain.Promotion, int64, error)
+	FindByID(id uint64) (*domain.Promotion, error)
+	Create(promo *domain.Promotion) error
+	Update(promo *domain.Promotion) error
+	Delete(id uint64) error
+	Evaluate(req *domain.EvaluateCartRequest) (*domain.CartEvaluation, error)
+}
+
+type promotionUsecase struct {
+	promotionRepo repository.PromotionRepository
+	productRepo   repository.ProductRepository
+	priceListUC   PriceListUsecase
+}
+
+func NewPromotionUsecase(promotionRepo repository.PromotionRepository, productRepo repository.ProductRepository, priceListUC PriceListUsecase) PromotionUsecase {
+	return &promotionUsecase{
+		promotionRepo: promotionRepo,
+		productRepo:   productRepo,
+		priceListUC:   priceListUC,
+	}
+}
+
+func (u *promotionUsecase) FindPaginated(c *gin.Context) ([]domain.Promotion, int64, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	filters := map[string]interface{}{}
+
+	// Query params: ?code=PROMO&type=bundle&is_active=true&active_at=2025-01-01T10:00:00Z
+	if code := c.Query("code"); code != "" {
+		filters["code"] = code
+	}
+
+	if promoType := c.Query("type"); promoType != "" {
+		filters["type"] = promoType
+	}
+
+	if isActive := c.Query("is_active"); isActive != "" {
+		if active, err := strconv.ParseBool(isActive); err == nil {
+			filters["is_active"] = active
+		}
+	}
+
+	if activeAt := c.Query("active_at"); activeAt != "" {
+		if at, err := time.Parse(time.RFC3339, activeAt); err == nil {
+			filters["active_at"] = at
+		}
+	}
+
+	promotions, total, err := u.promotionRepo.FindPaginatedWithFilter(page, limit, filters)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrPromotionList, err)
+	}
+
+	return promotions, total, nil
+}
+
+func (u *promotionUsecase) FindByID(id uint64) (*domain.Promotion, error) {
+	promo, err := u.promotionRepo.FindByID(id)
+	if err != nil || promo == nil {
+		return nil, appErr.Get(appErr.ErrPromotionShow, err)
+	}
+	return promo, nil
+}
+
+func (u *promotionUsecase) Create(promo *domain.Promotion) error {
+	if err := validatePromotion(promo); err != nil {
+		return err
+	}
+
+	if err := u.promotionRepo.Create(promo); err != nil {
+		return appErr.Get(appErr.ErrPromotionCreate, err)
+	}
+	return nil
+}
+
+func (u *promotionUsecase) Update(promo *domain.Promotion) error {
+	existing, err := u.promotionRepo.FindByID(promo.ID)
+	if err != nil || existing == nil {
+		return appErr.Get(appErr.ErrPromotionShow, err)
+	}
+
+	if err := validatePromotion(promo); err != nil {
+		return err
+	}
+
+	if err := u.promotionRepo.Update(promo); err != nil {
+		return appErr.Get(appErr.ErrPromotionUpdate, err)
+	}
+	return nil
+}
+
+func (u *promotionUsecase) Delete(id uint64) error {
+	promo, err := u.promotionRepo.FindByID(id)
+	if err != nil || promo == nil {
+		return appErr.Get(appErr.ErrPromotionShow, err)
+	}
+
+	if err := u.promotionRepo.Delete(promo); err != nil {
+		return appErr.Get(appErr.ErrPromotionDelete, err)
+	}
+	return nil
+}
+
+// Evaluate previews the promotions the cart would get if it was checked out now.
+func (u *promotionUsecase) Evaluate(req *domain.EvaluateCartRequest) (*domain.CartEvaluation, error) {
+	evaluation := &domain.CartEvaluation{}
+	lines := make([]promotion.Line, 0, len(req.Items))
+
+	priceList, err := u.priceListUC.Applicable(req.OutletID, req.CustomerGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range req.Items {
+		product, err := u.productRepo.FindByID(item.ProductID)
+		if err != nil || product == nil {
+			return nil, appErr.Get(appErr.ErrProductShow, err)
+		}
+
+		unit, err := resolveUnit(product, item.UnitID)
+		if err != nil {
+			return nil, err
+		}
+		price, err := u.priceListUC.UnitPrice(priceList, product.ID, unit.Factor, item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		if price != nil {
+			unit.Price = *price
+		}
+
+		subtotal := utils.RoundMoney(unit.Price * float64(item.Quantity))
+		evaluation.Items = append(evaluation.Items, domain.CartEvaluationItem{
+			ProductID: product.ID,
+			UnitID:    unit.ID,
+			Quantity:  item.Quantity,
+			UnitPrice: unit.Price,
+			Subtotal:  subtotal,
+		})
+		evaluation.Subtotal += subtotal
+		lines = append(lines, promotion.Line{
+			ProductID:  product.ID,
+			CategoryID: product.CategoryID,
+			Quantity:   item.Quantity,
+			UnitPrice:  unit.Price,
+		})
+	}
+
+	now := time.Now()
+	promotions, err := u.promotionRepo.FindActive(now)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrPromotionEvaluate, err)
+	}
+
+	result := evaluatePromotions(promotions, lines, req.OutletID, now)
+	for i := range evaluation.Items {
+		evaluation.Items[i].Discount = result.Lines[i].Discount
+		evaluation.Items[i].Promotions = appliedPromotions(result.Lines[i])
+	}
+
+	evaluation.Subtotal = utils.RoundMoney(evaluation.Subtotal)
+	evaluation.Discount = result.Discount
+	evaluation.Total = utils.RoundMoney(evaluation.Subtotal - evaluation.Discount)
+	return evaluation, nil
+}
+
+func validatePromotion(promo *domain.Promotion) error {
+	if promo.Scope == "" {
+		promo.Scope = domain.PromotionScopeItem
+	}
+
+	switch promo.Type {
+	case domain.PromotionTypePercentage:
+		if promo.Value <= 0 || promo.Value > 100 {
+			return appErr.Get(appErr.ErrValidation, fmt.Errorf("percentage must be between 0 and 100"))
+		}
+	case domain.PromotionTypeFixed:
+		if promo.Value <= 0 {
+			return appErr.Get(appErr.ErrValidation, fmt.Errorf("fixed discount must be greater than 0"))
+		}
+	case domain.PromotionTypeBuyXGetY:
+		if promo.BuyQuantity <= 0 || promo.GetQuantity <= 0 {
+			return appErr.Get(appErr.ErrValidation, fmt.Errorf("buy_quantity and get_quantity are required"))
+		}
+	case domain.PromotionTypeBundle:
+		if promo.BundleQuantity < 2 || promo.Value <= 0 {
+			return appErr.Get(appErr.ErrValidation, fmt.Errorf("bundle needs bundle_quantity of at least 2 and a bundle price"))
+		}
+	}
+
+	if promo.Scope == domain.PromotionScopeCart {
+		if promo.Type != domain.PromotionTypePercentage && promo.Type != domain.PromotionTypeFixed {
+			return appErr.Get(appErr.ErrValidation, fmt.Errorf("%s promotions apply to items only", promo.Type))
+		}
+		if len(promo.Targets) > 0 {
+			return appErr.Get(appErr.ErrValidation, fmt.Errorf("cart promotions cannot target products"))
+		}
+	}
+
+	if promo.StartsAt != nil && promo.EndsAt != nil && !promo.EndsAt.After(*promo.StartsAt) {
+		return appErr.Get(appErr.ErrValidation, fmt.Errorf("ends_at must be after starts_at"))
+	}
+
+	if (promo.StartTime == "") != (promo.EndTime == "") {
+		return appErr.Get(appErr.ErrValidation, fmt.Errorf("start_time and end_time go together"))
+	}
+
+	for _, target := range promo.Targets {
+		if (target.ProductID == nil) == (target.CategoryID == nil) {
+			return appErr.Get(appErr.ErrValidation, fmt.Errorf("a target needs either product_id or category_id"))
+		}
+	}
+
+	return nil
+}
+
+// evaluatePromotions runs the promotion engine over the cart lines of a
+// sale in outletID; promotions for other outlets do not apply.
+func evaluatePromotions(promotions []domain.Promotion, lines []promotion.Line, outletID *uint64, at time.Time) promotion.Result {
+	rules := make([]promotion.Promotion, 0, len(promotions))
+	for _, p := range promotions {
+		rule := promotion.Promotion{
+			ID:             p.ID,
+			Code:           p.Code,
+			Name:           p.Name,
+			Type:           p.Type,
+			Scope:          p.Scope,
+			Value:          p.Value,
+			BuyQuantity:    p.BuyQuantity,
+			GetQuantity:    p.GetQuantity,
+			BundleQuantity: p.BundleQuantity,
+			MinSpend:       p.MinSpend,
+			Stackable:      p.Stackable,
+			Priority:       p.Priority,
+			StartsAt:       p.StartsAt,
+			EndsAt:         p.EndsAt,
+			StartTime:      p.StartTime,
+			EndTime:        p.EndTime,
+			OutletID:       p.OutletID,
+		}
+		for _, target := range p.Targets {
+			if target.ProductID != nil {
+				rule.ProductIDs = append(rule.ProductIDs, *target.ProductID)
+			}
+			if target.CategoryID != nil {
+				rule.CategoryIDs = append(rule.CategoryIDs, *target.CategoryID)
+			}
+		}
+		rules = append(rules, rule)
+	}
+
+	return promotion.Evaluate(promotion.Cart{Lines: lines, OutletID: outletID, At: at}, rules)
+}
+
+func appliedPromotions(line promotion.LineResult) []domain.SaleItemPromotion {
+	applied := make([]domain.SaleItemPromotion, 0, len(line.Applied))
+	for _, a := range line.Applied {
+		applied = append(applied, domain.SaleItemPromotion{
+			PromotionID:   a.PromotionID,
+			PromotionCode: a.Code,
+			PromotionName: a.Name,
+			Discount:      a.Discount,
+		})
+	}
+	return applied
+}
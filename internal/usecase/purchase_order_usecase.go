@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"fmt"
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PurchaseOrderUsecase interface {
+	FindPaginated(c *gin.Context) ([]domain.PurchaseOrder, int64, error)
+	FindByID(id uint64) (*domain.PurchaseOrder, error)
+	Create(req *domain.PurchaseOrderRequest) (*domain.PurchaseOrder, error)
+	Update(id uint64, req *domain.PurchaseOrderRequest) (*domain.PurchaseOrder, error)
+	Send(id uint64) (*domain.PurchaseOrder, error)
+	Cancel(id uint64) (*domain.PurchaseOrder, error)
+	FindReceipts(id uint64) ([]domain.GoodsReceipt, error)
+	Receive(id uint64, req *domain.GoodsReceiptRequest) (*domain.GoodsReceipt, error)
+}
+
+type purchaseOrderUsecase struct {
+	purchaseOrderRepo repository.PurchaseOrderRepository
+	supplierRepo      repository.SupplierRepository
+	productRepo       repository.ProductRepository
+	outletRepo        repository.OutletRepository
+}
+
+func NewPurchaseOrderUsecase(purchaseOrderRepo repository.PurchaseOrderRepository, supplierRepo repository.SupplierRepository, productRepo repository.ProductRepository, outletRepo repository.OutletRepository) PurchaseOrderUsecase {
+	return &purchaseOrderUsecase{
+		purchaseOrderRepo: purchaseOrderRepo,
+		supplierRepo:      supplierRepo,
+		productRepo:       productRepo,
+		outletRepo:        outletRepo,
+	}
+}
+
+func (u *purchaseOrderUsecase) FindPaginated(c *gin.Context) ([]domain.PurchaseOrder, int64, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	filters := map[string]interface{}{}
+
+	// Query params: ?status=sent&supplier_id=1&outlet_id=1&order_no=PO&start_date=2025-01-01&end_date=2025-01-31
+	dateRangeFilters(c, filters)
+
+	if status := c.Query("status"); status != "" {
+		filters["status"] = status
+	}
+	if supplierID := c.Query("supplier_id"); supplierID != "" {
+		if id, err := strconv.ParseUint(supplierID, 10, 64); err == nil {
+			filters["supplier_id"] = id
+		}
+	}
+	if outletID := c.Query("outlet_id"); outletID != "" {
+		if id, err := strconv.ParseUint(outletID, 10, 64); err == nil {
+			filters["outlet_id"] = id
+		}
+	}
+	if orderNo := c.Query("order_no"); orderNo != "" {
+		filters["order_no"] = orderNo
+	}
+
+	orders, total, err := u.purchaseOrderRepo.FindPaginatedWithFilter(page, limit, filters)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrPurchaseOrderList, err)
+	}
+
+	return orders, total, nil
+}
+
+func (u *purchaseOrderUsecase) FindByID(id uint64) (*domain.PurchaseOrder, error) {
+	order, err := u.purchaseOrderRepo.FindByID(id)
+	if err != nil || order == nil {
+		return nil, appErr.Get(appErr.ErrPurchaseOrderShow, err)
+	}
+	return order, nil
+}
+
+func (u *purchaseOrderUsecase) Create(req *domain.PurchaseOrderRequest) (*domain.PurchaseOrder, error) {
+	order := &domain.PurchaseOrder{
+		Status:    domain.PurchaseOrderStatusDraft,
+		CreatedBy: req.CreatedBy,
+	}
+	if err := u.fill(order, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.purchaseOrderRepo.Create(order); err != nil {
+		return nil, appErr.Get(appErr.ErrPurchaseOrderCreate, err)
+	}
+
+	return u.FindByID(order.ID)
+}
+
+func (u *purchaseOrderUsecase) Update(id uint64, req *domain.PurchaseOrderRequest) (*domain.PurchaseOrder, error) {
+	order, err := u.findInStatus(id, domain.PurchaseOrderStatusDraft)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.fill(order, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.purchaseOrderRepo.Update(order); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrPurchaseOrderUpdate, err)
+	}
+
+	return u.FindByID(order.ID)
+}
+
+func (u *purchaseOrderUsecase) Send(id uint64) (*domain.PurchaseOrder, error) {
+	return u.transition(id, []string{domain.PurchaseOrderStatusDraft}, domain.PurchaseOrderStatusSent)
+}
+
+// Cancel is only allowed before anything was received; goods already in
+// stock are corrected with a stock adjustment instead.
+func (u *purchaseOrderUsecase) Cancel(id uint64) (*domain.PurchaseOrder, error) {
+	return u.transition(id, []string{domain.PurchaseOrderStatusDraft, domain.PurchaseOrderStatusSent}, domain.PurchaseOrderStatusCancelled)
+}
+
+func (u *purchaseOrderUsecase) FindReceipts(id uint64) ([]domain.GoodsReceipt, error) {
+	order, err := u.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts, err := u.purchaseOrderRepo.FindReceipts(order.ID)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrPurchaseOrderShow, err)
+	}
+	return receipts, nil
+}
+
+func (u *purchaseOrderUsecase) Receive(id uint64, req *domain.GoodsReceiptRequest) (*domain.GoodsReceipt, error) {
+	order, err := u.findInStatus(id, domain.PurchaseOrderStatusSent, domain.PurchaseOrderStatusPartiallyReceived)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make(map[uint64]*domain.PurchaseOrderItem, len(order.Items))
+	for i := range order.Items {
+		lines[order.Items[i].ID] = &order.Items[i]
+	}
+
+	receipt := &domain.GoodsReceipt{
+		PurchaseOrderID: order.ID,
+		ReceivedBy:      req.ReceivedBy,
+		Note:            req.Note,
+	}
+
+	requested := make(map[uint64]int, len(req.Items))
+	for _, r := range req.Items {
+		line, ok := lines[r.PurchaseOrderItemID]
+		if !ok {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("line %d is not part of purchase order %s", r.PurchaseOrderItemID, order.OrderNo))
+		}
+
+		requested[line.ID] += r.Quantity
+		if outstanding := line.Quantity - line.ReceivedQuantity; requested[line.ID] > outstanding {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("only %d left to receive on line %d", outstanding, line.ID))
+		}
+
+		if line.Product != nil && line.Product.TrackExpiry && (r.LotNo == "" || r.ExpiryDate == nil) {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s needs a lot number and expiry date", line.Product.Code))
+		}
+		if r.ExpiryDate != nil && r.LotNo == "" {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("line %d has an expiry date but no lot number", line.ID))
+		}
+
+		unitCost := line.UnitCost
+		if r.UnitCost != nil {
+			unitCost = *r.UnitCost
+		}
+
+		subtotal := utils.RoundMoney(unitCost * float64(r.Quantity))
+		receipt.Items = append(receipt.Items, domain.GoodsReceiptItem{
+			PurchaseOrderItemID: line.ID,
+			ProductID:           line.ProductID,
+			UnitFactor:          line.UnitFactor,
+			Quantity:            r.Quantity,
+			UnitCost:            unitCost,
+			Subtotal:            subtotal,
+			LotNo:               r.LotNo,
+			ExpiryDate:          r.ExpiryDate,
+		})
+		receipt.Total += subtotal
+	}
+	receipt.Total = utils.RoundMoney(receipt.Total)
+
+	if err := u.purchaseOrderRepo.Receive(order, receipt); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrGoodsReceiptCreate, err)
+	}
+
+	return receipt, nil
+}
+
+// fill copies the request onto the order, pricing every line and checking
+// the supplier, outlet and products exist.
+func (u *purchaseOrderUsecase) fill(order *domain.PurchaseOrder, req *domain.PurchaseOrderRequest) error {
+	supplier, err := u.supplierRepo.FindByID(req.SupplierID)
+	if err != nil || supplier == nil {
+		return appErr.Get(appErr.ErrSupplierShow, err)
+	}
+	if !supplier.IsActive {
+		return appErr.Get(appErr.ErrValidation, fmt.Errorf("supplier %s is inactive", supplier.Code))
+	}
+
+	outlet, err := resolveOutlet(u.outletRepo, req.OutletID)
+	if err != nil {
+		return err
+	}
+
+	order.SupplierID = supplier.ID
+	order.OutletID = outlet.ID
+	order.ExpectedDate = req.ExpectedDate
+	order.Note = req.Note
+	order.Total = 0
+	order.Items = make([]domain.PurchaseOrderItem, 0, len(req.Items))
+
+	for _, item := range req.Items {
+		product, err := u.productRepo.FindByID(item.ProductID)
+		if err != nil || product == nil {
+			return appErr.Get(appErr.ErrProductShow, err)
+		}
+		if err := requireStockItem(product); err != nil {
+			return err
+		}
+		unit, err := resolveUnit(product, item.UnitID)
+		if err != nil {
+			return err
+		}
+
+		subtotal := utils.RoundMoney(item.UnitCost * float64(item.Quantity))
+		order.Items = append(order.Items, domain.PurchaseOrderItem{
+			ProductID:  product.ID,
+			UnitID:     unit.ID,
+			UnitCode:   unit.Code,
+			UnitFactor: unit.Factor,
+			Quantity:   item.Quantity,
+			UnitCost:   item.UnitCost,
+			Subtotal:   subtotal,
+		})
+		order.Total += subtotal
+	}
+	order.Total = utils.RoundMoney(order.Total)
+	return nil
+}
+
+func (u *purchaseOrderUsecase) transition(id uint64, from []string, to string) (*domain.PurchaseOrder, error) {
+	order, err := u.findInStatus(id, from...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.purchaseOrderRepo.UpdateStatus(order, from, to); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrPurchaseOrderUpdate, err)
+	}
+	return order, nil
+}
+
+func (u *purchaseOrderUsecase) findInStatus(id uint64, statuses ...string) (*domain.PurchaseOrder, error) {
+	order, err := u.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, status := range statuses {
+		if order.Status == status {
+			return order, nil
+		}
+	}
+	return nil, appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("purchase order %s is %s", order.OrderNo, order.Status))
+}
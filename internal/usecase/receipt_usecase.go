@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+	"gopos/pkg/receipt"
+	"strings"
+)
+
+type ReceiptUsecase interface {
+	Render(saleID uint64, format string) ([]byte, string, error)
+}
+
+type receiptUsecase struct {
+	saleRepo         repository.SaleRepository
+	userRepo         domain.UserRepository
+	outletRepo       repository.OutletRepository
+	storeSettingRepo repository.StoreSettingRepository
+}
+
+func NewReceiptUsecase(saleRepo repository.SaleRepository, userRepo domain.UserRepository, outletRepo repository.OutletRepository, storeSettingRepo repository.StoreSettingRepository) ReceiptUsecase {
+	return &receiptUsecase{
+		saleRepo:         saleRepo,
+		userRepo:         userRepo,
+		outletRepo:       outletRepo,
+		storeSettingRepo: storeSettingRepo,
+	}
+}
+
+// Render returns the receipt of a sale in the requested format
+// (escpos, text or html) together with its content type. The receipt is
+// headed with the name, address and phone of the sale's outlet; the store
+// settings fill in whichever of them the outlet leaves empty.
+func (u *receiptUsecase) Render(saleID uint64, format string) ([]byte, string, error) {
+	sale, err := u.saleRepo.FindByID(saleID)
+	if err != nil || sale == nil {
+		return nil, "", appErr.Get(appErr.ErrSaleShow, err)
+	}
+
+	setting, err := u.storeSettingRepo.Get()
+	if err != nil {
+		return nil, "", appErr.Get(appErr.ErrStoreSettingShow, err)
+	}
+	if setting == nil {
+		setting = defaultStoreSetting()
+	}
+
+	outlet, err := u.outletRepo.FindByID(sale.OutletID)
+	if err != nil {
+		return nil, "", appErr.Get(appErr.ErrOutletShow, err)
+	}
+	if outlet == nil {
+		outlet = &domain.Outlet{}
+	}
+
+	cashier := fmt.Sprintf("#%d", sale.CashierID)
+	if user, err := u.userRepo.FindByID(sale.CashierID); err == nil && user != nil {
+		cashier = user.Name
+	}
+
+	content, contentType, err := receipt.Render(format, buildReceipt(sale, cashier), receipt.Template{
+		StoreName:  firstNonEmpty(outlet.Name, setting.StoreName),
+		Address:    firstNonEmpty(outlet.Address, setting.Address),
+		Phone:      firstNonEmpty(outlet.Phone, setting.Phone),
+		Header:     setting.ReceiptHeader,
+		Footer:     setting.ReceiptFooter,
+		PaperWidth: setting.PaperWidth,
+	})
+	if err != nil {
+		if errors.Is(err, receipt.ErrUnknownFormat) {
+			return nil, "", appErr.Get(appErr.ErrBadRequest, err)
+		}
+		return nil, "", appErr.Get(appErr.ErrReceiptRender, err)
+	}
+
+	return content, contentType, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func buildReceipt(sale *domain.Sale, cashier string) receipt.Receipt {
+	r := receipt.Receipt{
+		Number:      sale.InvoiceNo,
+		Date:        sale.CreatedAt,
+		Cashier:     cashier,
+		Subtotal:    sale.Subtotal,
+		Discount:    sale.DiscountTotal,
+		TaxIncluded: sale.PriceIncludesTax,
+		Total:       sale.Total,
+		Paid:        sale.PaidAmount,
+		Change:      sale.ChangeAmount,
+		Voided:      sale.Status == domain.SaleStatusVoided,
+	}
+
+	for _, tax := range sale.Taxes {
+		r.Taxes = append(r.Taxes, receipt.Tax{
+			Name:   tax.TaxName,
+			Rate:   tax.Rate,
+			Amount: tax.TaxAmount,
+		})
+	}
+
+	for _, item := range sale.Items {
+		r.Lines = append(r.Lines, receipt.Line{
+			Name:      item.ProductName,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			Subtotal:  item.Subtotal,
+			Discount:  item.Discount,
+		})
+	}
+
+	names := make(map[uint64]string, len(sale.Items))
+	for _, item := range sale.Items {
+		names[item.ID] = item.ProductName
+	}
+	for _, void := range sale.Voids {
+		for _, item := range void.Items {
+			r.Voids = append(r.Voids, receipt.VoidLine{
+				Name:     names[item.SaleItemID],
+				Quantity: item.Quantity,
+				Amount:   item.Amount,
+			})
+		}
+	}
+
+	for _, payment := range sale.Payments {
+		name := fmt.Sprintf("Payment #%d", payment.PaymentMethodID)
+		if payment.PaymentMethod != nil {
+			name = payment.PaymentMethod.Name
+		}
+		r.Payments = append(r.Payments, receipt.Payment{
+			Name:   name,
+			Amount: payment.Tendered,
+		})
+	}
+
+	return r
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"fmt"
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+	"gopos/pkg/utils"
+)
+
+type SaleReturnUsecase interface {
+	FindBySaleID(saleID uint64) ([]domain.SaleReturn, error)
+	Create(req *domain.CreateSaleReturnRequest) (*domain.SaleReturn, error)
+}
+
+type saleReturnUsecase struct {
+	saleReturnRepo    repository.SaleReturnRepository
+	saleRepo          repository.SaleRepository
+	paymentMethodRepo repository.PaymentMethodRepository
+	shiftRepo         repository.ShiftRepository
+}
+
+func NewSaleReturnUsecase(saleReturnRepo repository.SaleReturnRepository, saleRepo repository.SaleRepository, paymentMethodRepo repository.PaymentMethodRepository, shiftRepo repository.ShiftRepository) SaleReturnUsecase {
+	return &saleReturnUsecase{
+		saleReturnRepo:    saleReturnRepo,
+		saleRepo:          saleRepo,
+		paymentMethodRepo: paymentMethodRepo,
+		shiftRepo:         shiftRepo,
+	}
+}
+
+func (u *saleReturnUsecase) FindBySaleID(saleID uint64) ([]domain.SaleReturn, error) {
+	returns, err := u.saleReturnRepo.FindBySaleID(saleID)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrSaleReturnList, err)
+	}
+	return returns, nil
+}
+
+func (u *saleReturnUsecase) Create(req *domain.CreateSaleReturnRequest) (*domain.SaleReturn, error) {
+	sale, err := u.saleRepo.FindByID(req.SaleID)
+	if err != nil || sale == nil {
+		return nil, appErr.Get(appErr.ErrSaleShow, err)
+	}
+
+	if sale.Status != domain.SaleStatusCompleted {
+		return nil, appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("sale %d is %s", sale.ID, sale.Status))
+	}
+
+	// Refunds are paid out of the cashier's drawer, so they belong to a shift;
+	// the returned goods go back into the stock of the shift's outlet
+	shift, err := u.shiftRepo.FindOpenByUser(req.CashierID)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrShiftShow, err)
+	}
+	if shift == nil {
+		return nil, appErr.Get(appErr.ErrShiftNotOpen, nil)
+	}
+
+	method, err := u.paymentMethodRepo.FindByID(req.PaymentMethodID)
+	if err != nil || method == nil {
+		return nil, appErr.Get(appErr.ErrPaymentMethodShow, err)
+	}
+
+	if !method.IsActive {
+		return nil, appErr.Get(appErr.ErrPaymentFailed, fmt.Errorf("payment method %s is not active", method.Code))
+	}
+
+	saleItems := make(map[uint64]domain.SaleItem, len(sale.Items))
+	for _, item := range sale.Items {
+		saleItems[item.ID] = item
+	}
+
+	requested := make(map[uint64]int, len(req.Items))
+	saleReturn := &domain.SaleReturn{
+		SaleID:          sale.ID,
+		CashierID:       req.CashierID,
+		ShiftID:         &shift.ID,
+		OutletID:        shift.OutletID,
+		PaymentMethodID: method.ID,
+		Reason:          req.Reason,
+	}
+
+	for _, line := range req.Items {
+		item, ok := saleItems[line.SaleItemID]
+		if !ok {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("sale item %d does not belong to sale %d", line.SaleItemID, sale.ID))
+		}
+
+		requested[item.ID] += line.Quantity
+		if requested[item.ID] > item.Quantity {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("return quantity for %s exceeds sold quantity %d", item.ProductCode, item.Quantity))
+		}
+		left := item.Quantity - item.ReturnedQuantity - item.VoidedQuantity
+		if requested[item.ID] > left {
+			return nil, appErr.Get(appErr.ErrAlreadyProcessed, fmt.Errorf("only %d of %s left to return", left, item.ProductCode))
+		}
+
+		// Refund what was actually charged for the line, per unit
+		unitPrice := item.Total / float64(item.Quantity)
+		subtotal := utils.RoundMoney(unitPrice * float64(line.Quantity))
+		taxAmount := utils.RoundMoney(item.TaxAmount / float64(item.Quantity) * float64(line.Quantity))
+
+		saleReturn.Items = append(saleReturn.Items, domain.SaleReturnItem{
+			SaleItemID:    item.ID,
+			ProductID:     item.ProductID,
+			Quantity:      line.Quantity,
+			UnitFactor:    item.UnitFactor,
+			UnitPrice:     utils.RoundMoney(unitPrice),
+			Subtotal:      subtotal,
+			TaxRateID:     item.TaxRateID,
+			TaxableAmount: utils.RoundMoney(subtotal - taxAmount),
+			TaxAmount:     taxAmount,
+		})
+		saleReturn.TotalRefund += subtotal
+	}
+
+	saleReturn.TotalRefund = utils.RoundMoney(saleReturn.TotalRefund)
+
+	if err := u.saleReturnRepo.Create(saleReturn); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) || appErr.Is(err, appErr.ErrShiftNotOpen) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrSaleReturnCreate, err)
+	}
+
+	return saleReturn, nil
+}
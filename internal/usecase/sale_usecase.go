@@ -0,0 +1,382 @@
+package usecase
+
+import (
+	"fmt"
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+	"gopos/pkg/promotion"
+	"gopos/pkg/tax"
+	"gopos/pkg/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SaleUsecase interface {
+	FindPaginated(c *gin.Context) ([]domain.Sale, int64, error)
+	FindByID(id uint64) (*domain.Sale, error)
+	Create(req *domain.CreateSaleRequest) (*domain.Sale, error)
+}
+
+type saleUsecase struct {
+	saleRepo          repository.SaleRepository
+	productRepo       repository.ProductRepository
+	paymentMethodRepo repository.PaymentMethodRepository
+	shiftRepo         repository.ShiftRepository
+	taxRateRepo       repository.TaxRateRepository
+	storeSettingRepo  repository.StoreSettingRepository
+	promotionRepo     repository.PromotionRepository
+	priceListUC       PriceListUsecase
+}
+
+func NewSaleUsecase(saleRepo repository.SaleRepository, productRepo repository.ProductRepository, paymentMethodRepo repository.PaymentMethodRepository, shiftRepo repository.ShiftRepository, taxRateRepo repository.TaxRateRepository, storeSettingRepo repository.StoreSettingRepository, promotionRepo repository.PromotionRepository, priceListUC PriceListUsecase) SaleUsecase {
+	return &saleUsecase{
+		saleRepo:          saleRepo,
+		productRepo:       productRepo,
+		paymentMethodRepo: paymentMethodRepo,
+		shiftRepo:         shiftRepo,
+		taxRateRepo:       taxRateRepo,
+		storeSettingRepo:  storeSettingRepo,
+		promotionRepo:     promotionRepo,
+		priceListUC:       priceListUC,
+	}
+}
+
+func (u *saleUsecase) FindPaginated(c *gin.Context) ([]domain.Sale, int64, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	filters := map[string]interface{}{}
+
+	// Query params: ?start_date=2025-01-01&end_date=2025-01-31&cashier_id=1&outlet_id=1
+	dateRangeFilters(c, filters)
+
+	if cashierID := c.Query("cashier_id"); cashierID != "" {
+		if id, err := strconv.Atoi(cashierID); err == nil {
+			filters["cashier_id"] = id
+		}
+	}
+
+	if shiftID := c.Query("shift_id"); shiftID != "" {
+		if id, err := strconv.Atoi(shiftID); err == nil {
+			filters["shift_id"] = id
+		}
+	}
+
+	if outletID := c.Query("outlet_id"); outletID != "" {
+		if id, err := strconv.Atoi(outletID); err == nil {
+			filters["outlet_id"] = id
+		}
+	}
+
+	if invoiceNo := c.Query("invoice_no"); invoiceNo != "" {
+		filters["invoice_no"] = invoiceNo
+	}
+
+	if status := c.Query("status"); status != "" {
+		filters["status"] = status
+	}
+
+	sales, total, err := u.saleRepo.FindPaginatedWithFilter(page, limit, filters)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrSaleList, err)
+	}
+
+	return sales, total, nil
+}
+
+func (u *saleUsecase) FindByID(id uint64) (*domain.Sale, error) {
+	sale, err := u.saleRepo.FindByID(id)
+	if err != nil || sale == nil {
+		return nil, appErr.Get(appErr.ErrSaleShow, err)
+	}
+	return sale, nil
+}
+
+func (u *saleUsecase) Create(req *domain.CreateSaleRequest) (*domain.Sale, error) {
+	shift, err := u.shiftRepo.FindOpenByUser(req.CashierID)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrShiftShow, err)
+	}
+	if shift == nil {
+		return nil, appErr.Get(appErr.ErrShiftNotOpen, nil)
+	}
+
+	setting, err := u.storeSettingRepo.Get()
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrStoreSettingShow, err)
+	}
+
+	priceList, err := u.priceListUC.Applicable(&shift.OutletID, req.CustomerGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	sale := &domain.Sale{
+		CashierID:        req.CashierID,
+		ShiftID:          &shift.ID,
+		OutletID:         shift.OutletID,
+		DraftOrderID:     req.DraftOrderID,
+		CustomerGroupID:  req.CustomerGroupID,
+		Status:           domain.SaleStatusCompleted,
+		PriceIncludesTax: setting != nil && setting.PriceIncludesTax,
+		Note:             req.Note,
+	}
+	if priceList != nil {
+		sale.PriceListID = &priceList.ID
+	}
+
+	products := make([]*domain.Product, 0, len(req.Items))
+	lines := make([]promotion.Line, 0, len(req.Items))
+
+	for _, line := range req.Items {
+		product, err := u.productRepo.FindByID(line.ProductID)
+		if err != nil || product == nil {
+			return nil, appErr.Get(appErr.ErrProductShow, err)
+		}
+
+		if !product.IsActive {
+			return nil, appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("product %s is not active", product.Code))
+		}
+		if err := requireSellable(product); err != nil {
+			return nil, err
+		}
+
+		unit, err := resolveUnit(product, line.UnitID)
+		if err != nil {
+			return nil, err
+		}
+		price, err := u.priceListUC.UnitPrice(priceList, product.ID, unit.Factor, line.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		if price != nil {
+			unit.Price = *price
+		}
+
+		// A composite product is sold out when one of its components is
+		components, err := recipeComponents(product, unit.Factor)
+		if err != nil {
+			return nil, err
+		}
+		parts := components
+		if len(parts) == 0 {
+			parts = []domain.SaleItemComponent{{ProductID: product.ID, ProductCode: product.Code, Quantity: unit.Factor}}
+		}
+		for _, part := range parts {
+			stock, err := u.productRepo.OutletStock(part.ProductID, shift.OutletID)
+			if err != nil {
+				return nil, appErr.Get(appErr.ErrProductShow, err)
+			}
+			if stock < line.Quantity*part.Quantity {
+				return nil, appErr.Get(appErr.ErrInsufficientStock, fmt.Errorf("product %s has %d in stock", part.ProductCode, stock))
+			}
+		}
+
+		sale.Items = append(sale.Items, domain.SaleItem{
+			ProductID:   product.ID,
+			ProductCode: product.Code,
+			ProductName: product.Name,
+			UnitID:      unit.ID,
+			UnitCode:    unit.Code,
+			UnitFactor:  unit.Factor,
+			Quantity:    line.Quantity,
+			UnitPrice:   unit.Price,
+			CostPrice:   utils.RoundMoney(product.CostPrice * float64(unit.Factor)),
+			Subtotal:    utils.RoundMoney(unit.Price * float64(line.Quantity)),
+			Components:  components,
+		})
+		products = append(products, product)
+		lines = append(lines, promotion.Line{
+			ProductID:  product.ID,
+			CategoryID: product.CategoryID,
+			Quantity:   line.Quantity,
+			UnitPrice:  unit.Price,
+		})
+	}
+
+	now := time.Now()
+	promotions, err := u.promotionRepo.FindActive(now)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrPromotionEvaluate, err)
+	}
+	discounts := evaluatePromotions(promotions, lines, &shift.OutletID, now)
+
+	rates := map[uint64]*domain.TaxRate{}
+	taxes := map[uint64]*domain.SaleTax{}
+
+	for i := range sale.Items {
+		item := &sale.Items[i]
+		item.Discount = discounts.Lines[i].Discount
+		item.Promotions = appliedPromotions(discounts.Lines[i])
+
+		// Tax is charged on what is left after the discount
+		net := utils.RoundMoney(item.Subtotal - item.Discount)
+		item.Total = net
+
+		rate, err := u.taxRateFor(products[i], rates)
+		if err != nil {
+			return nil, err
+		}
+		if rate != nil {
+			base, amount := tax.Split(net, rate.Rate, sale.PriceIncludesTax)
+			item.TaxRateID = &rate.ID
+			item.TaxRate = rate.Rate
+			item.TaxAmount = amount
+			if !sale.PriceIncludesTax {
+				item.Total = utils.RoundMoney(net + amount)
+			}
+
+			saleTax, ok := taxes[rate.ID]
+			if !ok {
+				saleTax = &domain.SaleTax{TaxRateID: rate.ID, TaxCode: rate.Code, TaxName: rate.Name, Rate: rate.Rate}
+				taxes[rate.ID] = saleTax
+			}
+			saleTax.TaxableAmount += base
+			saleTax.TaxAmount += amount
+		}
+
+		sale.Subtotal += item.Subtotal
+		sale.DiscountTotal += item.Discount
+		sale.TaxTotal += item.TaxAmount
+		sale.Total += item.Total
+	}
+
+	sale.Subtotal = utils.RoundMoney(sale.Subtotal)
+	sale.DiscountTotal = utils.RoundMoney(sale.DiscountTotal)
+	sale.TaxTotal = utils.RoundMoney(sale.TaxTotal)
+	sale.Total = utils.RoundMoney(sale.Total)
+
+	for _, item := range sale.Items {
+		if item.TaxRateID == nil {
+			continue
+		}
+		if saleTax, ok := taxes[*item.TaxRateID]; ok {
+			saleTax.TaxableAmount = utils.RoundMoney(saleTax.TaxableAmount)
+			saleTax.TaxAmount = utils.RoundMoney(saleTax.TaxAmount)
+			sale.Taxes = append(sale.Taxes, *saleTax)
+			delete(taxes, *item.TaxRateID)
+		}
+	}
+
+	if err := u.settlePayments(sale, req.Payments); err != nil {
+		return nil, err
+	}
+
+	if err := u.saleRepo.Create(sale); err != nil {
+		if appErr.Is(err, appErr.ErrInsufficientStock) || appErr.Is(err, appErr.ErrStockExpired) || appErr.Is(err, appErr.ErrAlreadyProcessed) || appErr.Is(err, appErr.ErrShiftNotOpen) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrSaleCreate, err)
+	}
+
+	return sale, nil
+}
+
+// taxRateFor returns the active tax rate of the product, falling back to
+// the one of its category. Rates are cached in rates for the current sale.
+func (u *saleUsecase) taxRateFor(product *domain.Product, rates map[uint64]*domain.TaxRate) (*domain.TaxRate, error) {
+	rateID := product.TaxRateID
+	if rateID == nil && product.Category != nil {
+		rateID = product.Category.TaxRateID
+	}
+	if rateID == nil {
+		return nil, nil
+	}
+
+	rate, ok := rates[*rateID]
+	if !ok {
+		var err error
+		rate, err = u.taxRateRepo.FindByID(*rateID)
+		if err != nil {
+			return nil, appErr.Get(appErr.ErrTaxRateShow, err)
+		}
+		rates[*rateID] = rate
+	}
+
+	if rate == nil || !rate.IsActive {
+		return nil, nil
+	}
+	return rate, nil
+}
+
+// settlePayments attaches the tenders to the sale and computes the change.
+// Only cash may be overpaid; any other method has to match what is still
+// owed, otherwise the sale is rejected with ErrPaymentFailed.
+func (u *saleUsecase) settlePayments(sale *domain.Sale, requests []domain.PaymentRequest) error {
+	var tendered, nonCash float64
+
+	payments := make([]domain.Payment, 0, len(requests))
+	methodTypes := make([]string, 0, len(requests))
+	for _, req := range requests {
+		method, err := u.paymentMethodRepo.FindByID(req.PaymentMethodID)
+		if err != nil || method == nil {
+			return appErr.Get(appErr.ErrPaymentMethodShow, err)
+		}
+
+		if !method.IsActive {
+			return appErr.Get(appErr.ErrPaymentFailed, fmt.Errorf("payment method %s is not active", method.Code))
+		}
+
+		amount := utils.RoundMoney(req.Amount)
+		tendered += amount
+		if method.Type != domain.PaymentTypeCash {
+			nonCash += amount
+		}
+
+		payments = append(payments, domain.Payment{
+			PaymentMethodID: method.ID,
+			Amount:          amount,
+			Tendered:        amount,
+			Reference:       req.Reference,
+		})
+		methodTypes = append(methodTypes, method.Type)
+	}
+
+	tendered = utils.RoundMoney(tendered)
+	if tendered < sale.Total {
+		return appErr.Get(appErr.ErrPaymentFailed, fmt.Errorf("paid %.2f of %.2f", tendered, sale.Total))
+	}
+
+	if utils.RoundMoney(nonCash) > sale.Total {
+		return appErr.Get(appErr.ErrPaymentFailed, fmt.Errorf("non-cash payments exceed the total"))
+	}
+
+	// Change is given back from the cash tenders, last one first
+	change := utils.RoundMoney(tendered - sale.Total)
+	remaining := change
+	for i := len(payments) - 1; i >= 0 && remaining > 0; i-- {
+		if methodTypes[i] != domain.PaymentTypeCash {
+			continue
+		}
+		deduct := payments[i].Amount
+		if deduct > remaining {
+			deduct = remaining
+		}
+		payments[i].Amount = utils.RoundMoney(payments[i].Amount - deduct)
+		remaining = utils.RoundMoney(remaining - deduct)
+	}
+
+	sale.Payments = payments
+	sale.PaidAmount = tendered
+	sale.ChangeAmount = change
+	return nil
+}
+
+// dateRangeFilters reads ?start_date and ?end_date (YYYY-MM-DD). The end
+// date is inclusive, so it is stored as the start of the following day.
+func dateRangeFilters(c *gin.Context, filters map[string]interface{}) {
+	if startDate := c.Query("start_date"); startDate != "" {
+		if date, err := time.ParseInLocation("2006-01-02", startDate, time.Local); err == nil {
+			filters["start_date"] = date
+		}
+	}
+
+	if endDate := c.Query("end_date"); endDate != "" {
+		if date, err := time.ParseInLocation("2006-01-02", endDate, time.Local); err == nil {
+			filters["end_date"] = date.AddDate(0, 0, 1)
+		}
+	}
+}
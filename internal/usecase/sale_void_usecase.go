@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"fmt"
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+	"gopos/pkg/utils"
+)
+
+type SaleVoidUsecase interface {
+	FindBySaleID(saleID uint64) ([]domain.SaleVoid, error)
+	Create(req *domain.VoidSaleRequest) (*domain.SaleVoid, error)
+}
+
+type saleVoidUsecase struct {
+	saleVoidRepo      repository.SaleVoidRepository
+	saleRepo          repository.SaleRepository
+	paymentMethodRepo repository.PaymentMethodRepository
+	shiftRepo         repository.ShiftRepository
+	userRepo          domain.UserRepository
+	authorizeRepo     repository.AuthorizeRepository
+}
+
+func NewSaleVoidUsecase(saleVoidRepo repository.SaleVoidRepository, saleRepo repository.SaleRepository, paymentMethodRepo repository.PaymentMethodRepository, shiftRepo repository.ShiftRepository, userRepo domain.UserRepository, authorizeRepo repository.AuthorizeRepository) SaleVoidUsecase {
+	return &saleVoidUsecase{
+		saleVoidRepo:      saleVoidRepo,
+		saleRepo:          saleRepo,
+		paymentMethodRepo: paymentMethodRepo,
+		shiftRepo:         shiftRepo,
+		userRepo:          userRepo,
+		authorizeRepo:     authorizeRepo,
+	}
+}
+
+func (u *saleVoidUsecase) FindBySaleID(saleID uint64) ([]domain.SaleVoid, error) {
+	voids, err := u.saleVoidRepo.FindBySaleID(saleID)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrSaleVoidList, err)
+	}
+	return voids, nil
+}
+
+func (u *saleVoidUsecase) Create(req *domain.VoidSaleRequest) (*domain.SaleVoid, error) {
+	approver, err := u.approve(req)
+	if err != nil {
+		return nil, err
+	}
+
+	sale, err := u.saleRepo.FindByID(req.SaleID)
+	if err != nil || sale == nil {
+		return nil, appErr.Get(appErr.ErrSaleShow, err)
+	}
+
+	if sale.Status != domain.SaleStatusCompleted {
+		return nil, appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("sale %d is %s", sale.ID, sale.Status))
+	}
+
+	// The money goes back out of the requesting cashier's drawer
+	shift, err := u.shiftRepo.FindOpenByUser(req.RequestedBy)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrShiftShow, err)
+	}
+	if shift == nil {
+		return nil, appErr.Get(appErr.ErrShiftNotOpen, nil)
+	}
+
+	// Default to the tender the sale was paid with
+	methodID := req.PaymentMethodID
+	if methodID == 0 && len(sale.Payments) > 0 {
+		methodID = sale.Payments[0].PaymentMethodID
+	}
+
+	method, err := u.paymentMethodRepo.FindByID(methodID)
+	if err != nil || method == nil {
+		return nil, appErr.Get(appErr.ErrPaymentMethodShow, err)
+	}
+
+	if !method.IsActive {
+		return nil, appErr.Get(appErr.ErrPaymentFailed, fmt.Errorf("payment method %s is not active", method.Code))
+	}
+
+	saleVoid := &domain.SaleVoid{
+		SaleID:          sale.ID,
+		ShiftID:         &shift.ID,
+		OutletID:        sale.OutletID,
+		RequestedBy:     req.RequestedBy,
+		ApprovedBy:      approver.ID,
+		PaymentMethodID: method.ID,
+		Reason:          req.Reason,
+	}
+
+	// Without items every line that is still open gets voided
+	lines := req.Items
+	if len(lines) == 0 {
+		for _, item := range sale.Items {
+			if left := item.Quantity - item.ReturnedQuantity - item.VoidedQuantity; left > 0 {
+				lines = append(lines, domain.SaleReturnItemRequest{SaleItemID: item.ID, Quantity: left})
+			}
+		}
+		if len(lines) == 0 {
+			return nil, appErr.Get(appErr.ErrAlreadyProcessed, fmt.Errorf("sale %d has nothing left to void", sale.ID))
+		}
+	}
+
+	saleItems := make(map[uint64]domain.SaleItem, len(sale.Items))
+	for _, item := range sale.Items {
+		saleItems[item.ID] = item
+	}
+
+	requested := make(map[uint64]int, len(lines))
+	for _, line := range lines {
+		item, ok := saleItems[line.SaleItemID]
+		if !ok {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("sale item %d does not belong to sale %d", line.SaleItemID, sale.ID))
+		}
+
+		requested[item.ID] += line.Quantity
+		left := item.Quantity - item.ReturnedQuantity - item.VoidedQuantity
+		if requested[item.ID] > item.Quantity {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("void quantity for %s exceeds sold quantity %d", item.ProductCode, item.Quantity))
+		}
+		if requested[item.ID] > left {
+			return nil, appErr.Get(appErr.ErrAlreadyProcessed, fmt.Errorf("only %d of %s left to void", left, item.ProductCode))
+		}
+
+		amount := utils.RoundMoney(item.Total / float64(item.Quantity) * float64(line.Quantity))
+		taxAmount := utils.RoundMoney(item.TaxAmount / float64(item.Quantity) * float64(line.Quantity))
+		saleVoid.Items = append(saleVoid.Items, domain.SaleVoidItem{
+			SaleItemID:    item.ID,
+			ProductID:     item.ProductID,
+			Quantity:      line.Quantity,
+			UnitFactor:    item.UnitFactor,
+			Amount:        amount,
+			TaxRateID:     item.TaxRateID,
+			TaxableAmount: utils.RoundMoney(amount - taxAmount),
+			TaxAmount:     taxAmount,
+		})
+		saleVoid.Amount += amount
+	}
+
+	saleVoid.Amount = utils.RoundMoney(saleVoid.Amount)
+
+	// The sale is voided once nothing is left open on any line
+	voidSale := true
+	for _, item := range sale.Items {
+		if item.Quantity-item.ReturnedQuantity-item.VoidedQuantity > requested[item.ID] {
+			voidSale = false
+			break
+		}
+	}
+
+	if err := u.saleVoidRepo.Create(saleVoid, voidSale); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) || appErr.Is(err, appErr.ErrShiftNotOpen) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrSaleVoidCreate, err)
+	}
+
+	return saleVoid, nil
+}
+
+// approve checks the supervisor credentials sent with the void, either the
+// password or the PIN, and that the supervisor holds the void permission.
+// Nobody approves their own void.
+func (u *saleVoidUsecase) approve(req *domain.VoidSaleRequest) (*domain.User, error) {
+	approver, err := u.userRepo.FindByEmailOrUsername(req.SupervisorUsername)
+	if err != nil || approver == nil {
+		return nil, appErr.ErrInvalidCredentials
+	}
+
+	switch {
+	case req.SupervisorPin != "":
+		if approver.Pin == "" || !utils.CheckPasswordHash(req.SupervisorPin, approver.Pin) {
+			return nil, appErr.ErrInvalidCredentials
+		}
+	case req.SupervisorPassword != "":
+		if !utils.CheckPasswordHash(req.SupervisorPassword, approver.Password) {
+			return nil, appErr.ErrInvalidCredentials
+		}
+	default:
+		return nil, appErr.ErrInvalidCredentials
+	}
+
+	if approver.ID == req.RequestedBy {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("a void must be approved by someone other than %s", approver.Username))
+	}
+
+	allowed, err := u.authorizeRepo.CheckPermission(utils.UintToStr(approver.ID), domain.VoidPermissionObject, domain.VoidPermissionAction)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrAccessDenied, err)
+	}
+	if !allowed {
+		return nil, appErr.ErrAccessDenied
+	}
+
+	return approver, nil
+}
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"fmt"
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+	"gopos/pkg/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShiftUsecase interface {
+	FindPaginated(c *gin.Context) ([]domain.Shift, int64, error)
+	FindByID(id uint64) (*domain.Shift, error)
+	Current(userID uint) (*domain.Shift, error)
+	Open(req *domain.OpenShiftRequest) (*domain.Shift, error)
+	Close(req *domain.CloseShiftRequest) (*domain.ShiftSummary, error)
+	AddCashMovement(req *domain.CashMovementRequest) (*domain.CashMovement, error)
+	Summary(id uint64) (*domain.ShiftSummary, error)
+}
+
+type shiftUsecase struct {
+	shiftRepo    repository.ShiftRepository
+	saleRepo     repository.SaleRepository
+	paymentRepo  repository.PaymentRepository
+	terminalRepo repository.TerminalRepository
+	outletRepo   repository.OutletRepository
+}
+
+func NewShiftUsecase(shiftRepo repository.ShiftRepository, saleRepo repository.SaleRepository, paymentRepo repository.PaymentRepository, terminalRepo repository.TerminalRepository, outletRepo repository.OutletRepository) ShiftUsecase {
+	return &shiftUsecase{
+		shiftRepo:    shiftRepo,
+		saleRepo:     saleRepo,
+		paymentRepo:  paymentRepo,
+		terminalRepo: terminalRepo,
+		outletRepo:   outletRepo,
+	}
+}
+
+func (u *shiftUsecase) FindPaginated(c *gin.Context) ([]domain.Shift, int64, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	filters := map[string]interface{}{}
+
+	// Query params: ?user_id=1&outlet_id=1&status=closed&start_date=2025-01-01&end_date=2025-01-31
+	dateRangeFilters(c, filters)
+
+	if userID := c.Query("user_id"); userID != "" {
+		if id, err := strconv.Atoi(userID); err == nil {
+			filters["user_id"] = id
+		}
+	}
+
+	if outletID := c.Query("outlet_id"); outletID != "" {
+		if id, err := strconv.Atoi(outletID); err == nil {
+			filters["outlet_id"] = id
+		}
+	}
+
+	if status := c.Query("status"); status != "" {
+		filters["status"] = status
+	}
+
+	shifts, total, err := u.shiftRepo.FindPaginatedWithFilter(page, limit, filters)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrShiftList, err)
+	}
+
+	return shifts, total, nil
+}
+
+func (u *shiftUsecase) FindByID(id uint64) (*domain.Shift, error) {
+	shift, err := u.shiftRepo.FindByID(id)
+	if err != nil || shift == nil {
+		return nil, appErr.Get(appErr.ErrShiftShow, err)
+	}
+	return shift, nil
+}
+
+func (u *shiftUsecase) Current(userID uint) (*domain.Shift, error) {
+	shift, err := u.shiftRepo.FindOpenByUser(userID)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrShiftShow, err)
+	}
+	if shift == nil {
+		return nil, appErr.Get(appErr.ErrShiftNotOpen, nil)
+	}
+	return shift, nil
+}
+
+func (u *shiftUsecase) Open(req *domain.OpenShiftRequest) (*domain.Shift, error) {
+	var outletID *uint64
+	if req.TerminalID != nil {
+		terminal, err := u.terminalRepo.FindByID(*req.TerminalID)
+		if err != nil || terminal == nil {
+			return nil, appErr.Get(appErr.ErrTerminalShow, err)
+		}
+		if !terminal.IsActive {
+			return nil, appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("terminal %s is not active", terminal.Code))
+		}
+		outletID = &terminal.OutletID
+	}
+
+	outlet, err := resolveOutlet(u.outletRepo, outletID)
+	if err != nil {
+		return nil, err
+	}
+
+	shift := &domain.Shift{
+		UserID:       req.UserID,
+		TerminalID:   req.TerminalID,
+		OutletID:     outlet.ID,
+		Status:       domain.ShiftStatusOpen,
+		OpeningFloat: utils.RoundMoney(req.OpeningFloat),
+		Note:         req.Note,
+		OpenedAt:     time.Now(),
+	}
+
+	if err := u.shiftRepo.Open(shift); err != nil {
+		if appErr.Is(err, appErr.ErrShiftAlreadyOpen) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrShiftOpen, err)
+	}
+
+	return shift, nil
+}
+
+func (u *shiftUsecase) Close(req *domain.CloseShiftRequest) (*domain.ShiftSummary, error) {
+	shift, err := u.Current(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The totals are summed while the repository holds the shift, so a
+	// checkout racing the close cannot slip past them
+	var summary *domain.ShiftSummary
+	settle := func() error {
+		var err error
+		if summary, err = u.summarize(shift); err != nil {
+			return err
+		}
+
+		closedAt := time.Now()
+		shift.Status = domain.ShiftStatusClosed
+		shift.ExpectedCash = summary.ExpectedCash
+		shift.CountedCash = utils.RoundMoney(*req.CountedCash)
+		shift.Variance = utils.RoundMoney(shift.CountedCash - shift.ExpectedCash)
+		shift.ClosedAt = &closedAt
+		if req.Note != "" {
+			shift.Note = req.Note
+		}
+		return nil
+	}
+
+	if err := u.shiftRepo.Close(shift, settle); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) || appErr.Is(err, appErr.ErrShiftSummary) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrShiftClose, err)
+	}
+
+	summary.Shift = *shift
+	return summary, nil
+}
+
+func (u *shiftUsecase) AddCashMovement(req *domain.CashMovementRequest) (*domain.CashMovement, error) {
+	shift, err := u.Current(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	movement := &domain.CashMovement{
+		ShiftID: shift.ID,
+		UserID:  req.UserID,
+		Type:    req.Type,
+		Amount:  utils.RoundMoney(req.Amount),
+		Reason:  req.Reason,
+	}
+
+	if err := u.shiftRepo.CreateCashMovement(movement); err != nil {
+		return nil, appErr.Get(appErr.ErrCashMovementCreate, err)
+	}
+
+	return movement, nil
+}
+
+func (u *shiftUsecase) Summary(id uint64) (*domain.ShiftSummary, error) {
+	shift, err := u.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.summarize(shift)
+}
+
+// summarize computes the cash the drawer should hold:
+// opening float + cash sales - cash refunds - cash voids + paid in - paid out.
+func (u *shiftUsecase) summarize(shift *domain.Shift) (*domain.ShiftSummary, error) {
+	filters := map[string]interface{}{"shift_id": shift.ID}
+
+	salesCount, salesTotal, err := u.saleRepo.Totals(filters)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrShiftSummary, err)
+	}
+
+	paymentTotals, err := u.paymentRepo.TotalsByMethod(filters)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrShiftSummary, err)
+	}
+
+	refundTotals, err := u.paymentRepo.RefundTotalsByMethod(filters)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrShiftSummary, err)
+	}
+
+	voidTotals, err := u.paymentRepo.VoidTotalsByMethod(filters)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrShiftSummary, err)
+	}
+
+	paidIn, paidOut, err := u.shiftRepo.CashMovementTotals(shift.ID)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrShiftSummary, err)
+	}
+
+	summary := &domain.ShiftSummary{
+		Shift:         *shift,
+		SalesCount:    salesCount,
+		SalesTotal:    utils.RoundMoney(salesTotal),
+		PaidIn:        utils.RoundMoney(paidIn),
+		PaidOut:       utils.RoundMoney(paidOut),
+		PaymentTotals: paymentTotals,
+		RefundTotals:  refundTotals,
+		VoidTotals:    voidTotals,
+	}
+
+	for _, total := range paymentTotals {
+		if total.Type == domain.PaymentTypeCash {
+			summary.CashSales += total.Total
+		}
+	}
+
+	for _, total := range refundTotals {
+		summary.RefundsCount += total.Count
+		summary.RefundsTotal += total.Total
+		if total.Type == domain.PaymentTypeCash {
+			summary.CashRefunds += total.Total
+		}
+	}
+
+	for _, total := range voidTotals {
+		summary.VoidsCount += total.Count
+		summary.VoidsTotal += total.Total
+		if total.Type == domain.PaymentTypeCash {
+			summary.CashVoids += total.Total
+		}
+	}
+
+	summary.CashSales = utils.RoundMoney(summary.CashSales)
+	summary.CashRefunds = utils.RoundMoney(summary.CashRefunds)
+	summary.RefundsTotal = utils.RoundMoney(summary.RefundsTotal)
+	summary.CashVoids = utils.RoundMoney(summary.CashVoids)
+	summary.VoidsTotal = utils.RoundMoney(summary.VoidsTotal)
+	summary.ExpectedCash = utils.RoundMoney(shift.OpeningFloat + summary.CashSales - summary.CashRefunds - summary.CashVoids + summary.PaidIn - summary.PaidOut)
+
+	return summary, nil
+}
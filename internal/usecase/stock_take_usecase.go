@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"fmt"
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	"gopos/pkg/barcode"
+	appErr "gopos/pkg/errors"
+	"gopos/pkg/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StockTakeUsecase interface {
+	FindPaginated(c *gin.Context) ([]domain.StockTake, int64, error)
+	FindByID(id uint64) (*domain.StockTake, error)
+	Create(req *domain.CreateStockTakeRequest) (*domain.StockTake, error)
+	Count(id uint64, req *domain.StockTakeCountRequest) (*domain.StockTakeItem, error)
+	Variances(id uint64) (*domain.StockTakeVarianceReport, error)
+	Approve(id uint64, userID uint) (*domain.StockTakeVarianceReport, error)
+	Cancel(id uint64) (*domain.StockTake, error)
+}
+
+type stockTakeUsecase struct {
+	stockTakeRepo repository.StockTakeRepository
+	productRepo   repository.ProductRepository
+	outletRepo    repository.OutletRepository
+}
+
+func NewStockTakeUsecase(stockTakeRepo repository.StockTakeRepository, productRepo repository.ProductRepository, outletRepo repository.OutletRepository) StockTakeUsecase {
+	return &stockTakeUsecase{
+		stockTakeRepo: stockTakeRepo,
+		productRepo:   productRepo,
+		outletRepo:    outletRepo,
+	}
+}
+
+func (u *stockTakeUsecase) FindPaginated(c *gin.Context) ([]domain.StockTake, int64, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	filters := map[string]interface{}{}
+
+	// Query params: ?status=open&outlet_id=1&start_date=2025-01-01&end_date=2025-01-31
+	dateRangeFilters(c, filters)
+
+	if status := c.Query("status"); status != "" {
+		filters["status"] = status
+	}
+
+	if outletID := c.Query("outlet_id"); outletID != "" {
+		if id, err := strconv.Atoi(outletID); err == nil {
+			filters["outlet_id"] = id
+		}
+	}
+
+	takes, total, err := u.stockTakeRepo.FindPaginatedWithFilter(page, limit, filters)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrStockTakeList, err)
+	}
+
+	return takes, total, nil
+}
+
+func (u *stockTakeUsecase) FindByID(id uint64) (*domain.StockTake, error) {
+	take, err := u.stockTakeRepo.FindByID(id)
+	if err != nil || take == nil {
+		return nil, appErr.Get(appErr.ErrStockTakeShow, err)
+	}
+	return take, nil
+}
+
+func (u *stockTakeUsecase) Create(req *domain.CreateStockTakeRequest) (*domain.StockTake, error) {
+	outlet, err := resolveOutlet(u.outletRepo, req.OutletID)
+	if err != nil {
+		return nil, err
+	}
+
+	take := &domain.StockTake{
+		OutletID:   outlet.ID,
+		Status:     domain.StockTakeStatusOpen,
+		CategoryID: req.CategoryID,
+		Note:       req.Note,
+		CreatedBy:  req.CreatedBy,
+	}
+
+	if err := u.stockTakeRepo.Create(take); err != nil {
+		return nil, appErr.Get(appErr.ErrStockTakeCreate, err)
+	}
+
+	if len(take.Items) == 0 {
+		return take, nil
+	}
+	return u.FindByID(take.ID)
+}
+
+func (u *stockTakeUsecase) Count(id uint64, req *domain.StockTakeCountRequest) (*domain.StockTakeItem, error) {
+	take, err := u.findOpen(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var product *domain.Product
+	quantity := *req.Quantity
+	if req.ProductID != nil {
+		product, err = u.productRepo.FindByID(*req.ProductID)
+	} else {
+		product, quantity, err = u.scan(req.Barcode, quantity)
+	}
+	if err != nil || product == nil {
+		return nil, appErr.Get(appErr.ErrProductShow, err)
+	}
+
+	var item *domain.StockTakeItem
+	for i := range take.Items {
+		if take.Items[i].ProductID == product.ID {
+			item = &take.Items[i]
+			break
+		}
+	}
+	if item == nil {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s is not part of stock take %d", product.Code, take.ID))
+	}
+
+	count := &domain.StockTakeCount{
+		CounterID: req.CounterID,
+		Quantity:  quantity,
+	}
+
+	if err := u.stockTakeRepo.SaveCount(take, item, count); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrStockTakeCount, err)
+	}
+
+	item.Counts = nil
+	return item, nil
+}
+
+func (u *stockTakeUsecase) Variances(id uint64) (*domain.StockTakeVarianceReport, error) {
+	take, err := u.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return varianceReport(take), nil
+}
+
+func (u *stockTakeUsecase) Approve(id uint64, userID uint) (*domain.StockTakeVarianceReport, error) {
+	take, err := u.findOpen(id)
+	if err != nil {
+		return nil, err
+	}
+
+	take.ApprovedBy = &userID
+	if err := u.stockTakeRepo.Approve(take); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) || appErr.Is(err, appErr.ErrInsufficientStock) || appErr.Is(err, appErr.ErrStockExpired) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrStockTakeApprove, err)
+	}
+
+	return u.Variances(take.ID)
+}
+
+func (u *stockTakeUsecase) Cancel(id uint64) (*domain.StockTake, error) {
+	take, err := u.findOpen(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.stockTakeRepo.Cancel(take); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrStockTakeApprove, err)
+	}
+
+	return take, nil
+}
+
+func (u *stockTakeUsecase) findOpen(id uint64) (*domain.StockTake, error) {
+	take, err := u.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if take.Status != domain.StockTakeStatusOpen {
+		return nil, appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("stock take %d is %s", take.ID, take.Status))
+	}
+	return take, nil
+}
+
+// scan finds the product a scanned barcode stands for, falling back to the
+// product code for labels printed before barcodes were kept. A barcode of
+// one of the product's other units turns quantity into base units.
+func (u *stockTakeUsecase) scan(value string, quantity int) (*domain.Product, int, error) {
+	productBarcode, err := u.productRepo.FindByBarcode(barcode.Candidates(value))
+	if err != nil {
+		return nil, 0, err
+	}
+	if productBarcode == nil {
+		product, err := u.productRepo.FindByCode(value)
+		return product, quantity, err
+	}
+
+	unit, err := resolveUnit(productBarcode.Product, productBarcode.UnitID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return productBarcode.Product, quantity * unit.Factor, nil
+}
+
+func varianceReport(take *domain.StockTake) *domain.StockTakeVarianceReport {
+	report := &domain.StockTakeVarianceReport{
+		StockTakeID: take.ID,
+		Status:      take.Status,
+		Items:       make([]domain.StockTakeVariance, 0, len(take.Items)),
+	}
+
+	for _, item := range take.Items {
+		variance := domain.StockTakeVariance{
+			ProductID:       item.ProductID,
+			SystemQuantity:  item.SystemQuantity,
+			CountedQuantity: item.CountedQuantity,
+			CostPrice:       item.CostPrice,
+		}
+		if item.Product != nil {
+			variance.ProductCode = item.Product.Code
+			variance.ProductName = item.Product.Name
+		}
+
+		if item.CountedQuantity == nil {
+			report.UncountedItems++
+		} else {
+			report.CountedItems++
+			variance.Variance = *item.CountedQuantity - item.SystemQuantity
+			if item.AdjustedQuantity != nil {
+				variance.Variance = *item.AdjustedQuantity
+				variance.SystemQuantity = *item.CountedQuantity - *item.AdjustedQuantity
+			}
+			variance.VarianceValue = utils.RoundMoney(float64(variance.Variance) * item.CostPrice)
+			if variance.VarianceValue > 0 {
+				report.GainValue += variance.VarianceValue
+			} else {
+				report.LossValue -= variance.VarianceValue
+			}
+		}
+
+		report.Items = append(report.Items, variance)
+	}
+
+	report.GainValue = utils.RoundMoney(report.GainValue)
+	report.LossValue = utils.RoundMoney(report.LossValue)
+	report.NetValue = utils.RoundMoney(report.GainValue - report.LossValue)
+	return report
+}
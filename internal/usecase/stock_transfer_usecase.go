@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"fmt"
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StockTransferUsecase interface {
+	FindPaginated(c *gin.Context) ([]domain.StockTransfer, int64, error)
+	FindByID(id uint64) (*domain.StockTransfer, error)
+	InTransit(c *gin.Context) ([]domain.InTransitQuantity, error)
+	Create(req *domain.CreateStockTransferRequest) (*domain.StockTransfer, error)
+	Ship(id uint64, userID uint) (*domain.StockTransfer, error)
+	Receive(id uint64, req *domain.ReceiveStockTransferRequest) (*domain.StockTransfer, error)
+	Cancel(id uint64) (*domain.StockTransfer, error)
+}
+
+type stockTransferUsecase struct {
+	stockTransferRepo repository.StockTransferRepository
+	productRepo       repository.ProductRepository
+	outletRepo        repository.OutletRepository
+}
+
+func NewStockTransferUsecase(stockTransferRepo repository.StockTransferRepository, productRepo repository.ProductRepository, outletRepo repository.OutletRepository) StockTransferUsecase {
+	return &stockTransferUsecase{
+		stockTransferRepo: stockTransferRepo,
+		productRepo:       productRepo,
+		outletRepo:        outletRepo,
+	}
+}
+
+func (u *stockTransferUsecase) FindPaginated(c *gin.Context) ([]domain.StockTransfer, int64, error) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	filters := map[string]interface{}{}
+
+	// Query params: ?status=in_transit&from_outlet_id=1&to_outlet_id=2&outlet_id=1&start_date=2025-01-01&end_date=2025-01-31
+	dateRangeFilters(c, filters)
+
+	if status := c.Query("status"); status != "" {
+		filters["status"] = status
+	}
+
+	for _, key := range []string{"from_outlet_id", "to_outlet_id", "outlet_id"} {
+		if value := c.Query(key); value != "" {
+			if id, err := strconv.ParseUint(value, 10, 64); err == nil {
+				filters[key] = id
+			}
+		}
+	}
+
+	transfers, total, err := u.stockTransferRepo.FindPaginatedWithFilter(page, limit, filters)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrStockTransferList, err)
+	}
+
+	return transfers, total, nil
+}
+
+func (u *stockTransferUsecase) FindByID(id uint64) (*domain.StockTransfer, error) {
+	transfer, err := u.stockTransferRepo.FindByID(id)
+	if err != nil || transfer == nil {
+		return nil, appErr.Get(appErr.ErrStockTransferShow, err)
+	}
+	return transfer, nil
+}
+
+func (u *stockTransferUsecase) InTransit(c *gin.Context) ([]domain.InTransitQuantity, error) {
+	filters := map[string]interface{}{}
+
+	// Query params: ?outlet_id=1&product_id=1
+	for _, key := range []string{"outlet_id", "product_id"} {
+		if value := c.Query(key); value != "" {
+			if id, err := strconv.ParseUint(value, 10, 64); err == nil {
+				filters[key] = id
+			}
+		}
+	}
+
+	quantities, err := u.stockTransferRepo.InTransit(filters)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrStockTransferList, err)
+	}
+	return quantities, nil
+}
+
+func (u *stockTransferUsecase) Create(req *domain.CreateStockTransferRequest) (*domain.StockTransfer, error) {
+	if req.FromOutletID == req.ToOutletID {
+		return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("source and destination outlet must differ"))
+	}
+
+	from, err := resolveOutlet(u.outletRepo, &req.FromOutletID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolveOutlet(u.outletRepo, &req.ToOutletID)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := &domain.StockTransfer{
+		FromOutletID: from.ID,
+		ToOutletID:   to.ID,
+		Status:       domain.StockTransferStatusDraft,
+		Note:         req.Note,
+		CreatedBy:    req.CreatedBy,
+	}
+
+	seen := make(map[uint64]bool, len(req.Items))
+	for _, line := range req.Items {
+		product, err := u.productRepo.FindByID(line.ProductID)
+		if err != nil || product == nil {
+			return nil, appErr.Get(appErr.ErrProductShow, err)
+		}
+		if err := requireStockItem(product); err != nil {
+			return nil, err
+		}
+		if seen[product.ID] {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("product %s is listed more than once", product.Code))
+		}
+		seen[product.ID] = true
+
+		transfer.Items = append(transfer.Items, domain.StockTransferItem{
+			ProductID: product.ID,
+			Quantity:  line.Quantity,
+		})
+	}
+
+	if err := u.stockTransferRepo.Create(transfer); err != nil {
+		return nil, appErr.Get(appErr.ErrStockTransferCreate, err)
+	}
+
+	return u.FindByID(transfer.ID)
+}
+
+func (u *stockTransferUsecase) Ship(id uint64, userID uint) (*domain.StockTransfer, error) {
+	transfer, err := u.findInStatus(id, domain.StockTransferStatusDraft)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer.ShippedBy = &userID
+	if err := u.stockTransferRepo.Ship(transfer); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) || appErr.Is(err, appErr.ErrInsufficientStock) || appErr.Is(err, appErr.ErrStockExpired) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrStockTransferUpdate, err)
+	}
+
+	return transfer, nil
+}
+
+func (u *stockTransferUsecase) Receive(id uint64, req *domain.ReceiveStockTransferRequest) (*domain.StockTransfer, error) {
+	transfer, err := u.findInStatus(id, domain.StockTransferStatusInTransit)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make(map[uint64]bool, len(transfer.Items))
+	for _, item := range transfer.Items {
+		lines[item.ID] = true
+	}
+
+	received := make(map[uint64]domain.ReceiveStockTransferItemRequest, len(req.Items))
+	for _, line := range req.Items {
+		if !lines[line.StockTransferItemID] {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("line %d is not part of stock transfer %s", line.StockTransferItemID, transfer.TransferNo))
+		}
+		received[line.StockTransferItemID] = line
+	}
+
+	for i := range transfer.Items {
+		item := &transfer.Items[i]
+
+		quantity := item.Quantity
+		if line, ok := received[item.ID]; ok {
+			quantity = *line.ReceivedQuantity
+			item.DiscrepancyReason = line.Reason
+		}
+
+		item.ReceivedQuantity = &quantity
+		item.Discrepancy = quantity - item.Quantity
+		if item.Discrepancy != 0 && item.DiscrepancyReason == "" {
+			return nil, appErr.Get(appErr.ErrValidation, fmt.Errorf("line %d received %d of %d, a reason is required", item.ID, quantity, item.Quantity))
+		}
+		if item.Discrepancy == 0 {
+			item.DiscrepancyReason = ""
+		}
+	}
+
+	transfer.ReceivedBy = &req.ReceivedBy
+	if err := u.stockTransferRepo.Receive(transfer); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrStockTransferReceive, err)
+	}
+
+	return transfer, nil
+}
+
+func (u *stockTransferUsecase) Cancel(id uint64) (*domain.StockTransfer, error) {
+	transfer, err := u.findInStatus(id, domain.StockTransferStatusDraft)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.stockTransferRepo.Cancel(transfer); err != nil {
+		if appErr.Is(err, appErr.ErrAlreadyProcessed) {
+			return nil, err
+		}
+		return nil, appErr.Get(appErr.ErrStockTransferUpdate, err)
+	}
+
+	return transfer, nil
+}
+
+func (u *stockTransferUsecase) findInStatus(id uint64, status string) (*domain.StockTransfer, error) {
+	transfer, err := u.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.Status != status {
+		return nil, appErr.Get(appErr.ErrInvalidStatus, fmt.Errorf("stock transfer %s is %s", transfer.TransferNo, transfer.Status))
+	}
+	return transfer, nil
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+)
+
+type StoreSettingUsecase interface {
+	Get() (*domain.StoreSetting, error)
+	Update(setting *domain.StoreSetting) (*domain.StoreSetting, error)
+}
+
+type storeSettingUsecase struct {
+	storeSettingRepo repository.StoreSettingRepository
+}
+
+func NewStoreSettingUsecase(storeSettingRepo repository.StoreSettingRepository) StoreSettingUsecase {
+	return &storeSettingUsecase{
+		storeSettingRepo: storeSettingRepo,
+	}
+}
+
+func (u *storeSettingUsecase) Get() (*domain.StoreSetting, error) {
+	setting, err := u.storeSettingRepo.Get()
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrStoreSettingShow, err)
+	}
+	if setting == nil {
+		return defaultStoreSetting(), nil
+	}
+	return setting, nil
+}
+
+func (u *storeSettingUsecase) Update(req *domain.StoreSetting) (*domain.StoreSetting, error) {
+	setting, err := u.storeSettingRepo.Get()
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrStoreSettingShow, err)
+	}
+	if setting != nil {
+		req.ID = setting.ID
+		req.CreatedAt = setting.CreatedAt
+	}
+	if req.PaperWidth == 0 {
+		req.PaperWidth = 58
+	}
+
+	if err := u.storeSettingRepo.Save(req); err != nil {
+		return nil, appErr.Get(appErr.ErrStoreSettingUpdate, err)
+	}
+	return req, nil
+}
+
+func defaultStoreSetting() *domain.StoreSetting {
+	return &domain.StoreSetting{
+		StoreName:     "GoPOS",
+		ReceiptFooter: "Terima kasih",
+		PaperWidth:    58,
+	}
+}
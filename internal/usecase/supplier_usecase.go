@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+)
+
+type SupplierUsecase interface {
+	FindPaginated(page, limit int) ([]domain.Supplier, int64, error)
+	FindByID(id uint64) (*domain.Supplier, error)
+	Create(supplier *domain.Supplier) error
+	Update(supplier *domain.Supplier) error
+	Delete(id uint64) error
+}
+
+type supplierUsecase struct {
+	supplierRepo repository.SupplierRepository
+}
+
+func NewSupplierUsecase(supplierRepo repository.SupplierRepository) SupplierUsecase {
+	return &supplierUsecase{
+		supplierRepo: supplierRepo,
+	}
+}
+
+func (u *supplierUsecase) FindPaginated(page, limit int) ([]domain.Supplier, int64, error) {
+	suppliers, total, err := u.supplierRepo.FindPaginated(page, limit)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrSupplierList, err)
+	}
+	return suppliers, total, nil
+}
+
+func (u *supplierUsecase) FindByID(id uint64) (*domain.Supplier, error) {
+	supplier, err := u.supplierRepo.FindByID(id)
+	if err != nil || supplier == nil {
+		return nil, appErr.Get(appErr.ErrSupplierShow, err)
+	}
+	return supplier, nil
+}
+
+func (u *supplierUsecase) Create(supplier *domain.Supplier) error {
+	if err := u.supplierRepo.Create(supplier); err != nil {
+		return appErr.Get(appErr.ErrSupplierCreate, err)
+	}
+	return nil
+}
+
+func (u *supplierUsecase) Update(supplier *domain.Supplier) error {
+	existing, err := u.supplierRepo.FindByID(supplier.ID)
+	if err != nil || existing == nil {
+		return appErr.Get(appErr.ErrSupplierShow, err)
+	}
+
+	if err := u.supplierRepo.Update(supplier); err != nil {
+		return appErr.Get(appErr.ErrSupplierUpdate, err)
+	}
+	return nil
+}
+
+func (u *supplierUsecase) Delete(id uint64) error {
+	supplier, err := u.supplierRepo.FindByID(id)
+	if err != nil || supplier == nil {
+		return appErr.Get(appErr.ErrSupplierShow, err)
+	}
+
+	if err := u.supplierRepo.Delete(supplier); err != nil {
+		return appErr.Get(appErr.ErrSupplierDelete, err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaxRateUsecase interface {
+	FindPaginated(page, limit int) ([]domain.TaxRate, int64, error)
+	FindByID(id uint64) (*domain.TaxRate, error)
+	Create(rate *domain.TaxRate) error
+	Update(rate *domain.TaxRate) error
+	Delete(id uint64) error
+	Summary(c *gin.Context) ([]domain.TaxTotal, error)
+}
+
+type taxRateUsecase struct {
+	taxRateRepo repository.TaxRateRepository
+}
+
+func NewTaxRateUsecase(taxRateRepo repository.TaxRateRepository) TaxRateUsecase {
+	return &taxRateUsecase{
+		taxRateRepo: taxRateRepo,
+	}
+}
+
+func (u *taxRateUsecase) FindPaginated(page, limit int) ([]domain.TaxRate, int64, error) {
+	rates, total, err := u.taxRateRepo.FindPaginated(page, limit)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrTaxRateList, err)
+	}
+	return rates, total, nil
+}
+
+func (u *taxRateUsecase) FindByID(id uint64) (*domain.TaxRate, error) {
+	rate, err := u.taxRateRepo.FindByID(id)
+	if err != nil || rate == nil {
+		return nil, appErr.Get(appErr.ErrTaxRateShow, err)
+	}
+	return rate, nil
+}
+
+func (u *taxRateUsecase) Create(rate *domain.TaxRate) error {
+	if err := u.taxRateRepo.Create(rate); err != nil {
+		return appErr.Get(appErr.ErrTaxRateCreate, err)
+	}
+	return nil
+}
+
+func (u *taxRateUsecase) Update(rate *domain.TaxRate) error {
+	existing, err := u.taxRateRepo.FindByID(rate.ID)
+	if err != nil || existing == nil {
+		return appErr.Get(appErr.ErrTaxRateShow, err)
+	}
+
+	if err := u.taxRateRepo.Update(rate); err != nil {
+		return appErr.Get(appErr.ErrTaxRateUpdate, err)
+	}
+	return nil
+}
+
+func (u *taxRateUsecase) Delete(id uint64) error {
+	rate, err := u.taxRateRepo.FindByID(id)
+	if err != nil || rate == nil {
+		return appErr.Get(appErr.ErrTaxRateShow, err)
+	}
+
+	if err := u.taxRateRepo.Delete(rate); err != nil {
+		return appErr.Get(appErr.ErrTaxRateDelete, err)
+	}
+	return nil
+}
+
+func (u *taxRateUsecase) Summary(c *gin.Context) ([]domain.TaxTotal, error) {
+	filters := map[string]interface{}{}
+
+	// Query params: ?start_date=2025-01-01&end_date=2025-01-31&cashier_id=1&shift_id=1
+	dateRangeFilters(c, filters)
+
+	if cashierID := c.Query("cashier_id"); cashierID != "" {
+		if id, err := strconv.Atoi(cashierID); err == nil {
+			filters["cashier_id"] = id
+		}
+	}
+
+	if shiftID := c.Query("shift_id"); shiftID != "" {
+		if id, err := strconv.Atoi(shiftID); err == nil {
+			filters["shift_id"] = id
+		}
+	}
+
+	totals, err := u.taxRateRepo.Totals(filters)
+	if err != nil {
+		return nil, appErr.Get(appErr.ErrTaxSummary, err)
+	}
+
+	return totals, nil
+}
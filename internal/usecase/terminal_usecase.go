@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	appErr "gopos/pkg/errors"
+)
+
+type TerminalUsecase interface {
+	FindPaginated(page, limit int) ([]domain.Terminal, int64, error)
+	FindByID(id uint64) (*domain.Terminal, error)
+	Create(terminal *domain.Terminal) error
+	Update(terminal *domain.Terminal) error
+	Delete(id uint64) error
+}
+
+type terminalUsecase struct {
+	terminalRepo repository.TerminalRepository
+	outletRepo   repository.OutletRepository
+}
+
+func NewTerminalUsecase(terminalRepo repository.TerminalRepository, outletRepo repository.OutletRepository) TerminalUsecase {
+	return &terminalUsecase{
+		terminalRepo: terminalRepo,
+		outletRepo:   outletRepo,
+	}
+}
+
+func (u *terminalUsecase) FindPaginated(page, limit int) ([]domain.Terminal, int64, error) {
+	terminals, total, err := u.terminalRepo.FindPaginated(page, limit)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrTerminalList, err)
+	}
+	return terminals, total, nil
+}
+
+func (u *terminalUsecase) FindByID(id uint64) (*domain.Terminal, error) {
+	terminal, err := u.terminalRepo.FindByID(id)
+	if err != nil || terminal == nil {
+		return nil, appErr.Get(appErr.ErrTerminalShow, err)
+	}
+	return terminal, nil
+}
+
+func (u *terminalUsecase) Create(terminal *domain.Terminal) error {
+	if err := u.checkOutlet(terminal.OutletID); err != nil {
+		return err
+	}
+
+	if err := u.terminalRepo.Create(terminal); err != nil {
+		return appErr.Get(appErr.ErrTerminalCreate, err)
+	}
+	return nil
+}
+
+func (u *terminalUsecase) Update(terminal *domain.Terminal) error {
+	existing, err := u.terminalRepo.FindByID(terminal.ID)
+	if err != nil || existing == nil {
+		return appErr.Get(appErr.ErrTerminalShow, err)
+	}
+
+	if err := u.checkOutlet(terminal.OutletID); err != nil {
+		return err
+	}
+
+	if err := u.terminalRepo.Update(terminal); err != nil {
+		return appErr.Get(appErr.ErrTerminalUpdate, err)
+	}
+	return nil
+}
+
+func (u *terminalUsecase) Delete(id uint64) error {
+	terminal, err := u.terminalRepo.FindByID(id)
+	if err != nil || terminal == nil {
+		return appErr.Get(appErr.ErrTerminalShow, err)
+	}
+
+	if err := u.terminalRepo.Delete(terminal); err != nil {
+		return appErr.Get(appErr.ErrTerminalDelete, err)
+	}
+	return nil
+}
+
+func (u *terminalUsecase) checkOutlet(outletID uint64) error {
+	outlet, err := u.outletRepo.FindByID(outletID)
+	if err != nil || outlet == nil {
+		return appErr.Get(appErr.ErrOutletShow, err)
+	}
+	return nil
+}
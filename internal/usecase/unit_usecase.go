@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"gopos/internal/domain"
+	"gopos/internal/repository"
+	"gopos/pkg/cache"
+	appErr "gopos/pkg/errors"
+)
+
+type UnitUsecase interface {
+	FindPaginated(page, limit int) ([]domain.Unit, int64, error)
+	FindByID(id uint64) (*domain.Unit, error)
+	Create(unit *domain.Unit) error
+	Update(unit *domain.Unit) error
+	Delete(id uint64) error
+}
+
+type unitUsecase struct {
+	unitRepo repository.UnitRepository
+	lookups  *cache.Cache[string, *domain.BarcodeLookup]
+}
+
+// NewUnitUsecase takes the barcode lookup cache because scans answer with
+// the unit's code; renaming or deleting a unit clears it.
+func NewUnitUsecase(unitRepo repository.UnitRepository, lookups *cache.Cache[string, *domain.BarcodeLookup]) UnitUsecase {
+	return &unitUsecase{
+		unitRepo: unitRepo,
+		lookups:  lookups,
+	}
+}
+
+func (u *unitUsecase) FindPaginated(page, limit int) ([]domain.Unit, int64, error) {
+	units, total, err := u.unitRepo.FindPaginated(page, limit)
+	if err != nil {
+		return nil, 0, appErr.Get(appErr.ErrUnitList, err)
+	}
+	return units, total, nil
+}
+
+func (u *unitUsecase) FindByID(id uint64) (*domain.Unit, error) {
+	unit, err := u.unitRepo.FindByID(id)
+	if err != nil || unit == nil {
+		return nil, appErr.Get(appErr.ErrUnitShow, err)
+	}
+	return unit, nil
+}
+
+func (u *unitUsecase) Create(unit *domain.Unit) error {
+	if err := u.unitRepo.Create(unit); err != nil {
+		return appErr.Get(appErr.ErrUnitCreate, err)
+	}
+	return nil
+}
+
+func (u *unitUsecase) Update(unit *domain.Unit) error {
+	existing, err := u.unitRepo.FindByID(unit.ID)
+	if err != nil || existing == nil {
+		return appErr.Get(appErr.ErrUnitShow, err)
+	}
+
+	if err := u.unitRepo.Update(unit); err != nil {
+		return appErr.Get(appErr.ErrUnitUpdate, err)
+	}
+	u.lookups.Clear()
+	return nil
+}
+
+func (u *unitUsecase) Delete(id uint64) error {
+	unit, err := u.unitRepo.FindByID(id)
+	if err != nil || unit == nil {
+		return appErr.Get(appErr.ErrUnitShow, err)
+	}
+
+	if err := u.unitRepo.Delete(unit); err != nil {
+		return appErr.Get(appErr.ErrUnitDelete, err)
+	}
+	u.lookups.Clear()
+	return nil
+}
@@ -11,6 +11,7 @@ type UserUsecase interface {
 	Detail(userId uint) (*domain.User, error)
 	Create(user *domain.User) (*domain.User, error)
 	Update(user *domain.User) (*domain.User, error)
+	UpdatePin(userId uint, pin string) error
 	Delete(userId uint) error
 }
 
@@ -120,6 +121,23 @@ func (u *userUsecase) Update(user *domain.User) (*domain.User, error) {
 	return updatedUser, nil
 }
 
+func (u *userUsecase) UpdatePin(id uint, pin string) error {
+	user, err := u.userRepo.FindByID(id)
+	if err != nil || user == nil {
+		return appErr.Get(appErr.ErrUserDetail, err)
+	}
+
+	hashedPin, err := utils.HashPassword(pin)
+	if err != nil {
+		return appErr.Get(appErr.ErrHashPassword, err)
+	}
+
+	if err := u.userRepo.UpdatePin(id, hashedPin); err != nil {
+		return appErr.Get(appErr.ErrUserUpdate, err)
+	}
+	return nil
+}
+
 func (u *userUsecase) Delete(id uint) error {
 	user, err := u.userRepo.FindByID(id)
 	if err != nil {
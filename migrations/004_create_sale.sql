@@ -0,0 +1,33 @@
+DROP TABLE IF EXISTS sale_items;
+DROP TABLE IF EXISTS sales;
+
+CREATE TABLE sales (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    cashier_id INT UNSIGNED NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'completed',
+    subtotal DOUBLE NOT NULL,
+    total DOUBLE NOT NULL,
+    note TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_sales_cashier_id (cashier_id),
+    INDEX idx_sales_created_at (created_at),
+    FOREIGN KEY (cashier_id) REFERENCES users(id)
+);
+
+CREATE TABLE sale_items (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    sale_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    product_code VARCHAR(50) NOT NULL,
+    product_name VARCHAR(100) NOT NULL,
+    quantity INT NOT NULL,
+    unit_price DOUBLE NOT NULL,
+    cost_price DOUBLE,
+    subtotal DOUBLE NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_sale_items_sale_id (sale_id),
+    INDEX idx_sale_items_product_id (product_id),
+    FOREIGN KEY (sale_id) REFERENCES sales(id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
@@ -0,0 +1,37 @@
+DROP TABLE IF EXISTS payments;
+DROP TABLE IF EXISTS payment_methods;
+
+CREATE TABLE payment_methods (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    code VARCHAR(30) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+
+INSERT INTO payment_methods (code, name, type) VALUES
+    ('CASH', 'Cash', 'cash'),
+    ('DEBIT', 'Debit Card', 'debit_card'),
+    ('EWALLET', 'E-Wallet', 'e_wallet'),
+    ('TRANSFER', 'Bank Transfer', 'bank_transfer');
+
+ALTER TABLE sales
+    ADD COLUMN paid_amount DOUBLE NOT NULL DEFAULT 0 AFTER total,
+    ADD COLUMN change_amount DOUBLE NOT NULL DEFAULT 0 AFTER paid_amount;
+
+CREATE TABLE payments (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    sale_id BIGINT NOT NULL,
+    payment_method_id BIGINT NOT NULL,
+    amount DOUBLE NOT NULL,
+    tendered DOUBLE NOT NULL,
+    reference VARCHAR(100),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_payments_sale_id (sale_id),
+    INDEX idx_payments_payment_method_id (payment_method_id),
+    FOREIGN KEY (sale_id) REFERENCES sales(id),
+    FOREIGN KEY (payment_method_id) REFERENCES payment_methods(id)
+);
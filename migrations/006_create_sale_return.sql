@@ -0,0 +1,35 @@
+DROP TABLE IF EXISTS sale_return_items;
+DROP TABLE IF EXISTS sale_returns;
+
+ALTER TABLE sale_items
+    ADD COLUMN returned_quantity INT NOT NULL DEFAULT 0 AFTER quantity;
+
+CREATE TABLE sale_returns (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    sale_id BIGINT NOT NULL,
+    cashier_id INT UNSIGNED NOT NULL,
+    payment_method_id BIGINT NOT NULL,
+    total_refund DOUBLE NOT NULL,
+    reason TEXT,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_sale_returns_sale_id (sale_id),
+    INDEX idx_sale_returns_cashier_id (cashier_id),
+    FOREIGN KEY (sale_id) REFERENCES sales(id),
+    FOREIGN KEY (cashier_id) REFERENCES users(id),
+    FOREIGN KEY (payment_method_id) REFERENCES payment_methods(id)
+);
+
+CREATE TABLE sale_return_items (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    sale_return_id BIGINT NOT NULL,
+    sale_item_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity INT NOT NULL,
+    unit_price DOUBLE NOT NULL,
+    subtotal DOUBLE NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_sale_return_items_sale_return_id (sale_return_id),
+    FOREIGN KEY (sale_return_id) REFERENCES sale_returns(id),
+    FOREIGN KEY (sale_item_id) REFERENCES sale_items(id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
@@ -0,0 +1,42 @@
+DROP TABLE IF EXISTS cash_movements;
+DROP TABLE IF EXISTS shifts;
+
+CREATE TABLE shifts (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id INT UNSIGNED NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    opening_float DOUBLE NOT NULL,
+    expected_cash DOUBLE,
+    counted_cash DOUBLE,
+    variance DOUBLE,
+    note TEXT,
+    opened_at DATETIME NOT NULL,
+    closed_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_shifts_user_status (user_id, status),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE cash_movements (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    shift_id BIGINT NOT NULL,
+    user_id INT UNSIGNED NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    amount DOUBLE NOT NULL,
+    reason VARCHAR(255),
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_cash_movements_shift_id (shift_id),
+    FOREIGN KEY (shift_id) REFERENCES shifts(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+ALTER TABLE sales
+    ADD COLUMN shift_id BIGINT AFTER cashier_id,
+    ADD INDEX idx_sales_shift_id (shift_id),
+    ADD FOREIGN KEY (shift_id) REFERENCES shifts(id);
+
+ALTER TABLE sale_returns
+    ADD COLUMN shift_id BIGINT AFTER cashier_id,
+    ADD INDEX idx_sale_returns_shift_id (shift_id),
+    ADD FOREIGN KEY (shift_id) REFERENCES shifts(id);
@@ -0,0 +1,33 @@
+DROP TABLE IF EXISTS draft_order_items;
+DROP TABLE IF EXISTS draft_orders;
+
+CREATE TABLE draft_orders (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    cashier_id INT UNSIGNED NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    label VARCHAR(100),
+    note TEXT,
+    sale_id BIGINT,
+    parked_at DATETIME,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_draft_orders_status (status),
+    INDEX idx_draft_orders_cashier_id (cashier_id),
+    FOREIGN KEY (cashier_id) REFERENCES users(id),
+    FOREIGN KEY (sale_id) REFERENCES sales(id)
+);
+
+CREATE TABLE draft_order_items (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    draft_order_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity INT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_draft_order_items_product (draft_order_id, product_id),
+    FOREIGN KEY (draft_order_id) REFERENCES draft_orders(id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
+
+ALTER TABLE sales
+    ADD COLUMN draft_order_id BIGINT AFTER shift_id;
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS store_settings;
+
+CREATE TABLE store_settings (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    store_name VARCHAR(100) NOT NULL,
+    address VARCHAR(255),
+    phone VARCHAR(30),
+    receipt_header TEXT,
+    receipt_footer TEXT,
+    paper_width INT NOT NULL DEFAULT 58,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
@@ -0,0 +1,30 @@
+DROP TABLE IF EXISTS number_sequences;
+
+CREATE TABLE number_sequences (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    doc_type VARCHAR(20) NOT NULL,
+    outlet_code VARCHAR(20) NOT NULL,
+    period VARCHAR(10) NOT NULL,
+    last_number BIGINT NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_number_sequence (doc_type, outlet_code, period)
+);
+
+ALTER TABLE sales
+    ADD COLUMN invoice_no VARCHAR(50) AFTER id;
+
+UPDATE sales SET invoice_no = CONCAT('INV/LEGACY/', id) WHERE invoice_no IS NULL;
+
+ALTER TABLE sales
+    MODIFY invoice_no VARCHAR(50) NOT NULL,
+    ADD UNIQUE KEY uq_sales_invoice_no (invoice_no);
+
+ALTER TABLE sale_returns
+    ADD COLUMN return_no VARCHAR(50) AFTER id;
+
+UPDATE sale_returns SET return_no = CONCAT('RET/LEGACY/', id) WHERE return_no IS NULL;
+
+ALTER TABLE sale_returns
+    MODIFY return_no VARCHAR(50) NOT NULL,
+    ADD UNIQUE KEY uq_sale_returns_return_no (return_no);
@@ -0,0 +1,42 @@
+DROP TABLE IF EXISTS sale_void_items;
+DROP TABLE IF EXISTS sale_voids;
+
+ALTER TABLE users
+    ADD COLUMN pin VARCHAR(255) NULL AFTER password;
+
+ALTER TABLE sale_items
+    ADD COLUMN voided_quantity INT NOT NULL DEFAULT 0 AFTER returned_quantity;
+
+CREATE TABLE sale_voids (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    sale_id BIGINT NOT NULL,
+    shift_id BIGINT NULL,
+    requested_by INT UNSIGNED NOT NULL,
+    approved_by INT UNSIGNED NOT NULL,
+    payment_method_id BIGINT NOT NULL,
+    amount DOUBLE NOT NULL,
+    reason TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_sale_voids_sale_id (sale_id),
+    INDEX idx_sale_voids_shift_id (shift_id),
+    FOREIGN KEY (sale_id) REFERENCES sales(id),
+    FOREIGN KEY (shift_id) REFERENCES shifts(id),
+    FOREIGN KEY (requested_by) REFERENCES users(id),
+    FOREIGN KEY (approved_by) REFERENCES users(id),
+    FOREIGN KEY (payment_method_id) REFERENCES payment_methods(id)
+);
+
+CREATE TABLE sale_void_items (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    sale_void_id BIGINT NOT NULL,
+    sale_item_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity INT NOT NULL,
+    amount DOUBLE NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_sale_void_items_sale_void_id (sale_void_id),
+    FOREIGN KEY (sale_void_id) REFERENCES sale_voids(id),
+    FOREIGN KEY (sale_item_id) REFERENCES sale_items(id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
+
@@ -0,0 +1,56 @@
+DROP TABLE IF EXISTS sale_taxes;
+DROP TABLE IF EXISTS tax_rates;
+
+CREATE TABLE tax_rates (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    code VARCHAR(30) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    rate DOUBLE NOT NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+
+INSERT INTO tax_rates (code, name, rate) VALUES
+    ('PPN', 'PPN', 11),
+    ('PB1', 'PB1', 10);
+
+ALTER TABLE products
+    ADD COLUMN tax_rate_id BIGINT NULL AFTER category_id,
+    ADD FOREIGN KEY (tax_rate_id) REFERENCES tax_rates(id);
+
+ALTER TABLE categories
+    ADD COLUMN tax_rate_id BIGINT NULL AFTER name,
+    ADD FOREIGN KEY (tax_rate_id) REFERENCES tax_rates(id);
+
+ALTER TABLE store_settings
+    ADD COLUMN price_includes_tax BOOLEAN NOT NULL DEFAULT FALSE AFTER paper_width;
+
+ALTER TABLE sales
+    ADD COLUMN tax_total DOUBLE NOT NULL DEFAULT 0 AFTER subtotal,
+    ADD COLUMN price_includes_tax BOOLEAN NOT NULL DEFAULT FALSE AFTER total;
+
+ALTER TABLE sale_items
+    ADD COLUMN tax_rate_id BIGINT NULL AFTER subtotal,
+    ADD COLUMN tax_rate DOUBLE NOT NULL DEFAULT 0 AFTER tax_rate_id,
+    ADD COLUMN tax_amount DOUBLE NOT NULL DEFAULT 0 AFTER tax_rate,
+    ADD COLUMN total DOUBLE NOT NULL DEFAULT 0 AFTER tax_amount;
+
+-- Lines sold before taxes existed were charged their subtotal
+UPDATE sale_items SET total = subtotal;
+
+CREATE TABLE sale_taxes (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    sale_id BIGINT NOT NULL,
+    tax_rate_id BIGINT NOT NULL,
+    tax_code VARCHAR(30) NOT NULL,
+    tax_name VARCHAR(100) NOT NULL,
+    rate DOUBLE NOT NULL,
+    taxable_amount DOUBLE NOT NULL,
+    tax_amount DOUBLE NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_sale_taxes_sale_id (sale_id),
+    FOREIGN KEY (sale_id) REFERENCES sales(id),
+    FOREIGN KEY (tax_rate_id) REFERENCES tax_rates(id)
+);
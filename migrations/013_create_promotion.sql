@@ -0,0 +1,58 @@
+DROP TABLE IF EXISTS sale_item_promotions;
+DROP TABLE IF EXISTS promotion_targets;
+DROP TABLE IF EXISTS promotions;
+
+CREATE TABLE promotions (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    code VARCHAR(30) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    scope VARCHAR(10) NOT NULL DEFAULT 'item',
+    value DOUBLE NOT NULL DEFAULT 0,
+    buy_quantity INT NOT NULL DEFAULT 0,
+    get_quantity INT NOT NULL DEFAULT 0,
+    bundle_quantity INT NOT NULL DEFAULT 0,
+    min_spend DOUBLE NOT NULL DEFAULT 0,
+    stackable BOOLEAN NOT NULL DEFAULT FALSE,
+    priority INT NOT NULL DEFAULT 0,
+    starts_at DATETIME NULL,
+    ends_at DATETIME NULL,
+    start_time VARCHAR(5),
+    end_time VARCHAR(5),
+    outlet_id BIGINT NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    INDEX idx_promotions_active (is_active, starts_at, ends_at)
+);
+
+CREATE TABLE promotion_targets (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    promotion_id BIGINT NOT NULL,
+    product_id BIGINT NULL,
+    category_id BIGINT NULL,
+    INDEX idx_promotion_targets_promotion_id (promotion_id),
+    FOREIGN KEY (promotion_id) REFERENCES promotions(id),
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (category_id) REFERENCES categories(id)
+);
+
+ALTER TABLE sales
+    ADD COLUMN discount_total DOUBLE NOT NULL DEFAULT 0 AFTER subtotal;
+
+ALTER TABLE sale_items
+    ADD COLUMN discount DOUBLE NOT NULL DEFAULT 0 AFTER subtotal;
+
+CREATE TABLE sale_item_promotions (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    sale_item_id BIGINT NOT NULL,
+    promotion_id BIGINT NOT NULL,
+    promotion_code VARCHAR(30) NOT NULL,
+    promotion_name VARCHAR(100) NOT NULL,
+    discount DOUBLE NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_sale_item_promotions_sale_item_id (sale_item_id),
+    FOREIGN KEY (sale_item_id) REFERENCES sale_items(id),
+    FOREIGN KEY (promotion_id) REFERENCES promotions(id)
+);
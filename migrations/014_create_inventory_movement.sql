@@ -0,0 +1,26 @@
+DROP TABLE IF EXISTS inventory_movements;
+
+CREATE TABLE inventory_movements (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    quantity INT NOT NULL,
+    balance_after INT NOT NULL,
+    reference_type VARCHAR(30),
+    reference_id BIGINT NULL,
+    reference_no VARCHAR(50),
+    note TEXT,
+    user_id INT UNSIGNED NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_inventory_movements_product_id (product_id, id),
+    INDEX idx_inventory_movements_created_at (created_at),
+    INDEX idx_inventory_movements_reference (reference_type, reference_id),
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- Open the ledger with the stock each product holds today
+INSERT INTO inventory_movements (product_id, type, quantity, balance_after, reference_type, reference_id, note)
+SELECT id, 'adjustment', stock, stock, 'product', id, 'Opening balance'
+FROM products
+WHERE stock <> 0;
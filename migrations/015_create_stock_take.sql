@@ -0,0 +1,43 @@
+DROP TABLE IF EXISTS stock_take_counts;
+DROP TABLE IF EXISTS stock_take_items;
+DROP TABLE IF EXISTS stock_takes;
+
+CREATE TABLE stock_takes (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    category_id BIGINT NULL,
+    note TEXT,
+    created_by INT UNSIGNED NOT NULL,
+    approved_by INT UNSIGNED NULL,
+    approved_at DATETIME NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_stock_takes_status (status),
+    FOREIGN KEY (category_id) REFERENCES categories(id),
+    FOREIGN KEY (created_by) REFERENCES users(id),
+    FOREIGN KEY (approved_by) REFERENCES users(id)
+);
+
+CREATE TABLE stock_take_items (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    stock_take_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    system_quantity INT NOT NULL,
+    counted_quantity INT NULL,
+    cost_price DOUBLE NOT NULL DEFAULT 0,
+    UNIQUE KEY idx_stock_take_items_product (stock_take_id, product_id),
+    FOREIGN KEY (stock_take_id) REFERENCES stock_takes(id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
+
+CREATE TABLE stock_take_counts (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    stock_take_item_id BIGINT NOT NULL,
+    counter_id INT UNSIGNED NOT NULL,
+    quantity INT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_stock_take_counts_item_counter (stock_take_item_id, counter_id),
+    FOREIGN KEY (stock_take_item_id) REFERENCES stock_take_items(id),
+    FOREIGN KEY (counter_id) REFERENCES users(id)
+);
@@ -0,0 +1,76 @@
+DROP TABLE IF EXISTS goods_receipt_items;
+DROP TABLE IF EXISTS goods_receipts;
+DROP TABLE IF EXISTS purchase_order_items;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TABLE IF EXISTS suppliers;
+
+CREATE TABLE suppliers (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    code VARCHAR(30) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    contact_name VARCHAR(100),
+    phone VARCHAR(30),
+    email VARCHAR(100),
+    address TEXT,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+
+CREATE TABLE purchase_orders (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    order_no VARCHAR(50) UNIQUE NOT NULL,
+    supplier_id BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    expected_date DATETIME NULL,
+    note TEXT,
+    total DOUBLE NOT NULL DEFAULT 0,
+    created_by INT UNSIGNED NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_purchase_orders_supplier_id (supplier_id),
+    INDEX idx_purchase_orders_status (status),
+    FOREIGN KEY (supplier_id) REFERENCES suppliers(id),
+    FOREIGN KEY (created_by) REFERENCES users(id)
+);
+
+CREATE TABLE purchase_order_items (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    purchase_order_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity INT NOT NULL,
+    received_quantity INT NOT NULL DEFAULT 0,
+    unit_cost DOUBLE NOT NULL,
+    subtotal DOUBLE NOT NULL,
+    INDEX idx_purchase_order_items_order_id (purchase_order_id),
+    FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
+
+CREATE TABLE goods_receipts (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    receipt_no VARCHAR(50) UNIQUE NOT NULL,
+    purchase_order_id BIGINT NOT NULL,
+    received_by INT UNSIGNED NOT NULL,
+    note TEXT,
+    total DOUBLE NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_goods_receipts_order_id (purchase_order_id),
+    FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(id),
+    FOREIGN KEY (received_by) REFERENCES users(id)
+);
+
+CREATE TABLE goods_receipt_items (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    goods_receipt_id BIGINT NOT NULL,
+    purchase_order_item_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity INT NOT NULL,
+    unit_cost DOUBLE NOT NULL,
+    subtotal DOUBLE NOT NULL,
+    INDEX idx_goods_receipt_items_receipt_id (goods_receipt_id),
+    FOREIGN KEY (goods_receipt_id) REFERENCES goods_receipts(id),
+    FOREIGN KEY (purchase_order_item_id) REFERENCES purchase_order_items(id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
@@ -0,0 +1,109 @@
+DROP TABLE IF EXISTS product_stocks;
+DROP TABLE IF EXISTS terminals;
+DROP TABLE IF EXISTS outlets;
+
+CREATE TABLE outlets (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    code VARCHAR(20) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL DEFAULT 'store',
+    address TEXT,
+    phone VARCHAR(30),
+    is_default BOOLEAN DEFAULT FALSE,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+
+-- Everything up to now happened in a single store
+INSERT INTO outlets (code, name, is_default) VALUES ('MAIN', 'Main Store', TRUE);
+SET @main_outlet_id = LAST_INSERT_ID();
+
+CREATE TABLE terminals (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    code VARCHAR(20) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    outlet_id BIGINT NOT NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    INDEX idx_terminals_outlet_id (outlet_id),
+    FOREIGN KEY (outlet_id) REFERENCES outlets(id)
+);
+
+CREATE TABLE product_stocks (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL,
+    outlet_id BIGINT NOT NULL,
+    stock INT NOT NULL DEFAULT 0,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_product_stocks_product_outlet (product_id, outlet_id),
+    INDEX idx_product_stocks_outlet_id (outlet_id),
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (outlet_id) REFERENCES outlets(id)
+);
+
+INSERT INTO product_stocks (product_id, outlet_id, stock)
+SELECT id, @main_outlet_id, stock
+FROM products
+WHERE stock <> 0;
+
+ALTER TABLE inventory_movements
+    ADD COLUMN outlet_id BIGINT NULL AFTER product_id;
+UPDATE inventory_movements SET outlet_id = @main_outlet_id;
+ALTER TABLE inventory_movements
+    MODIFY outlet_id BIGINT NOT NULL,
+    ADD INDEX idx_inventory_movements_outlet_id (outlet_id, product_id, id),
+    ADD FOREIGN KEY (outlet_id) REFERENCES outlets(id);
+
+ALTER TABLE shifts
+    ADD COLUMN terminal_id BIGINT NULL AFTER user_id,
+    ADD COLUMN outlet_id BIGINT NULL AFTER terminal_id;
+UPDATE shifts SET outlet_id = @main_outlet_id;
+ALTER TABLE shifts
+    MODIFY outlet_id BIGINT NOT NULL,
+    ADD INDEX idx_shifts_terminal_id (terminal_id),
+    ADD INDEX idx_shifts_outlet_id (outlet_id),
+    ADD FOREIGN KEY (terminal_id) REFERENCES terminals(id),
+    ADD FOREIGN KEY (outlet_id) REFERENCES outlets(id);
+
+ALTER TABLE sales
+    ADD COLUMN outlet_id BIGINT NULL AFTER shift_id;
+UPDATE sales SET outlet_id = @main_outlet_id;
+ALTER TABLE sales
+    MODIFY outlet_id BIGINT NOT NULL,
+    ADD INDEX idx_sales_outlet_id (outlet_id),
+    ADD FOREIGN KEY (outlet_id) REFERENCES outlets(id);
+
+ALTER TABLE sale_returns
+    ADD COLUMN outlet_id BIGINT NULL AFTER shift_id;
+UPDATE sale_returns SET outlet_id = @main_outlet_id;
+ALTER TABLE sale_returns
+    MODIFY outlet_id BIGINT NOT NULL,
+    ADD INDEX idx_sale_returns_outlet_id (outlet_id),
+    ADD FOREIGN KEY (outlet_id) REFERENCES outlets(id);
+
+ALTER TABLE sale_voids
+    ADD COLUMN outlet_id BIGINT NULL AFTER shift_id;
+UPDATE sale_voids SET outlet_id = @main_outlet_id;
+ALTER TABLE sale_voids
+    MODIFY outlet_id BIGINT NOT NULL,
+    ADD FOREIGN KEY (outlet_id) REFERENCES outlets(id);
+
+ALTER TABLE stock_takes
+    ADD COLUMN outlet_id BIGINT NULL AFTER id;
+UPDATE stock_takes SET outlet_id = @main_outlet_id;
+ALTER TABLE stock_takes
+    MODIFY outlet_id BIGINT NOT NULL,
+    ADD INDEX idx_stock_takes_outlet_id (outlet_id),
+    ADD FOREIGN KEY (outlet_id) REFERENCES outlets(id);
+
+ALTER TABLE purchase_orders
+    ADD COLUMN outlet_id BIGINT NULL AFTER supplier_id;
+UPDATE purchase_orders SET outlet_id = @main_outlet_id;
+ALTER TABLE purchase_orders
+    MODIFY outlet_id BIGINT NOT NULL,
+    ADD INDEX idx_purchase_orders_outlet_id (outlet_id),
+    ADD FOREIGN KEY (outlet_id) REFERENCES outlets(id);
@@ -0,0 +1,39 @@
+DROP TABLE IF EXISTS stock_transfer_items;
+DROP TABLE IF EXISTS stock_transfers;
+
+CREATE TABLE stock_transfers (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    transfer_no VARCHAR(50) UNIQUE NOT NULL,
+    from_outlet_id BIGINT NOT NULL,
+    to_outlet_id BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    note TEXT,
+    created_by INT UNSIGNED NOT NULL,
+    shipped_by INT UNSIGNED NULL,
+    shipped_at DATETIME NULL,
+    received_by INT UNSIGNED NULL,
+    received_at DATETIME NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_stock_transfers_from_outlet_id (from_outlet_id),
+    INDEX idx_stock_transfers_to_outlet_id (to_outlet_id),
+    INDEX idx_stock_transfers_status (status),
+    FOREIGN KEY (from_outlet_id) REFERENCES outlets(id),
+    FOREIGN KEY (to_outlet_id) REFERENCES outlets(id),
+    FOREIGN KEY (created_by) REFERENCES users(id),
+    FOREIGN KEY (shipped_by) REFERENCES users(id),
+    FOREIGN KEY (received_by) REFERENCES users(id)
+);
+
+CREATE TABLE stock_transfer_items (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    stock_transfer_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    quantity INT NOT NULL,
+    received_quantity INT NULL,
+    discrepancy INT NOT NULL DEFAULT 0,
+    discrepancy_reason VARCHAR(255),
+    UNIQUE KEY idx_stock_transfer_items_product (stock_transfer_id, product_id),
+    FOREIGN KEY (stock_transfer_id) REFERENCES stock_transfers(id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
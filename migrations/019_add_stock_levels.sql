@@ -0,0 +1,29 @@
+ALTER TABLE products
+    ADD COLUMN min_stock INT NOT NULL DEFAULT 0 AFTER stock,
+    ADD COLUMN reorder_quantity INT NOT NULL DEFAULT 0 AFTER min_stock;
+
+ALTER TABLE product_stocks
+    ADD COLUMN min_stock INT NULL AFTER stock,
+    ADD COLUMN reorder_quantity INT NULL AFTER min_stock;
+
+DROP TABLE IF EXISTS stock_alerts;
+
+CREATE TABLE stock_alerts (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL,
+    outlet_id BIGINT NOT NULL,
+    movement_id BIGINT NOT NULL,
+    stock INT NOT NULL,
+    min_stock INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    acknowledged_by INT UNSIGNED NULL,
+    acknowledged_at DATETIME NULL,
+    resolved_at DATETIME NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_stock_alerts_product_outlet (product_id, outlet_id, status),
+    INDEX idx_stock_alerts_status (status),
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (outlet_id) REFERENCES outlets(id),
+    FOREIGN KEY (movement_id) REFERENCES inventory_movements(id),
+    FOREIGN KEY (acknowledged_by) REFERENCES users(id)
+);
@@ -0,0 +1,39 @@
+ALTER TABLE products
+    ADD COLUMN track_expiry BOOLEAN NOT NULL DEFAULT FALSE AFTER reorder_quantity;
+
+ALTER TABLE goods_receipt_items
+    ADD COLUMN lot_no VARCHAR(50) NULL AFTER subtotal,
+    ADD COLUMN expiry_date DATE NULL AFTER lot_no;
+
+DROP TABLE IF EXISTS inventory_movement_lots;
+DROP TABLE IF EXISTS product_lots;
+
+CREATE TABLE product_lots (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL,
+    outlet_id BIGINT NOT NULL,
+    lot_no VARCHAR(50) NOT NULL DEFAULT '',
+    expiry_date DATE NULL,
+    quantity INT NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_product_lots_product_outlet_lot (product_id, outlet_id, lot_no),
+    INDEX idx_product_lots_expiry_date (expiry_date),
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (outlet_id) REFERENCES outlets(id)
+);
+
+CREATE TABLE inventory_movement_lots (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    movement_id BIGINT NOT NULL,
+    lot_id BIGINT NOT NULL,
+    quantity INT NOT NULL,
+    INDEX idx_inventory_movement_lots_movement_id (movement_id),
+    INDEX idx_inventory_movement_lots_lot_id (lot_id),
+    FOREIGN KEY (movement_id) REFERENCES inventory_movements(id),
+    FOREIGN KEY (lot_id) REFERENCES product_lots(id)
+);
+
+-- Stock on hand has no lot yet, so it becomes the unlotted lot of its outlet
+INSERT INTO product_lots (product_id, outlet_id, lot_no, quantity)
+SELECT product_id, outlet_id, '', stock FROM product_stocks WHERE stock > 0;
@@ -0,0 +1,29 @@
+ALTER TABLE products
+    ADD COLUMN barcode VARCHAR(50) NULL AFTER code,
+    ADD COLUMN parent_id BIGINT NULL AFTER barcode,
+    ADD COLUMN has_variants BOOLEAN NOT NULL DEFAULT FALSE AFTER parent_id,
+    ADD UNIQUE INDEX idx_products_barcode (barcode),
+    ADD INDEX idx_products_parent_id (parent_id),
+    ADD FOREIGN KEY (parent_id) REFERENCES products(id);
+
+DROP TABLE IF EXISTS variant_options;
+DROP TABLE IF EXISTS variant_attributes;
+
+CREATE TABLE variant_attributes (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    UNIQUE KEY idx_variant_attributes_product_name (product_id, name),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
+
+CREATE TABLE variant_options (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL,
+    attribute_id BIGINT NOT NULL,
+    value VARCHAR(50) NOT NULL,
+    UNIQUE KEY idx_variant_options_product_attribute (product_id, attribute_id),
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (attribute_id) REFERENCES variant_attributes(id)
+);
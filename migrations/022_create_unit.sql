@@ -0,0 +1,57 @@
+DROP TABLE IF EXISTS product_units;
+DROP TABLE IF EXISTS units;
+
+CREATE TABLE units (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    code VARCHAR(20) UNIQUE NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+
+CREATE TABLE product_units (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL,
+    unit_id BIGINT NOT NULL,
+    factor INT NOT NULL,
+    price DOUBLE NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_product_units_product_unit (product_id, unit_id),
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (unit_id) REFERENCES units(id)
+);
+
+INSERT INTO units (code, name) VALUES ('PCS', 'Piece');
+
+ALTER TABLE products
+    ADD COLUMN base_unit_id BIGINT NULL AFTER track_expiry,
+    ADD FOREIGN KEY (base_unit_id) REFERENCES units(id);
+
+ALTER TABLE sale_items
+    ADD COLUMN unit_id BIGINT NULL AFTER product_name,
+    ADD COLUMN unit_code VARCHAR(20) NULL AFTER unit_id,
+    ADD COLUMN unit_factor INT NOT NULL DEFAULT 1 AFTER unit_code;
+
+ALTER TABLE sale_return_items
+    ADD COLUMN unit_factor INT NOT NULL DEFAULT 1 AFTER quantity;
+
+ALTER TABLE sale_void_items
+    ADD COLUMN unit_factor INT NOT NULL DEFAULT 1 AFTER quantity;
+
+ALTER TABLE draft_order_items
+    ADD COLUMN unit_id BIGINT NULL AFTER product_id,
+    DROP INDEX uq_draft_order_items_product,
+    ADD UNIQUE KEY uq_draft_order_items_product (draft_order_id, product_id, unit_id),
+    ADD FOREIGN KEY (unit_id) REFERENCES units(id);
+
+ALTER TABLE purchase_order_items
+    ADD COLUMN unit_id BIGINT NULL AFTER product_id,
+    ADD COLUMN unit_code VARCHAR(20) NULL AFTER unit_id,
+    ADD COLUMN unit_factor INT NOT NULL DEFAULT 1 AFTER unit_code,
+    ADD FOREIGN KEY (unit_id) REFERENCES units(id);
+
+ALTER TABLE goods_receipt_items
+    ADD COLUMN unit_factor INT NOT NULL DEFAULT 1 AFTER product_id;
@@ -0,0 +1,50 @@
+DROP TABLE IF EXISTS product_barcodes;
+
+CREATE TABLE product_barcodes (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL,
+    unit_id BIGINT NULL,
+    barcode VARCHAR(48) NOT NULL,
+    type VARCHAR(10) NOT NULL,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_product_barcodes_barcode (barcode),
+    INDEX idx_product_barcodes_product_id (product_id),
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (unit_id) REFERENCES units(id)
+);
+
+-- Carry over the single barcode column; its check digits were never
+-- verified, so the type only follows the length
+INSERT INTO product_barcodes (product_id, barcode, type, is_primary)
+SELECT id, barcode,
+    CASE
+        WHEN barcode REGEXP '^[0-9]{13}$' THEN 'ean13'
+        WHEN barcode REGEXP '^[0-9]{12}$' THEN 'upca'
+        WHEN barcode REGEXP '^[0-9]{8}$' THEN 'ean8'
+        ELSE 'code128'
+    END,
+    TRUE
+FROM products
+WHERE barcode IS NOT NULL AND barcode <> '' AND deleted_at IS NULL;
+
+-- Every other sellable product gets its internal EAN-13: prefix 20, the
+-- product ID in ten digits and the GS1 check digit
+INSERT INTO product_barcodes (product_id, barcode, type, is_primary)
+SELECT id, CONCAT(digits, MOD(10 - MOD(
+        SUBSTRING(digits, 1, 1) + 3 * SUBSTRING(digits, 2, 1) +
+        SUBSTRING(digits, 3, 1) + 3 * SUBSTRING(digits, 4, 1) +
+        SUBSTRING(digits, 5, 1) + 3 * SUBSTRING(digits, 6, 1) +
+        SUBSTRING(digits, 7, 1) + 3 * SUBSTRING(digits, 8, 1) +
+        SUBSTRING(digits, 9, 1) + 3 * SUBSTRING(digits, 10, 1) +
+        SUBSTRING(digits, 11, 1) + 3 * SUBSTRING(digits, 12, 1), 10), 10)),
+    'ean13', TRUE
+FROM (
+    SELECT id, CONCAT('20', LPAD(id, 10, '0')) AS digits
+    FROM products
+    WHERE (barcode IS NULL OR barcode = '') AND has_variants = FALSE AND deleted_at IS NULL
+) internal;
+
+ALTER TABLE products
+    DROP INDEX idx_products_barcode,
+    DROP COLUMN barcode;
@@ -0,0 +1,31 @@
+ALTER TABLE products
+    ADD COLUMN is_composite BOOLEAN NOT NULL DEFAULT FALSE AFTER has_variants;
+
+DROP TABLE IF EXISTS sale_item_components;
+DROP TABLE IF EXISTS recipe_items;
+
+CREATE TABLE recipe_items (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL,
+    component_id BIGINT NOT NULL,
+    unit_id BIGINT NULL,
+    quantity INT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_recipe_items_product_component (product_id, component_id),
+    INDEX idx_recipe_items_component_id (component_id),
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (component_id) REFERENCES products(id),
+    FOREIGN KEY (unit_id) REFERENCES units(id)
+);
+
+-- What one unit of a composite sale line took from each component
+CREATE TABLE sale_item_components (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    sale_item_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    product_code VARCHAR(50) NOT NULL,
+    quantity INT NOT NULL,
+    INDEX idx_sale_item_components_sale_item_id (sale_item_id),
+    FOREIGN KEY (sale_item_id) REFERENCES sale_items(id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
@@ -0,0 +1,74 @@
+DROP TABLE IF EXISTS product_price_histories;
+DROP TABLE IF EXISTS customer_groups;
+DROP TABLE IF EXISTS price_list_items;
+DROP TABLE IF EXISTS price_lists;
+
+CREATE TABLE price_lists (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    code VARCHAR(20) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+
+CREATE TABLE price_list_items (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    price_list_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    min_quantity INT NOT NULL DEFAULT 1,
+    price DOUBLE NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_price_list_items_list_product_quantity (price_list_id, product_id, min_quantity),
+    INDEX idx_price_list_items_product_id (product_id),
+    FOREIGN KEY (price_list_id) REFERENCES price_lists(id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
+
+CREATE TABLE customer_groups (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    code VARCHAR(20) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    price_list_id BIGINT NULL,
+    is_active BOOLEAN DEFAULT TRUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    FOREIGN KEY (price_list_id) REFERENCES price_lists(id)
+);
+
+ALTER TABLE outlets
+    ADD COLUMN price_list_id BIGINT NULL AFTER phone,
+    ADD FOREIGN KEY (price_list_id) REFERENCES price_lists(id);
+
+ALTER TABLE sales
+    ADD COLUMN customer_group_id BIGINT NULL AFTER draft_order_id,
+    ADD COLUMN price_list_id BIGINT NULL AFTER customer_group_id,
+    ADD FOREIGN KEY (customer_group_id) REFERENCES customer_groups(id),
+    ADD FOREIGN KEY (price_list_id) REFERENCES price_lists(id);
+
+CREATE TABLE product_price_histories (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL,
+    old_price DOUBLE NULL,
+    price DOUBLE NOT NULL,
+    changed_by INT UNSIGNED NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_product_price_histories_product_created (product_id, created_at),
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (changed_by) REFERENCES users(id)
+);
+
+-- The history is append-only; the database refuses to rewrite it
+CREATE TRIGGER product_price_histories_no_update BEFORE UPDATE ON product_price_histories
+FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'product_price_histories is append-only';
+
+CREATE TRIGGER product_price_histories_no_delete BEFORE DELETE ON product_price_histories
+FOR EACH ROW SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'product_price_histories is append-only';
+
+-- Start every product's history with the price it has now
+INSERT INTO product_price_histories (product_id, price)
+SELECT id, price FROM products WHERE deleted_at IS NULL;
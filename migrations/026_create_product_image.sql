@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS product_images;
+
+-- path and thumbnail_path are keys in file storage, not URLs
+CREATE TABLE product_images (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL,
+    path VARCHAR(255) NOT NULL,
+    thumbnail_path VARCHAR(255) NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    size BIGINT NOT NULL,
+    thumbnail_size BIGINT NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_product_images_product_id (product_id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+);
@@ -0,0 +1,5 @@
+-- Categories form a tree; top categories have no parent
+ALTER TABLE categories
+    ADD COLUMN parent_id BIGINT NULL AFTER name,
+    ADD INDEX idx_categories_parent_id (parent_id),
+    ADD FOREIGN KEY (parent_id) REFERENCES categories(id);
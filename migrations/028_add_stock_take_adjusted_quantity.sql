@@ -0,0 +1,4 @@
+-- What approving a stock take posted per product: counted minus the stock
+-- at approval, which differs from the opening snapshot when stock moved
+ALTER TABLE stock_take_items
+    ADD COLUMN adjusted_quantity INT NULL AFTER counted_quantity;
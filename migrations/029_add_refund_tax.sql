@@ -0,0 +1,24 @@
+-- Returned and voided lines keep their share of the line's tax, so the tax
+-- report can net refunds against the sales
+ALTER TABLE sale_return_items
+    ADD COLUMN tax_rate_id BIGINT NULL AFTER subtotal,
+    ADD COLUMN taxable_amount DOUBLE NOT NULL DEFAULT 0 AFTER tax_rate_id,
+    ADD COLUMN tax_amount DOUBLE NOT NULL DEFAULT 0 AFTER taxable_amount;
+
+ALTER TABLE sale_void_items
+    ADD COLUMN tax_rate_id BIGINT NULL AFTER amount,
+    ADD COLUMN taxable_amount DOUBLE NOT NULL DEFAULT 0 AFTER tax_rate_id,
+    ADD COLUMN tax_amount DOUBLE NOT NULL DEFAULT 0 AFTER taxable_amount;
+
+-- Earlier refunds take the same share of their sale line's tax
+UPDATE sale_return_items
+    JOIN sale_items ON sale_items.id = sale_return_items.sale_item_id
+    SET sale_return_items.tax_rate_id = sale_items.tax_rate_id,
+        sale_return_items.tax_amount = ROUND(sale_items.tax_amount / sale_items.quantity * sale_return_items.quantity, 2),
+        sale_return_items.taxable_amount = ROUND(sale_return_items.subtotal - ROUND(sale_items.tax_amount / sale_items.quantity * sale_return_items.quantity, 2), 2);
+
+UPDATE sale_void_items
+    JOIN sale_items ON sale_items.id = sale_void_items.sale_item_id
+    SET sale_void_items.tax_rate_id = sale_items.tax_rate_id,
+        sale_void_items.tax_amount = ROUND(sale_items.tax_amount / sale_items.quantity * sale_void_items.quantity, 2),
+        sale_void_items.taxable_amount = ROUND(sale_void_items.amount - ROUND(sale_items.tax_amount / sale_items.quantity * sale_void_items.quantity, 2), 2);
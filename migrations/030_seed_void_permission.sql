@@ -0,0 +1,18 @@
+-- Supervisors approve voids. Policies are not set up in code on start, so
+-- the rule is seeded here; the table is the one the Casbin adapter keeps.
+CREATE TABLE IF NOT EXISTS casbin_rule (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    ptype VARCHAR(100),
+    v0 VARCHAR(100),
+    v1 VARCHAR(100),
+    v2 VARCHAR(100),
+    v3 VARCHAR(100),
+    v4 VARCHAR(100),
+    v5 VARCHAR(100)
+);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2, v3, v4, v5)
+SELECT 'p', 'supervisor', 'void', 'approve', '', '', '' FROM DUAL
+WHERE NOT EXISTS (
+    SELECT 1 FROM casbin_rule WHERE ptype = 'p' AND v0 = 'supervisor' AND v1 = 'void' AND v2 = 'approve'
+);
@@ -0,0 +1,7 @@
+-- Image files are named after their content, so one path is one image;
+-- drop rows left behind by racing uploads before enforcing it
+DELETE duplicate FROM product_images duplicate
+    JOIN product_images kept ON kept.path = duplicate.path AND kept.id < duplicate.id;
+
+ALTER TABLE product_images
+    ADD UNIQUE INDEX idx_product_images_path (path);
@@ -0,0 +1,122 @@
+package barcode
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Symbologies a product barcode can be in.
+const (
+	EAN13   = "ean13"
+	EAN8    = "ean8"
+	UPCA    = "upca"
+	Code128 = "code128"
+)
+
+// InternalPrefix starts every EAN-13 generated in the store. GS1 keeps the
+// 20-29 range for numbers that never leave the store, so generated codes
+// cannot collide with a supplier's.
+const InternalPrefix = "20"
+
+// MaxLength is the longest Code 128 value accepted; longer symbols do not
+// fit on a shelf label.
+const MaxLength = 48
+
+// Detect returns the symbology of a scanned value: EAN-13, UPC-A or EAN-8
+// for 13, 12 or 8 digits, Code 128 for anything else. Values that look like
+// an EAN or UPC must carry a valid check digit.
+func Detect(value string) (string, error) {
+	symbology := Code128
+	if isDigits(value) {
+		switch len(value) {
+		case 13:
+			symbology = EAN13
+		case 12:
+			symbology = UPCA
+		case 8:
+			symbology = EAN8
+		}
+	}
+	return symbology, Validate(value, symbology)
+}
+
+// Validate checks value against symbology: the length and check digit of
+// EAN-13, UPC-A and EAN-8, printable ASCII for Code 128.
+func Validate(value, symbology string) error {
+	switch symbology {
+	case EAN13, UPCA, EAN8:
+		length := map[string]int{EAN13: 13, UPCA: 12, EAN8: 8}[symbology]
+		if len(value) != length || !isDigits(value) {
+			return fmt.Errorf("%s must be %d digits", symbology, length)
+		}
+		if check := CheckDigit(value[:length-1]); value[length-1] != check {
+			return fmt.Errorf("%s has check digit %c, expected %c", value, value[length-1], check)
+		}
+	case Code128:
+		if value == "" || len(value) > MaxLength {
+			return fmt.Errorf("code128 must be 1 to %d characters", MaxLength)
+		}
+		for _, r := range value {
+			if r < ' ' || r > '~' {
+				return fmt.Errorf("code128 only takes printable ASCII")
+			}
+		}
+	default:
+		return fmt.Errorf("unknown barcode type %q", symbology)
+	}
+	return nil
+}
+
+// CheckDigit returns the GS1 check digit of the digits before it. The same
+// mod-10 rule covers EAN-13, UPC-A and EAN-8: counting from the right,
+// every other digit weighs 3.
+func CheckDigit(digits string) byte {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-1-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// Internal returns the in-store EAN-13 for seq: InternalPrefix, seq zero
+// padded to ten digits and the check digit.
+func Internal(seq uint64) (string, error) {
+	if seq >= 1e10 {
+		return "", fmt.Errorf("%d does not fit an internal barcode", seq)
+	}
+	digits := fmt.Sprintf("%s%010d", InternalPrefix, seq)
+	return digits + string(CheckDigit(digits)), nil
+}
+
+// Candidates returns the values a scan may be stored under. Scanners set up
+// for EAN report a UPC-A with a leading zero and the other way round, so
+// both forms are tried.
+func Candidates(value string) []string {
+	value = strings.TrimSpace(value)
+	candidates := []string{value}
+	if isDigits(value) {
+		switch {
+		case len(value) == 13 && value[0] == '0':
+			candidates = append(candidates, value[1:])
+		case len(value) == 12:
+			candidates = append(candidates, "0"+value)
+		}
+	}
+	return candidates
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+package barcode
+
+import "testing"
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   byte
+	}{
+		{"400638133393", '1'}, // EAN-13
+		{"03600029145", '2'},  // UPC-A
+		{"9638507", '4'},      // EAN-8
+		{"200000000001", '5'},
+		{"000000000000", '0'},
+	}
+
+	for _, tt := range tests {
+		if got := CheckDigit(tt.digits); got != tt.want {
+			t.Errorf("CheckDigit(%s) = %c, want %c", tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"4006381333931", EAN13, false},
+		{"4006381333932", EAN13, true},
+		{"036000291452", UPCA, false},
+		{"036000291453", UPCA, true},
+		{"96385074", EAN8, false},
+		{"96385075", EAN8, true},
+		{"ABC-123", Code128, false},
+		{"12345", Code128, false},
+		{"", Code128, true},
+		{"café", Code128, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := Detect(tt.value)
+			if got != tt.want {
+				t.Errorf("Detect(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Detect(%q) error = %v, want error %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		value     string
+		symbology string
+		wantErr   bool
+	}{
+		{"4006381333931", EAN13, false},
+		{"400638133393", EAN13, true},
+		{"036000291452", UPCA, false},
+		{"4006381333931", UPCA, true},
+		{"96385074", EAN8, false},
+		{"9638507A", EAN8, true},
+		{"4006381333931", "qr", true},
+	}
+
+	for _, tt := range tests {
+		if err := Validate(tt.value, tt.symbology); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %s) error = %v, want error %v", tt.value, tt.symbology, err, tt.wantErr)
+		}
+	}
+}
+
+func TestInternal(t *testing.T) {
+	got, err := Internal(1)
+	if err != nil || got != "2000000000015" {
+		t.Fatalf("Internal(1) = %q, %v, want 2000000000015", got, err)
+	}
+	if _, err := Detect(got); err != nil {
+		t.Errorf("Detect(%s) error = %v", got, err)
+	}
+	if _, err := Internal(1e10); err == nil {
+		t.Error("Internal(1e10) want error")
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"0036000291452", []string{"0036000291452", "036000291452"}},
+		{"036000291452", []string{"036000291452", "0036000291452"}},
+		{" 4006381333931 ", []string{"4006381333931"}},
+		{"ABC", []string{"ABC"}},
+	}
+
+	for _, tt := range tests {
+		got := Candidates(tt.value)
+		if len(got) != len(tt.want) {
+			t.Errorf("Candidates(%q) = %v, want %v", tt.value, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Candidates(%q) = %v, want %v", tt.value, got, tt.want)
+				break
+			}
+		}
+	}
+}
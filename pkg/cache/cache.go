@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache is an in-memory map whose entries expire a fixed time after they
+// were set. It is safe for concurrent use. Expired entries are dropped when
+// they are read or when the cache is cleared.
+type Cache[K comparable, V any] struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[K]entry[V]
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+func New[K comparable, V any](ttl time.Duration) *Cache[K, V] {
+	return &Cache[K, V]{ttl: ttl, entries: make(map[K]entry[V])}
+}
+
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.RLock()
+	e, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if ok && time.Now().Before(e.expiresAt) {
+		return e.value, true
+	}
+	if ok {
+		c.mu.Lock()
+		if e, ok := c.entries[key]; ok && !time.Now().Before(e.expiresAt) {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+
+	var zero V
+	return zero, false
+}
+
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	c.entries[key] = entry[V]{value: value, expiresAt: time.Now().Add(c.ttl)}
+	c.mu.Unlock()
+}
+
+// Clear drops every entry, for writes that may change any of them.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	c.entries = make(map[K]entry[V])
+	c.mu.Unlock()
+}
@@ -74,6 +74,9 @@ var (
 	ErrPaymentFailed      = New("ERR0904", "Payment failed")
 	ErrQuotaExceeded      = New("ERR0905", "Quota exceeded")
 	ErrAlreadyProcessed   = New("ERR0906", "Data already processed")
+	ErrShiftNotOpen       = New("ERR0907", "No open shift for this cashier")
+	ErrShiftAlreadyOpen   = New("ERR0908", "Cashier already has an open shift")
+	ErrStockExpired       = New("ERR0909", "Only expired stock left")
 )
 
 // Configuration / System
@@ -152,4 +155,167 @@ var (
 	ErrProductCreate = New("ERR1422", "Failed to create product")
 	ErrProductUpdate = New("ERR1423", "Failed to update product")
 	ErrProductDelete = New("ERR1424", "Failed to delete product")
+
+	// Sale errors
+	ErrSaleList   = New("ERR1425", "Failed to list sales")
+	ErrSaleShow   = New("ERR1426", "Failed to get sale detail")
+	ErrSaleCreate = New("ERR1427", "Failed to create sale")
+
+	// Payment errors
+	ErrPaymentMethodList   = New("ERR1428", "Failed to list payment methods")
+	ErrPaymentMethodShow   = New("ERR1429", "Failed to get payment method detail")
+	ErrPaymentMethodCreate = New("ERR1430", "Failed to create payment method")
+	ErrPaymentMethodUpdate = New("ERR1431", "Failed to update payment method")
+	ErrPaymentMethodDelete = New("ERR1432", "Failed to delete payment method")
+	ErrPaymentSummary      = New("ERR1433", "Failed to summarize payments")
+
+	// Sale return errors
+	ErrSaleReturnList   = New("ERR1434", "Failed to list sale returns")
+	ErrSaleReturnCreate = New("ERR1435", "Failed to create sale return")
+
+	// Shift errors
+	ErrShiftList          = New("ERR1436", "Failed to list shifts")
+	ErrShiftShow          = New("ERR1437", "Failed to get shift detail")
+	ErrShiftOpen          = New("ERR1438", "Failed to open shift")
+	ErrShiftClose         = New("ERR1439", "Failed to close shift")
+	ErrShiftSummary       = New("ERR1440", "Failed to summarize shift")
+	ErrCashMovementCreate = New("ERR1441", "Failed to record cash movement")
+
+	// Draft order errors
+	ErrDraftOrderList     = New("ERR1442", "Failed to list draft orders")
+	ErrDraftOrderShow     = New("ERR1443", "Failed to get draft order detail")
+	ErrDraftOrderCreate   = New("ERR1444", "Failed to create draft order")
+	ErrDraftOrderUpdate   = New("ERR1445", "Failed to update draft order")
+	ErrDraftOrderCheckout = New("ERR1446", "Failed to checkout draft order")
+
+	// Receipt & store setting errors
+	ErrReceiptRender      = New("ERR1447", "Failed to render receipt")
+	ErrStoreSettingShow   = New("ERR1448", "Failed to get store setting")
+	ErrStoreSettingUpdate = New("ERR1449", "Failed to update store setting")
+
+	// Sale void errors
+	ErrSaleVoidList   = New("ERR1450", "Failed to list sale voids")
+	ErrSaleVoidCreate = New("ERR1451", "Failed to void sale")
+
+	// Tax errors
+	ErrTaxRateList   = New("ERR1452", "Failed to list tax rates")
+	ErrTaxRateShow   = New("ERR1453", "Failed to get tax rate detail")
+	ErrTaxRateCreate = New("ERR1454", "Failed to create tax rate")
+	ErrTaxRateUpdate = New("ERR1455", "Failed to update tax rate")
+	ErrTaxRateDelete = New("ERR1456", "Failed to delete tax rate")
+	ErrTaxSummary    = New("ERR1457", "Failed to summarize taxes")
+
+	// Promotion errors
+	ErrPromotionList     = New("ERR1458", "Failed to list promotions")
+	ErrPromotionShow     = New("ERR1459", "Failed to get promotion detail")
+	ErrPromotionCreate   = New("ERR1460", "Failed to create promotion")
+	ErrPromotionUpdate   = New("ERR1461", "Failed to update promotion")
+	ErrPromotionDelete   = New("ERR1462", "Failed to delete promotion")
+	ErrPromotionEvaluate = New("ERR1463", "Failed to evaluate promotions")
+
+	// Inventory errors
+	ErrInventoryList   = New("ERR1464", "Failed to list inventory movements")
+	ErrInventoryAdjust = New("ERR1465", "Failed to adjust stock")
+
+	// Stock take errors
+	ErrStockTakeList    = New("ERR1466", "Failed to list stock takes")
+	ErrStockTakeShow    = New("ERR1467", "Failed to get stock take detail")
+	ErrStockTakeCreate  = New("ERR1468", "Failed to create stock take")
+	ErrStockTakeCount   = New("ERR1469", "Failed to save stock count")
+	ErrStockTakeApprove = New("ERR1470", "Failed to update stock take")
+
+	// Supplier errors
+	ErrSupplierList   = New("ERR1471", "Failed to list suppliers")
+	ErrSupplierShow   = New("ERR1472", "Failed to get supplier detail")
+	ErrSupplierCreate = New("ERR1473", "Failed to create supplier")
+	ErrSupplierUpdate = New("ERR1474", "Failed to update supplier")
+	ErrSupplierDelete = New("ERR1475", "Failed to delete supplier")
+
+	// Purchase order errors
+	ErrPurchaseOrderList   = New("ERR1476", "Failed to list purchase orders")
+	ErrPurchaseOrderShow   = New("ERR1477", "Failed to get purchase order detail")
+	ErrPurchaseOrderCreate = New("ERR1478", "Failed to create purchase order")
+	ErrPurchaseOrderUpdate = New("ERR1479", "Failed to update purchase order")
+	ErrGoodsReceiptCreate  = New("ERR1480", "Failed to receive goods")
+
+	// Outlet errors
+	ErrOutletList   = New("ERR1481", "Failed to list outlets")
+	ErrOutletShow   = New("ERR1482", "Failed to get outlet detail")
+	ErrOutletCreate = New("ERR1483", "Failed to create outlet")
+	ErrOutletUpdate = New("ERR1484", "Failed to update outlet")
+	ErrOutletDelete = New("ERR1485", "Failed to delete outlet")
+
+	// Terminal errors
+	ErrTerminalList   = New("ERR1486", "Failed to list terminals")
+	ErrTerminalShow   = New("ERR1487", "Failed to get terminal detail")
+	ErrTerminalCreate = New("ERR1488", "Failed to create terminal")
+	ErrTerminalUpdate = New("ERR1489", "Failed to update terminal")
+	ErrTerminalDelete = New("ERR1490", "Failed to delete terminal")
+
+	// Stock transfer errors
+	ErrStockTransferList    = New("ERR1491", "Failed to list stock transfers")
+	ErrStockTransferShow    = New("ERR1492", "Failed to get stock transfer detail")
+	ErrStockTransferCreate  = New("ERR1493", "Failed to create stock transfer")
+	ErrStockTransferUpdate  = New("ERR1494", "Failed to update stock transfer")
+	ErrStockTransferReceive = New("ERR1495", "Failed to receive stock transfer")
+
+	// Stock level errors
+	ErrLowStockReport   = New("ERR1496", "Failed to get low stock report")
+	ErrStockAlertList   = New("ERR1497", "Failed to list stock alerts")
+	ErrStockAlertShow   = New("ERR1498", "Failed to get stock alert detail")
+	ErrStockAlertUpdate = New("ERR1499", "Failed to update stock alert")
+	ErrStockLevelUpdate = New("ERR1500", "Failed to update stock level")
+
+	// Lot errors
+	ErrLotList          = New("ERR1501", "Failed to list lots")
+	ErrLotShow          = New("ERR1502", "Failed to get lot detail")
+	ErrNearExpiryReport = New("ERR1503", "Failed to get near expiry report")
+
+	// Product variant errors
+	ErrVariantCreate          = New("ERR1504", "Failed to create product variant")
+	ErrVariantAttributeUpdate = New("ERR1505", "Failed to update variant attributes")
+
+	// Unit of measure errors
+	ErrUnitList          = New("ERR1506", "Failed to list units")
+	ErrUnitShow          = New("ERR1507", "Failed to get unit detail")
+	ErrUnitCreate        = New("ERR1508", "Failed to create unit")
+	ErrUnitUpdate        = New("ERR1509", "Failed to update unit")
+	ErrUnitDelete        = New("ERR1510", "Failed to delete unit")
+	ErrProductUnitUpdate = New("ERR1511", "Failed to update product units")
+
+	// Barcode errors
+	ErrBarcodeLookup = New("ERR1512", "Failed to find product by barcode")
+	ErrBarcodeCreate = New("ERR1513", "Failed to add barcode")
+	ErrBarcodeDelete = New("ERR1514", "Failed to remove barcode")
+
+	// Recipe errors
+	ErrRecipeUpdate = New("ERR1515", "Failed to update recipe")
+
+	// Price list errors
+	ErrPriceListList       = New("ERR1516", "Failed to list price lists")
+	ErrPriceListShow       = New("ERR1517", "Failed to get price list detail")
+	ErrPriceListCreate     = New("ERR1518", "Failed to create price list")
+	ErrPriceListUpdate     = New("ERR1519", "Failed to update price list")
+	ErrPriceListDelete     = New("ERR1520", "Failed to delete price list")
+	ErrPriceListItemUpdate = New("ERR1521", "Failed to update price list items")
+	ErrPriceHistoryList    = New("ERR1522", "Failed to list price history")
+
+	// Customer group errors
+	ErrCustomerGroupList   = New("ERR1523", "Failed to list customer groups")
+	ErrCustomerGroupShow   = New("ERR1524", "Failed to get customer group detail")
+	ErrCustomerGroupCreate = New("ERR1525", "Failed to create customer group")
+	ErrCustomerGroupUpdate = New("ERR1526", "Failed to update customer group")
+	ErrCustomerGroupDelete = New("ERR1527", "Failed to delete customer group")
+
+	// Product image errors
+	ErrProductImageCreate = New("ERR1528", "Failed to add product image")
+	ErrProductImageDelete = New("ERR1529", "Failed to remove product image")
+
+	// Product import errors
+	ErrProductImport = New("ERR1530", "Failed to import products")
+	ErrProductExport = New("ERR1531", "Failed to export products")
+
+	// Category tree errors
+	ErrCategoryCycle       = New("ERR1532", "Category cannot be moved under itself or its subcategories")
+	ErrCategoryHasChildren = New("ERR1533", "Category still has subcategories")
 )
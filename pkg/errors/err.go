@@ -1,6 +1,9 @@
 package errors
 
-import "log"
+import (
+	"errors"
+	"log"
+)
 
 type AppError struct {
 	Code    string
@@ -29,3 +32,12 @@ func Get(appErr AppError, err error) error {
 
 	return appErr
 }
+
+// Is reports whether err is an AppError carrying the same code as target.
+func Is(err error, target AppError) bool {
+	var appErr AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code == target.Code
+	}
+	return false
+}
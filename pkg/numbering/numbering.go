@@ -0,0 +1,104 @@
+package numbering
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ResetDaily   = "daily"
+	ResetMonthly = "monthly"
+	ResetYearly  = "yearly"
+	ResetNever   = "never"
+)
+
+var seqToken = regexp.MustCompile(`\{SEQ(?::(\d+))?\}`)
+
+// Format builds a document number from a pattern. Supported tokens:
+//
+//	{OUTLET}    outlet code
+//	{YYYY} {YY} {MM} {DD} {YYYYMM} {YYYYMMDD}
+//	{SEQ} or {SEQ:n}   sequence, zero padded to n digits
+//
+// e.g. "INV/{OUTLET}/{YYYYMMDD}/{SEQ:4}" -> "INV/MAIN/20261018/0001".
+func Format(pattern, outlet string, at time.Time, seq int64) string {
+	number := strings.NewReplacer(
+		"{OUTLET}", outlet,
+		"{YYYYMMDD}", at.Format("20060102"),
+		"{YYYYMM}", at.Format("200601"),
+		"{YYYY}", at.Format("2006"),
+		"{YY}", at.Format("06"),
+		"{MM}", at.Format("01"),
+		"{DD}", at.Format("02"),
+	).Replace(pattern)
+
+	return seqToken.ReplaceAllStringFunc(number, func(token string) string {
+		width := 0
+		if match := seqToken.FindStringSubmatch(token); match[1] != "" {
+			width, _ = strconv.Atoi(match[1])
+		}
+		return fmt.Sprintf("%0*d", width, seq)
+	})
+}
+
+// Validate checks that a pattern can number documents without clashes:
+// it needs a {SEQ} token, the {OUTLET} token because every outlet counts on
+// its own, and date tokens that spell out the whole reset period, since the
+// counter starts again from 1 in every period.
+func Validate(pattern, reset string) error {
+	if !seqToken.MatchString(pattern) {
+		return errors.New("pattern has no {SEQ} token")
+	}
+	if !strings.Contains(pattern, "{OUTLET}") {
+		return errors.New("pattern has no {OUTLET} token")
+	}
+
+	has := func(tokens ...string) bool {
+		for _, token := range tokens {
+			if strings.Contains(pattern, token) {
+				return true
+			}
+		}
+		return false
+	}
+	year := has("{YYYYMMDD}", "{YYYYMM}", "{YYYY}", "{YY}")
+	month := has("{YYYYMMDD}", "{YYYYMM}", "{MM}")
+	day := has("{YYYYMMDD}", "{DD}")
+
+	switch reset {
+	case ResetDaily:
+		if !year || !month || !day {
+			return errors.New("a daily reset needs {YYYYMMDD} or year, {MM} and {DD} tokens")
+		}
+	case ResetMonthly:
+		if !year || !month {
+			return errors.New("a monthly reset needs {YYYYMM} or year and {MM} tokens")
+		}
+	case ResetYearly:
+		if !year {
+			return errors.New("a yearly reset needs a {YYYY} or {YY} token")
+		}
+	case ResetNever:
+	default:
+		return fmt.Errorf("unknown reset %q", reset)
+	}
+	return nil
+}
+
+// Period returns the key of the sequence bucket a date falls in, so the
+// counter starts again from 1 whenever the period changes.
+func Period(reset string, at time.Time) string {
+	switch reset {
+	case ResetDaily:
+		return at.Format("20060102")
+	case ResetMonthly:
+		return at.Format("200601")
+	case ResetYearly:
+		return at.Format("2006")
+	}
+	return "-"
+}
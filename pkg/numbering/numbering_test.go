@@ -0,0 +1,95 @@
+package numbering
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	at := time.Date(2026, 3, 7, 23, 59, 0, 0, time.UTC)
+
+	tests := []struct {
+		pattern string
+		outlet  string
+		seq     int64
+		want    string
+	}{
+		{"INV/{OUTLET}/{YYYYMMDD}/{SEQ:4}", "MAIN", 1, "INV/MAIN/20260307/0001"},
+		{"RF-{YYYYMM}-{SEQ:6}", "MAIN", 42, "RF-202603-000042"},
+		{"{YY}{MM}{DD}{SEQ}", "", 7, "2603077"},
+		{"PO/{YYYY}/{SEQ:2}", "", 12345, "PO/2026/12345"},
+		{"{OUTLET}-{SEQ:3}-{SEQ}", "BR2", 5, "BR2-005-5"},
+		{"FIXED", "MAIN", 9, "FIXED"},
+	}
+
+	for _, tt := range tests {
+		if got := Format(tt.pattern, tt.outlet, at, tt.seq); got != tt.want {
+			t.Errorf("Format(%q, %q, %d) = %q, want %q", tt.pattern, tt.outlet, tt.seq, got, tt.want)
+		}
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	day := time.Date(2026, 12, 31, 23, 59, 0, 0, time.UTC)
+	next := day.Add(time.Minute)
+
+	tests := []struct {
+		reset     string
+		want      string
+		wantReset bool // the counter starts again the minute after
+	}{
+		{ResetDaily, "20261231", true},
+		{ResetMonthly, "202612", true},
+		{ResetYearly, "2026", true},
+		{ResetNever, "-", false},
+		{"", "-", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reset, func(t *testing.T) {
+			got := Period(tt.reset, day)
+			if got != tt.want {
+				t.Errorf("Period(%q) = %q, want %q", tt.reset, got, tt.want)
+			}
+			if reset := Period(tt.reset, next) != got; reset != tt.wantReset {
+				t.Errorf("Period(%q) changes at new year = %v, want %v", tt.reset, reset, tt.wantReset)
+			}
+		})
+	}
+
+	if Period(ResetMonthly, time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)) != Period(ResetMonthly, day) {
+		t.Error("monthly period changed within the month")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		pattern string
+		reset   string
+		wantErr bool
+	}{
+		{"INV/{OUTLET}/{YYYYMMDD}/{SEQ:4}", ResetDaily, false},
+		{"INV/{OUTLET}/{YY}{MM}{DD}/{SEQ}", ResetDaily, false},
+		{"PO/{OUTLET}/{YYYYMM}/{SEQ:4}", ResetMonthly, false},
+		{"PO/{OUTLET}/{YYYYMMDD}/{SEQ:4}", ResetMonthly, false},
+		{"PO/{OUTLET}/{YYYY}-{MM}/{SEQ:4}", ResetMonthly, false},
+		{"TRF/{OUTLET}/{YY}/{SEQ:6}", ResetYearly, false},
+		{"TRF/{OUTLET}/{SEQ:8}", ResetNever, false},
+		{"INV/{OUTLET}/{YYYYMMDD}", ResetDaily, true},
+		{"INV/{OUTLET}/{YYYYMMDD}/{SEQ:x}", ResetDaily, true},
+		{"INV/{YYYYMMDD}/{SEQ:4}", ResetDaily, true},
+		{"INV/{OUTLET}/{YYYYMM}/{SEQ:4}", ResetDaily, true},
+		{"INV/{OUTLET}/{MM}{DD}/{SEQ:4}", ResetDaily, true},
+		{"PO/{OUTLET}/{MM}/{SEQ:4}", ResetMonthly, true},
+		{"PO/{OUTLET}/{YYYY}/{SEQ:4}", ResetMonthly, true},
+		{"TRF/{OUTLET}/{SEQ:6}", ResetYearly, true},
+		{"TRF/{OUTLET}/{YYYY}/{SEQ:6}", "weekly", true},
+	}
+
+	for _, tt := range tests {
+		err := Validate(tt.pattern, tt.reset)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, want error %v", tt.pattern, tt.reset, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,465 @@
+package promotion
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	TypePercentage = "percentage"  // Value percent off
+	TypeFixed      = "fixed"       // Value off each unit, or off the cart
+	TypeBuyXGetY   = "buy_x_get_y" // buy BuyQuantity, get GetQuantity of the cheapest free
+	TypeBundle     = "bundle"      // BundleQuantity units for a total of Value
+
+	ScopeItem = "item"
+	ScopeCart = "cart"
+)
+
+// maxExclusive caps the exhaustive search over non-stackable promotions.
+// Beyond it every exclusive promotion is tried in priority order instead.
+const maxExclusive = 12
+
+// Promotion is the engine's view of a promotion. ProductIDs and CategoryIDs
+// restrict the products it applies to; when both are empty it applies to
+// every product. OutletID nil means every outlet.
+type Promotion struct {
+	ID             uint64
+	Code           string
+	Name           string
+	Type           string
+	Scope          string
+	Value          float64
+	BuyQuantity    int
+	GetQuantity    int
+	BundleQuantity int
+	MinSpend       float64
+	Stackable      bool
+	Priority       int
+	StartsAt       *time.Time
+	EndsAt         *time.Time
+	StartTime      string // daily window "15:04", may wrap past midnight
+	EndTime        string
+	OutletID       *uint64
+	ProductIDs     []uint64
+	CategoryIDs    []uint64
+}
+
+type Line struct {
+	ProductID  uint64
+	CategoryID *uint64
+	Quantity   int
+	UnitPrice  float64
+}
+
+type Cart struct {
+	Lines    []Line
+	OutletID *uint64
+	At       time.Time
+}
+
+type Applied struct {
+	PromotionID uint64
+	Code        string
+	Name        string
+	Discount    float64
+}
+
+type LineResult struct {
+	Discount float64
+	Applied  []Applied
+}
+
+// Result holds one LineResult per cart line, in cart order.
+type Result struct {
+	Lines    []LineResult
+	Discount float64
+}
+
+// Evaluate applies the promotions to the cart and returns the combination
+// with the largest discount. The stacking rules are:
+//
+//   - stackable promotions always apply, on top of anything else;
+//   - non-stackable item promotions never share a unit;
+//   - at most one non-stackable cart promotion applies.
+//
+// Item promotions run before cart promotions, and each discount is taken
+// from what is left of the unit price. The result only depends on the
+// inputs: ties go to the combination found first, with promotions ordered
+// by priority (highest first) and then by ID.
+func Evaluate(cart Cart, promotions []Promotion) Result {
+	var gross float64
+	for _, line := range cart.Lines {
+		gross += line.UnitPrice * float64(line.Quantity)
+	}
+
+	var exclusive, stackable []Promotion
+	for _, p := range promotions {
+		if !p.eligible(cart, gross) {
+			continue
+		}
+		if p.Stackable {
+			stackable = append(stackable, p)
+		} else {
+			exclusive = append(exclusive, p)
+		}
+	}
+	order(exclusive)
+	order(stackable)
+
+	best := apply(cart, nil, stackable)
+	if len(exclusive) > maxExclusive {
+		if result := apply(cart, exclusive, stackable); result.Discount > best.Discount {
+			best = result
+		}
+		return best
+	}
+
+	for mask := 1; mask < 1<<len(exclusive); mask++ {
+		var chosen []Promotion
+		carts := 0
+		for i, p := range exclusive {
+			if mask&(1<<i) == 0 {
+				continue
+			}
+			if p.Scope == ScopeCart {
+				carts++
+			}
+			chosen = append(chosen, p)
+		}
+		if carts > 1 {
+			continue
+		}
+
+		if result := apply(cart, chosen, stackable); result.Discount > best.Discount {
+			best = result
+		}
+	}
+
+	return best
+}
+
+func order(promotions []Promotion) {
+	sort.SliceStable(promotions, func(i, j int) bool {
+		if promotions[i].Priority != promotions[j].Priority {
+			return promotions[i].Priority > promotions[j].Priority
+		}
+		return promotions[i].ID < promotions[j].ID
+	})
+}
+
+func (p Promotion) eligible(cart Cart, gross float64) bool {
+	if p.StartsAt != nil && cart.At.Before(*p.StartsAt) {
+		return false
+	}
+	if p.EndsAt != nil && !cart.At.Before(*p.EndsAt) {
+		return false
+	}
+	if !p.withinDailyWindow(cart.At) {
+		return false
+	}
+	if p.OutletID != nil && (cart.OutletID == nil || *cart.OutletID != *p.OutletID) {
+		return false
+	}
+	if gross < p.MinSpend {
+		return false
+	}
+
+	switch p.Type {
+	case TypeBuyXGetY:
+		return p.BuyQuantity > 0 && p.GetQuantity > 0
+	case TypeBundle:
+		return p.BundleQuantity > 0
+	case TypePercentage, TypeFixed:
+		return p.Value > 0
+	}
+	return false
+}
+
+func (p Promotion) withinDailyWindow(at time.Time) bool {
+	if p.StartTime == "" || p.EndTime == "" {
+		return true
+	}
+	start, err1 := time.Parse("15:04", p.StartTime)
+	end, err2 := time.Parse("15:04", p.EndTime)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+
+	now := at.Hour()*60 + at.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	if from <= to {
+		return now >= from && now < to
+	}
+	// Window wraps past midnight, e.g. 22:00 - 02:00
+	return now >= from || now < to
+}
+
+func (p Promotion) matches(line Line) bool {
+	if len(p.ProductIDs) == 0 && len(p.CategoryIDs) == 0 {
+		return true
+	}
+	for _, id := range p.ProductIDs {
+		if id == line.ProductID {
+			return true
+		}
+	}
+	if line.CategoryID != nil {
+		for _, id := range p.CategoryIDs {
+			if id == *line.CategoryID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// group is a run of identical units of one cart line: the same price left
+// and the same claim. Promotions work on units so buy-X-get-Y and bundles
+// can mix products, but a line's units stay in one group until a promotion
+// treats some of them differently, so the work grows with the number of
+// lines and not with their quantities.
+type group struct {
+	line     int
+	quantity int
+	net      float64 // per unit
+	claimed  bool
+}
+
+type basket struct {
+	groups []*group
+}
+
+// split moves n units of g into a group of their own for the caller to
+// change, and returns it; all of g's units return g itself.
+func (b *basket) split(g *group, n int) *group {
+	if n >= g.quantity {
+		return g
+	}
+	part := *g
+	part.quantity = n
+	g.quantity -= n
+	b.groups = append(b.groups, &part)
+	return &part
+}
+
+// piece is n units of a group taken into a bundle.
+type piece struct {
+	group *group
+	n     int
+}
+
+type ledger struct {
+	lines [][]Applied
+}
+
+func (l *ledger) add(line int, p Promotion, discount float64) {
+	if discount <= 0 {
+		return
+	}
+	for i := range l.lines[line] {
+		if l.lines[line][i].PromotionID == p.ID {
+			l.lines[line][i].Discount += discount
+			return
+		}
+	}
+	l.lines[line] = append(l.lines[line], Applied{PromotionID: p.ID, Code: p.Code, Name: p.Name, Discount: discount})
+}
+
+func apply(cart Cart, exclusive, stackable []Promotion) Result {
+	b := &basket{}
+	for i, line := range cart.Lines {
+		if line.Quantity > 0 {
+			b.groups = append(b.groups, &group{line: i, quantity: line.Quantity, net: line.UnitPrice})
+		}
+	}
+	book := &ledger{lines: make([][]Applied, len(cart.Lines))}
+
+	var carts []Promotion
+	for _, p := range exclusive {
+		if p.Scope == ScopeCart {
+			carts = append(carts, p)
+			continue
+		}
+		applyItem(cart, p, b, book, true)
+	}
+	for _, p := range stackable {
+		if p.Scope == ScopeCart {
+			carts = append(carts, p)
+			continue
+		}
+		applyItem(cart, p, b, book, false)
+	}
+	for _, p := range carts {
+		applyCart(p, b, book)
+	}
+
+	result := Result{Lines: make([]LineResult, len(cart.Lines))}
+	for i, applied := range book.lines {
+		for j := range applied {
+			applied[j].Discount = round(applied[j].Discount)
+			result.Lines[i].Discount += applied[j].Discount
+		}
+		result.Lines[i].Applied = applied
+		result.Lines[i].Discount = round(result.Lines[i].Discount)
+		result.Discount += result.Lines[i].Discount
+	}
+	result.Discount = round(result.Discount)
+	return result
+}
+
+// applyItem applies an item promotion to the matching units. Exclusive
+// promotions skip units another exclusive promotion already took and claim
+// the units they discount.
+func applyItem(cart Cart, p Promotion, b *basket, book *ledger, exclusive bool) {
+	var matching []*group
+	for _, g := range b.groups {
+		if exclusive && g.claimed {
+			continue
+		}
+		if g.net > 0 && p.matches(cart.Lines[g.line]) {
+			matching = append(matching, g)
+		}
+	}
+
+	// take discounts every unit of g by up to discount
+	take := func(g *group, discount float64) {
+		if discount > g.net {
+			discount = g.net
+		}
+		if discount <= 0 {
+			return
+		}
+		g.net -= discount
+		if exclusive {
+			g.claimed = true
+		}
+		book.add(g.line, p, discount*float64(g.quantity))
+	}
+
+	switch p.Type {
+	case TypePercentage:
+		for _, g := range matching {
+			take(g, g.net*p.Value/100)
+		}
+	case TypeFixed:
+		for _, g := range matching {
+			take(g, p.Value)
+		}
+	case TypeBuyXGetY:
+		// Most expensive first, so every set gives away its cheapest units.
+		// Lined up that way, the units at positions BuyQuantity and on of
+		// every set of BuyQuantity+GetQuantity are free.
+		sortGroups(matching)
+		size := p.BuyQuantity + p.GetQuantity
+		units := 0
+		for _, g := range matching {
+			units += g.quantity
+		}
+		usable := units / size * size
+		free := func(n int) int {
+			return n/size*p.GetQuantity + max(0, n%size-p.BuyQuantity)
+		}
+
+		start := 0
+		for _, g := range matching {
+			end := min(start+g.quantity, usable)
+			if end <= start {
+				break
+			}
+			freeUnits := free(end) - free(start)
+			boughtUnits := end - start - freeUnits
+			start += g.quantity
+
+			if exclusive && boughtUnits > 0 {
+				b.split(g, boughtUnits).claimed = true
+			}
+			if freeUnits > 0 {
+				part := b.split(g, freeUnits)
+				take(part, part.net)
+			}
+		}
+	case TypeBundle:
+		// Bundles are filled most expensive first. Whole bundles inside one
+		// group are all alike and handled at once.
+		sortGroups(matching)
+		size := p.BundleQuantity
+		bundle := func(pieces []piece, total float64) {
+			if total <= p.Value {
+				return
+			}
+			for _, pc := range pieces {
+				part := b.split(pc.group, pc.n)
+				take(part, (total-p.Value)*part.net/total)
+				if exclusive {
+					part.claimed = true
+				}
+			}
+		}
+
+		var pieces []piece
+		var count int
+		var total float64
+		for _, g := range matching {
+			left := g.quantity
+			for left > 0 {
+				if count == 0 && left >= size {
+					n := left / size * size
+					bundle([]piece{{g, n}}, float64(size)*g.net)
+					left -= n
+					continue
+				}
+
+				n := min(left, size-count)
+				pieces = append(pieces, piece{g, n})
+				count += n
+				total += float64(n) * g.net
+				left -= n
+				if count == size {
+					bundle(pieces, total)
+					pieces, count, total = nil, 0, 0
+				}
+			}
+		}
+	}
+}
+
+// applyCart takes the cart discount off what is left of every unit and
+// spreads it across them in proportion to their remaining price.
+func applyCart(p Promotion, b *basket, book *ledger) {
+	var total float64
+	for _, g := range b.groups {
+		total += g.net * float64(g.quantity)
+	}
+	if total <= 0 {
+		return
+	}
+
+	discount := p.Value
+	if p.Type == TypePercentage {
+		discount = total * p.Value / 100
+	}
+	if discount > total {
+		discount = total
+	}
+
+	for _, g := range b.groups {
+		share := discount * g.net / total
+		g.net -= share
+		book.add(g.line, p, share*float64(g.quantity))
+	}
+}
+
+func sortGroups(groups []*group) {
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].net != groups[j].net {
+			return groups[i].net > groups[j].net
+		}
+		return groups[i].line < groups[j].line
+	})
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
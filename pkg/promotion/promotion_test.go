@@ -0,0 +1,262 @@
+package promotion
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluate(t *testing.T) {
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	outlet, other := uint64(1), uint64(2)
+
+	tests := []struct {
+		name       string
+		lines      []Line
+		outletID   *uint64
+		promotions []Promotion
+		want       float64
+		wantLines  []float64
+		wantIDs    []uint64 // promotions applied to the first line, in order
+	}{
+		{
+			name:       "percentage per item",
+			lines:      []Line{{ProductID: 1, Quantity: 2, UnitPrice: 10}},
+			promotions: []Promotion{{ID: 1, Type: TypePercentage, Scope: ScopeItem, Value: 10}},
+			want:       2,
+			wantLines:  []float64{2},
+		},
+		{
+			name:       "fixed capped at the unit price",
+			lines:      []Line{{ProductID: 1, Quantity: 3, UnitPrice: 2}},
+			promotions: []Promotion{{ID: 1, Type: TypeFixed, Scope: ScopeItem, Value: 3}},
+			want:       6,
+			wantLines:  []float64{6},
+		},
+		{
+			name: "buy 2 get 1 gives away within the most expensive set",
+			lines: []Line{
+				{ProductID: 1, Quantity: 3, UnitPrice: 10},
+				{ProductID: 2, Quantity: 1, UnitPrice: 4},
+			},
+			promotions: []Promotion{{ID: 1, Type: TypeBuyXGetY, Scope: ScopeItem, BuyQuantity: 2, GetQuantity: 1}},
+			want:       10,
+			wantLines:  []float64{10, 0},
+		},
+		{
+			name: "buy 1 get 1 across products frees the cheaper ones",
+			lines: []Line{
+				{ProductID: 1, Quantity: 2, UnitPrice: 10},
+				{ProductID: 2, Quantity: 2, UnitPrice: 6},
+			},
+			promotions: []Promotion{{ID: 1, Type: TypeBuyXGetY, Scope: ScopeItem, BuyQuantity: 1, GetQuantity: 1}},
+			want:       16,
+			wantLines:  []float64{10, 6},
+		},
+		{
+			name:       "bundle of one product",
+			lines:      []Line{{ProductID: 1, Quantity: 7, UnitPrice: 10}},
+			promotions: []Promotion{{ID: 1, Type: TypeBundle, Scope: ScopeItem, BundleQuantity: 3, Value: 25}},
+			want:       10,
+			wantLines:  []float64{10},
+		},
+		{
+			name: "bundle spread over mixed products",
+			lines: []Line{
+				{ProductID: 1, Quantity: 2, UnitPrice: 10},
+				{ProductID: 2, Quantity: 1, UnitPrice: 7},
+			},
+			promotions: []Promotion{{ID: 1, Type: TypeBundle, Scope: ScopeItem, BundleQuantity: 3, Value: 25}},
+			want:       2,
+			wantLines:  []float64{1.48, 0.52},
+		},
+		{
+			name: "bundle dearer than the units is skipped",
+			lines: []Line{
+				{ProductID: 1, Quantity: 3, UnitPrice: 5},
+			},
+			promotions: []Promotion{{ID: 1, Type: TypeBundle, Scope: ScopeItem, BundleQuantity: 3, Value: 20}},
+			want:       0,
+			wantLines:  []float64{0},
+		},
+		{
+			name:  "exclusive item promotions do not share a unit",
+			lines: []Line{{ProductID: 1, Quantity: 1, UnitPrice: 100}},
+			promotions: []Promotion{
+				{ID: 1, Type: TypePercentage, Scope: ScopeItem, Value: 10},
+				{ID: 2, Type: TypePercentage, Scope: ScopeItem, Value: 20},
+			},
+			want:      20,
+			wantLines: []float64{20},
+			wantIDs:   []uint64{2},
+		},
+		{
+			name: "exclusive item promotions split a line",
+			lines: []Line{
+				{ProductID: 1, Quantity: 3, UnitPrice: 10},
+			},
+			promotions: []Promotion{
+				{ID: 1, Type: TypeBundle, Scope: ScopeItem, BundleQuantity: 2, Value: 10},
+				{ID: 2, Type: TypePercentage, Scope: ScopeItem, Value: 40},
+			},
+			want:      14,
+			wantLines: []float64{14},
+			wantIDs:   []uint64{1, 2},
+		},
+		{
+			name:  "stackable promotions apply on top",
+			lines: []Line{{ProductID: 1, Quantity: 1, UnitPrice: 100}},
+			promotions: []Promotion{
+				{ID: 1, Type: TypePercentage, Scope: ScopeItem, Value: 10, Stackable: true},
+				{ID: 2, Type: TypePercentage, Scope: ScopeItem, Value: 20},
+			},
+			want:      28,
+			wantLines: []float64{28},
+			wantIDs:   []uint64{2, 1},
+		},
+		{
+			name:  "at most one exclusive cart promotion",
+			lines: []Line{{ProductID: 1, Quantity: 1, UnitPrice: 100}},
+			promotions: []Promotion{
+				{ID: 1, Type: TypeFixed, Scope: ScopeCart, Value: 5},
+				{ID: 2, Type: TypeFixed, Scope: ScopeCart, Value: 8},
+			},
+			want:      8,
+			wantLines: []float64{8},
+			wantIDs:   []uint64{2},
+		},
+		{
+			name:  "cart promotions come after item promotions",
+			lines: []Line{{ProductID: 1, Quantity: 1, UnitPrice: 100}},
+			promotions: []Promotion{
+				{ID: 1, Type: TypePercentage, Scope: ScopeCart, Value: 10},
+				{ID: 2, Type: TypePercentage, Scope: ScopeItem, Value: 50},
+			},
+			want:      55,
+			wantLines: []float64{55},
+			wantIDs:   []uint64{2, 1},
+		},
+		{
+			name: "cart discount spread by remaining price",
+			lines: []Line{
+				{ProductID: 1, Quantity: 1, UnitPrice: 30},
+				{ProductID: 2, Quantity: 2, UnitPrice: 5},
+			},
+			promotions: []Promotion{{ID: 1, Type: TypeFixed, Scope: ScopeCart, Value: 8}},
+			want:       8,
+			wantLines:  []float64{6, 2},
+		},
+		{
+			name:  "tie goes to the higher priority",
+			lines: []Line{{ProductID: 1, Quantity: 1, UnitPrice: 100}},
+			promotions: []Promotion{
+				{ID: 2, Type: TypePercentage, Scope: ScopeItem, Value: 10, Priority: 1},
+				{ID: 3, Type: TypePercentage, Scope: ScopeItem, Value: 10, Priority: 5},
+			},
+			want:      10,
+			wantLines: []float64{10},
+			wantIDs:   []uint64{3},
+		},
+		{
+			name:  "tie at equal priority goes to the lower ID",
+			lines: []Line{{ProductID: 1, Quantity: 1, UnitPrice: 100}},
+			promotions: []Promotion{
+				{ID: 7, Type: TypeFixed, Scope: ScopeItem, Value: 10},
+				{ID: 4, Type: TypeFixed, Scope: ScopeItem, Value: 10},
+			},
+			want:      10,
+			wantLines: []float64{10},
+			wantIDs:   []uint64{4},
+		},
+		{
+			name:  "product restriction",
+			lines: []Line{{ProductID: 1, Quantity: 1, UnitPrice: 10}, {ProductID: 2, Quantity: 1, UnitPrice: 10}},
+			promotions: []Promotion{
+				{ID: 1, Type: TypePercentage, Scope: ScopeItem, Value: 50, ProductIDs: []uint64{2}},
+			},
+			want:      5,
+			wantLines: []float64{0, 5},
+		},
+		{
+			name:       "other outlet",
+			lines:      []Line{{ProductID: 1, Quantity: 1, UnitPrice: 10}},
+			outletID:   &other,
+			promotions: []Promotion{{ID: 1, Type: TypePercentage, Scope: ScopeItem, Value: 50, OutletID: &outlet}},
+			want:       0,
+			wantLines:  []float64{0},
+		},
+		{
+			name:       "same outlet",
+			lines:      []Line{{ProductID: 1, Quantity: 1, UnitPrice: 10}},
+			outletID:   &outlet,
+			promotions: []Promotion{{ID: 1, Type: TypePercentage, Scope: ScopeItem, Value: 50, OutletID: &outlet}},
+			want:       5,
+			wantLines:  []float64{5},
+		},
+		{
+			name:       "below minimum spend",
+			lines:      []Line{{ProductID: 1, Quantity: 1, UnitPrice: 10}},
+			promotions: []Promotion{{ID: 1, Type: TypeFixed, Scope: ScopeCart, Value: 5, MinSpend: 20}},
+			want:       0,
+			wantLines:  []float64{0},
+		},
+		{
+			name:       "outside the daily window",
+			lines:      []Line{{ProductID: 1, Quantity: 1, UnitPrice: 10}},
+			promotions: []Promotion{{ID: 1, Type: TypeFixed, Scope: ScopeCart, Value: 5, StartTime: "22:00", EndTime: "02:00"}},
+			want:       0,
+			wantLines:  []float64{0},
+		},
+		{
+			name:       "large quantities",
+			lines:      []Line{{ProductID: 1, Quantity: 1_000_001, UnitPrice: 1}},
+			promotions: []Promotion{{ID: 1, Type: TypeBuyXGetY, Scope: ScopeItem, BuyQuantity: 1, GetQuantity: 1}},
+			want:       500_000,
+			wantLines:  []float64{500_000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Evaluate(Cart{Lines: tt.lines, OutletID: tt.outletID, At: at}, tt.promotions)
+
+			if result.Discount != tt.want {
+				t.Errorf("discount = %v, want %v", result.Discount, tt.want)
+			}
+			for i, want := range tt.wantLines {
+				if got := result.Lines[i].Discount; got != want {
+					t.Errorf("line %d discount = %v, want %v", i, got, want)
+				}
+			}
+			if tt.wantIDs != nil {
+				var ids []uint64
+				for _, applied := range result.Lines[0].Applied {
+					ids = append(ids, applied.PromotionID)
+				}
+				if len(ids) != len(tt.wantIDs) {
+					t.Fatalf("applied = %v, want %v", ids, tt.wantIDs)
+				}
+				for i := range ids {
+					if ids[i] != tt.wantIDs[i] {
+						t.Fatalf("applied = %v, want %v", ids, tt.wantIDs)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEvaluateManyExclusive(t *testing.T) {
+	var promotions []Promotion
+	for i := 1; i <= maxExclusive; i++ {
+		promotions = append(promotions, Promotion{ID: uint64(i), Type: TypeBundle, Scope: ScopeItem, BundleQuantity: i + 1, Value: float64(i)})
+	}
+	cart := Cart{Lines: []Line{
+		{ProductID: 1, Quantity: 100_000, UnitPrice: 3},
+		{ProductID: 2, Quantity: 50_000, UnitPrice: 2},
+	}}
+
+	result := Evaluate(cart, promotions)
+	if result.Discount <= 0 {
+		t.Fatalf("discount = %v, want a discount", result.Discount)
+	}
+}
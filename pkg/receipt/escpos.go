@@ -0,0 +1,51 @@
+package receipt
+
+import (
+	"bytes"
+	"strings"
+)
+
+// ESC/POS command bytes
+var (
+	escInit        = []byte{0x1b, 0x40}
+	escAlignLeft   = []byte{0x1b, 0x61, 0x00}
+	escAlignCenter = []byte{0x1b, 0x61, 0x01}
+	escBoldOn      = []byte{0x1b, 0x45, 0x01}
+	escBoldOff     = []byte{0x1b, 0x45, 0x00}
+	escFeed        = []byte{0x1b, 0x64, 0x04}
+	gsPartialCut   = []byte{0x1d, 0x56, 0x42, 0x00}
+)
+
+// ESCPOS renders the receipt as a byte stream for ESC/POS thermal
+// printers. Alignment is left to the printer, so text is not padded.
+func ESCPOS(r Receipt, t Template) []byte {
+	width := t.Columns()
+
+	var b bytes.Buffer
+	b.Write(escInit)
+	for _, row := range layout(r, t) {
+		if row.align == alignCenter {
+			b.Write(escAlignCenter)
+		} else {
+			b.Write(escAlignLeft)
+		}
+		if row.bold {
+			b.Write(escBoldOn)
+		}
+
+		if row.rule {
+			b.WriteString(strings.Repeat("-", width))
+		} else {
+			b.WriteString(row.text)
+		}
+		b.WriteByte('\n')
+
+		if row.bold {
+			b.Write(escBoldOff)
+		}
+	}
+	b.Write(escAlignLeft)
+	b.Write(escFeed)
+	b.Write(gsPartialCut)
+	return b.Bytes()
+}
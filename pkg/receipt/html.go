@@ -0,0 +1,104 @@
+package receipt
+
+import (
+	"html/template"
+	"strings"
+)
+
+var htmlTemplate = template.Must(template.New("receipt").Funcs(template.FuncMap{
+	"money": Money,
+	"lines": func(text string) []string {
+		var lines []string
+		for _, line := range strings.Split(text, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		return lines
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Receipt {{.Receipt.Number}}</title>
+<style>
+body { font-family: monospace; max-width: 420px; margin: 0 auto; }
+.center { text-align: center; }
+table { width: 100%; border-collapse: collapse; }
+td.amount { text-align: right; }
+hr { border: none; border-top: 1px dashed #000; }
+</style>
+</head>
+<body>
+<div class="center">
+{{- if .Template.StoreName}}<h3>{{.Template.StoreName}}</h3>{{end}}
+{{- if .Template.Address}}<div>{{.Template.Address}}</div>{{end}}
+{{- if .Template.Phone}}<div>{{.Template.Phone}}</div>{{end}}
+{{- range lines .Template.Header}}<div>{{.}}</div>{{end}}
+</div>
+<hr>
+{{- with .Receipt.Banner}}
+<div class="center"><strong>{{.}}</strong></div>
+<hr>
+{{- end}}
+<table>
+<tr><td>No</td><td>{{.Receipt.Number}}</td></tr>
+<tr><td>Date</td><td>{{.Receipt.Date.Format "02-01-2006 15:04"}}</td></tr>
+<tr><td>Cashier</td><td>{{.Receipt.Cashier}}</td></tr>
+</table>
+<hr>
+<table>
+{{- range .Receipt.Lines}}
+<tr><td colspan="2">{{.Name}}</td></tr>
+<tr><td>{{.Quantity}} x {{money .UnitPrice}}</td><td class="amount">{{money .Subtotal}}</td></tr>
+{{- if gt .Discount 0.0}}
+<tr><td>&nbsp;&nbsp;Discount</td><td class="amount">-{{money .Discount}}</td></tr>
+{{- end}}
+{{- end}}
+</table>
+<hr>
+<table>
+<tr><td>Subtotal</td><td class="amount">{{money .Receipt.Subtotal}}</td></tr>
+{{- if gt .Receipt.Discount 0.0}}
+<tr><td>Discount</td><td class="amount">-{{money .Receipt.Discount}}</td></tr>
+{{- end}}
+{{- range .Receipt.Taxes}}
+<tr><td>{{$.Receipt.TaxLabel .}}</td><td class="amount">{{money .Amount}}</td></tr>
+{{- end}}
+<tr><td><strong>TOTAL</strong></td><td class="amount"><strong>{{money .Receipt.Total}}</strong></td></tr>
+{{- range .Receipt.Payments}}
+<tr><td>{{.Name}}</td><td class="amount">{{money .Amount}}</td></tr>
+{{- end}}
+<tr><td>Change</td><td class="amount">{{money .Receipt.Change}}</td></tr>
+</table>
+{{- if .Receipt.Voids}}
+<hr>
+<table>
+<tr><td colspan="2"><strong>VOIDED</strong></td></tr>
+{{- range .Receipt.Voids}}
+<tr><td colspan="2">{{.Name}}</td></tr>
+<tr><td>&nbsp;&nbsp;{{.Quantity}} x</td><td class="amount">-{{money .Amount}}</td></tr>
+{{- end}}
+<tr><td><strong>Total voided</strong></td><td class="amount"><strong>-{{money .Receipt.VoidedTotal}}</strong></td></tr>
+</table>
+{{- end}}
+{{- with lines .Template.Footer}}
+<hr>
+<div class="center">{{range .}}<div>{{.}}</div>{{end}}</div>
+{{- end}}
+</body>
+</html>
+`))
+
+// HTML renders the receipt as a standalone HTML page, e.g. for email.
+func HTML(r Receipt, t Template) (string, error) {
+	var b strings.Builder
+	err := htmlTemplate.Execute(&b, struct {
+		Receipt  Receipt
+		Template Template
+	}{r, t})
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,300 @@
+package receipt
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatESCPOS = "escpos"
+	FormatText   = "text"
+	FormatHTML   = "html"
+)
+
+var ErrUnknownFormat = errors.New("unknown receipt format")
+
+// Template holds the per-store parts printed around every receipt.
+// Header and Footer may contain several lines separated by "\n".
+type Template struct {
+	StoreName  string
+	Address    string
+	Phone      string
+	Header     string
+	Footer     string
+	PaperWidth int // paper width in mm, 58 or 80
+}
+
+type Line struct {
+	Name      string
+	Quantity  int
+	UnitPrice float64
+	Subtotal  float64
+	Discount  float64
+}
+
+type Tax struct {
+	Name   string
+	Rate   float64 // percent
+	Amount float64
+}
+
+type Payment struct {
+	Name   string
+	Amount float64
+}
+
+// VoidLine is a line, or part of one, cancelled after the sale.
+type VoidLine struct {
+	Name     string
+	Quantity int
+	Amount   float64
+}
+
+// Receipt is the printable view of a completed sale. A sale voided in full
+// or in part keeps its original lines and totals and lists the voided lines
+// below them.
+type Receipt struct {
+	Number      string
+	Date        time.Time
+	Cashier     string
+	Lines       []Line
+	Subtotal    float64
+	Discount    float64
+	Taxes       []Tax
+	TaxIncluded bool // taxes are already part of Subtotal
+	Total       float64
+	Paid        float64
+	Change      float64
+	Payments    []Payment
+	Voided      bool // the whole sale is voided
+	Voids       []VoidLine
+}
+
+// Banner returns the notice printed above a voided sale, or "" when nothing
+// of it is voided.
+func (r Receipt) Banner() string {
+	switch {
+	case r.Voided:
+		return "*** VOID ***"
+	case len(r.Voids) > 0:
+		return "*** PARTIALLY VOIDED ***"
+	}
+	return ""
+}
+
+// VoidedTotal returns the amount of all voided lines.
+func (r Receipt) VoidedTotal() float64 {
+	var total float64
+	for _, line := range r.Voids {
+		total += line.Amount
+	}
+	return math.Round(total*100) / 100
+}
+
+// TaxLabel returns the printed label of a tax line, e.g. "PPN 11%" or
+// "Incl. PPN 11%" when prices include tax.
+func (r Receipt) TaxLabel(t Tax) string {
+	label := t.Name + " " + strings.Replace(strconv.FormatFloat(t.Rate, 'f', -1, 64), ".", ",", 1) + "%"
+	if r.TaxIncluded {
+		return "Incl. " + label
+	}
+	return label
+}
+
+// Render renders the receipt in the given format and returns the content
+// together with its MIME type.
+func Render(format string, r Receipt, t Template) ([]byte, string, error) {
+	switch format {
+	case FormatESCPOS:
+		return ESCPOS(r, t), "application/octet-stream", nil
+	case FormatText, "":
+		return []byte(Text(r, t)), "text/plain; charset=utf-8", nil
+	case FormatHTML:
+		html, err := HTML(r, t)
+		if err != nil {
+			return nil, "", err
+		}
+		return []byte(html), "text/html; charset=utf-8", nil
+	}
+	return nil, "", fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+}
+
+// Columns returns the number of characters per line for the paper width
+// using the printer's default font.
+func (t Template) Columns() int {
+	if t.PaperWidth >= 80 {
+		return 48
+	}
+	return 32
+}
+
+const (
+	alignLeft = iota
+	alignCenter
+)
+
+// row is one printed line of the receipt; both the text and the ESC/POS
+// renderer are built from the same rows so they never drift apart.
+type row struct {
+	text  string
+	align int
+	bold  bool
+	rule  bool
+}
+
+func layout(r Receipt, t Template) []row {
+	width := t.Columns()
+	var rows []row
+
+	if t.StoreName != "" {
+		rows = append(rows, row{text: t.StoreName, align: alignCenter, bold: true})
+	}
+	for _, text := range []string{t.Address, t.Phone} {
+		if text != "" {
+			rows = append(rows, centered(text, width)...)
+		}
+	}
+	rows = append(rows, centered(t.Header, width)...)
+	rows = append(rows, row{rule: true})
+
+	if banner := r.Banner(); banner != "" {
+		rows = append(rows, row{text: banner, align: alignCenter, bold: true}, row{rule: true})
+	}
+
+	rows = append(rows,
+		row{text: "No      : " + r.Number},
+		row{text: "Date    : " + r.Date.Format("02-01-2006 15:04")},
+		row{text: "Cashier : " + r.Cashier},
+		row{rule: true},
+	)
+
+	for _, line := range r.Lines {
+		for _, text := range wrap(line.Name, width) {
+			rows = append(rows, row{text: text})
+		}
+		qty := fmt.Sprintf("  %d x %s", line.Quantity, Money(line.UnitPrice))
+		rows = append(rows, row{text: justify(qty, Money(line.Subtotal), width)})
+		if line.Discount > 0 {
+			rows = append(rows, row{text: justify("  Discount", Money(-line.Discount), width)})
+		}
+	}
+	rows = append(rows, row{rule: true})
+
+	rows = append(rows, row{text: justify("Subtotal", Money(r.Subtotal), width)})
+	if r.Discount > 0 {
+		rows = append(rows, row{text: justify("Discount", Money(-r.Discount), width)})
+	}
+	for _, tax := range r.Taxes {
+		rows = append(rows, row{text: justify(r.TaxLabel(tax), Money(tax.Amount), width)})
+	}
+	rows = append(rows, row{text: justify("TOTAL", Money(r.Total), width), bold: true})
+	for _, payment := range r.Payments {
+		rows = append(rows, row{text: justify(payment.Name, Money(payment.Amount), width)})
+	}
+	rows = append(rows, row{text: justify("Change", Money(r.Change), width)})
+
+	if len(r.Voids) > 0 {
+		rows = append(rows, row{rule: true}, row{text: "VOIDED", bold: true})
+		for _, line := range r.Voids {
+			for _, text := range wrap(line.Name, width) {
+				rows = append(rows, row{text: text})
+			}
+			qty := fmt.Sprintf("  %d x", line.Quantity)
+			rows = append(rows, row{text: justify(qty, Money(-line.Amount), width)})
+		}
+		rows = append(rows, row{text: justify("Total voided", Money(-r.VoidedTotal()), width), bold: true})
+	}
+
+	if footer := centered(t.Footer, width); len(footer) > 0 {
+		rows = append(rows, row{rule: true})
+		rows = append(rows, footer...)
+	}
+
+	return rows
+}
+
+// Money formats an amount the Indonesian way: 15000.5 -> "15.000,50".
+func Money(amount float64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	cents := int64(math.Round(amount * 100))
+	whole := fmt.Sprintf("%d", cents/100)
+
+	var b strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(digit)
+	}
+
+	if fraction := cents % 100; fraction != 0 {
+		return fmt.Sprintf("%s%s,%02d", sign, b.String(), fraction)
+	}
+	return sign + b.String()
+}
+
+func centered(text string, width int) []row {
+	var rows []row
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		for _, part := range wrap(line, width) {
+			rows = append(rows, row{text: part, align: alignCenter})
+		}
+	}
+	return rows
+}
+
+// justify puts left at the start and right at the end of a line.
+func justify(left, right string, width int) string {
+	space := width - len([]rune(left)) - len([]rune(right))
+	if space < 1 {
+		space = 1
+	}
+	return left + strings.Repeat(" ", space) + right
+}
+
+// wrap breaks text into lines no longer than width, on word boundaries
+// where possible.
+func wrap(text string, width int) []string {
+	var lines []string
+	var current []rune
+
+	for _, word := range strings.Fields(text) {
+		w := []rune(word)
+		for len(w) > width {
+			if len(current) > 0 {
+				lines = append(lines, string(current))
+				current = nil
+			}
+			lines = append(lines, string(w[:width]))
+			w = w[width:]
+		}
+
+		switch {
+		case len(current) == 0:
+			current = w
+		case len(current)+1+len(w) <= width:
+			current = append(append(current, ' '), w...)
+		default:
+			lines = append(lines, string(current))
+			current = w
+		}
+	}
+
+	if len(current) > 0 {
+		lines = append(lines, string(current))
+	}
+	return lines
+}
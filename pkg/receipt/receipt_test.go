@@ -0,0 +1,329 @@
+package receipt
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleReceipt() Receipt {
+	return Receipt{
+		Number:  "INV-1",
+		Date:    time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC),
+		Cashier: "Ani",
+		Lines: []Line{
+			{Name: "Kopi Susu Gula Aren Extra Large Dengan Boba", Quantity: 2, UnitPrice: 15000, Subtotal: 30000, Discount: 3000},
+		},
+		Subtotal: 30000,
+		Discount: 3000,
+		Taxes:    []Tax{{Name: "PPN", Rate: 11, Amount: 2970}},
+		Total:    29970,
+		Paid:     50000,
+		Change:   20030,
+		Payments: []Payment{{Name: "Cash", Amount: 50000}},
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{name: "fits", text: "Kopi Susu", width: 10, want: []string{"Kopi Susu"}},
+		{name: "word boundary", text: "Kopi Susu Gula Aren", width: 10, want: []string{"Kopi Susu", "Gula Aren"}},
+		{name: "long word is cut", text: "Supercalifragilistic", width: 8, want: []string{"Supercal", "ifragili", "stic"}},
+		{name: "extra spaces", text: "  Teh   Manis ", width: 32, want: []string{"Teh Manis"}},
+		{name: "multibyte", text: "Café Crème Brûlée", width: 10, want: []string{"Café Crème", "Brûlée"}},
+		{name: "empty", text: "", width: 10, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrap(tt.text, tt.width); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrap(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{15000, "15.000"},
+		{1234567.5, "1.234.567,50"},
+		{0.005, "0,01"},
+		{-3000, "-3.000"},
+	}
+
+	for _, tt := range tests {
+		if got := Money(tt.amount); got != tt.want {
+			t.Errorf("Money(%v) = %q, want %q", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	voided := sampleReceipt()
+	voided.Voids = []VoidLine{{Name: "Kopi Susu", Quantity: 1, Amount: 14985}}
+
+	included := sampleReceipt()
+	included.TaxIncluded = true
+	included.Taxes = []Tax{{Name: "PPN", Rate: 11, Amount: 2675.68}, {Name: "PB1", Rate: 2.5, Amount: 500}}
+
+	tests := []struct {
+		name     string
+		receipt  Receipt
+		template Template
+		want     string
+	}{
+		{
+			name:     "58mm with wrapped line and tax",
+			receipt:  sampleReceipt(),
+			template: Template{StoreName: "Toko", Address: "Jl. Merdeka 1", Header: "Buka\n\n24 jam", Footer: "Terima kasih"},
+			want: `              Toko
+         Jl. Merdeka 1
+              Buka
+             24 jam
+--------------------------------
+No      : INV-1
+Date    : 18-10-2026 09:30
+Cashier : Ani
+--------------------------------
+Kopi Susu Gula Aren Extra Large
+Dengan Boba
+  2 x 15.000              30.000
+  Discount                -3.000
+--------------------------------
+Subtotal                  30.000
+Discount                  -3.000
+PPN 11%                    2.970
+TOTAL                     29.970
+Cash                      50.000
+Change                    20.030
+--------------------------------
+          Terima kasih
+`,
+		},
+		{
+			name:     "80mm keeps the name on one line",
+			receipt:  sampleReceipt(),
+			template: Template{PaperWidth: 80},
+			want: `------------------------------------------------
+No      : INV-1
+Date    : 18-10-2026 09:30
+Cashier : Ani
+------------------------------------------------
+Kopi Susu Gula Aren Extra Large Dengan Boba
+  2 x 15.000                              30.000
+  Discount                                -3.000
+------------------------------------------------
+Subtotal                                  30.000
+Discount                                  -3.000
+PPN 11%                                    2.970
+TOTAL                                     29.970
+Cash                                      50.000
+Change                                    20.030
+`,
+		},
+		{
+			name:    "partly voided",
+			receipt: voided,
+			want: `--------------------------------
+    *** PARTIALLY VOIDED ***
+--------------------------------
+No      : INV-1
+Date    : 18-10-2026 09:30
+Cashier : Ani
+--------------------------------
+Kopi Susu Gula Aren Extra Large
+Dengan Boba
+  2 x 15.000              30.000
+  Discount                -3.000
+--------------------------------
+Subtotal                  30.000
+Discount                  -3.000
+PPN 11%                    2.970
+TOTAL                     29.970
+Cash                      50.000
+Change                    20.030
+--------------------------------
+VOIDED
+Kopi Susu
+  1 x                    -14.985
+Total voided             -14.985
+`,
+		},
+		{
+			name:    "tax included breakdown",
+			receipt: included,
+			want: `--------------------------------
+No      : INV-1
+Date    : 18-10-2026 09:30
+Cashier : Ani
+--------------------------------
+Kopi Susu Gula Aren Extra Large
+Dengan Boba
+  2 x 15.000              30.000
+  Discount                -3.000
+--------------------------------
+Subtotal                  30.000
+Discount                  -3.000
+Incl. PPN 11%           2.675,68
+Incl. PB1 2,5%               500
+TOTAL                     29.970
+Cash                      50.000
+Change                    20.030
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Text(tt.receipt, tt.template); got != tt.want {
+				t.Errorf("Text() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestESCPOS(t *testing.T) {
+	voided := sampleReceipt()
+	voided.Voided = true
+	voided.Voids = []VoidLine{{Name: "Kopi Susu Gula Aren Extra Large Dengan Boba", Quantity: 2, Amount: 29970}}
+
+	tests := []struct {
+		name     string
+		receipt  Receipt
+		template Template
+		contains [][]byte
+		excludes [][]byte
+	}{
+		{
+			name:     "store name and total are bold",
+			receipt:  sampleReceipt(),
+			template: Template{StoreName: "Toko"},
+			contains: [][]byte{
+				concat(escAlignCenter, escBoldOn, []byte("Toko\n"), escBoldOff),
+				concat(escAlignLeft, escBoldOn, []byte("TOTAL                     29.970\n"), escBoldOff),
+				concat(escAlignLeft, []byte("PPN 11%                    2.970\n")),
+			},
+			excludes: [][]byte{[]byte("VOID")},
+		},
+		{
+			name:    "long names wrap to the paper width",
+			receipt: sampleReceipt(),
+			contains: [][]byte{
+				[]byte("Kopi Susu Gula Aren Extra Large\n"),
+				[]byte("Dengan Boba\n"),
+			},
+		},
+		{
+			name:    "voided sale",
+			receipt: voided,
+			contains: [][]byte{
+				concat(escAlignCenter, escBoldOn, []byte("*** VOID ***\n"), escBoldOff),
+				concat(escAlignLeft, escBoldOn, []byte("Total voided             -29.970\n"), escBoldOff),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ESCPOS(tt.receipt, tt.template)
+
+			if !bytes.HasPrefix(got, escInit) {
+				t.Errorf("output does not start with ESC @")
+			}
+			if !bytes.HasSuffix(got, concat(escAlignLeft, escFeed, gsPartialCut)) {
+				t.Errorf("output does not end with feed and cut")
+			}
+			for _, want := range tt.contains {
+				if !bytes.Contains(got, want) {
+					t.Errorf("output does not contain %q", want)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if bytes.Contains(got, unwanted) {
+					t.Errorf("output contains %q", unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestHTML(t *testing.T) {
+	voided := sampleReceipt()
+	voided.Voids = []VoidLine{{Name: "Kopi Susu", Quantity: 1, Amount: 14985}}
+
+	tests := []struct {
+		name     string
+		receipt  Receipt
+		template Template
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "totals and tax",
+			receipt:  sampleReceipt(),
+			template: Template{StoreName: "Toko <Satu>", Footer: "Terima kasih"},
+			contains: []string{
+				"<h3>Toko &lt;Satu&gt;</h3>",
+				"<tr><td colspan=\"2\">Kopi Susu Gula Aren Extra Large Dengan Boba</td></tr>",
+				"<tr><td>2 x 15.000</td><td class=\"amount\">30.000</td></tr>",
+				"<tr><td>Discount</td><td class=\"amount\">-3.000</td></tr>",
+				"<tr><td>PPN 11%</td><td class=\"amount\">2.970</td></tr>",
+				"<strong>29.970</strong>",
+				"<tr><td>Change</td><td class=\"amount\">20.030</td></tr>",
+				"<div>Terima kasih</div>",
+			},
+			excludes: []string{"VOID"},
+		},
+		{
+			name:    "partly voided",
+			receipt: voided,
+			contains: []string{
+				"<strong>*** PARTIALLY VOIDED ***</strong>",
+				"<tr><td>&nbsp;&nbsp;1 x</td><td class=\"amount\">-14.985</td></tr>",
+				"<strong>-14.985</strong>",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := HTML(tt.receipt, tt.template)
+			if err != nil {
+				t.Fatalf("HTML() error = %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("output does not contain %q", want)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("output contains %q", unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	if _, _, err := Render("pdf", sampleReceipt(), Template{}); !errors.Is(err, ErrUnknownFormat) {
+		t.Fatalf("Render(pdf) error = %v, want ErrUnknownFormat", err)
+	}
+}
+
+func concat(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
@@ -0,0 +1,27 @@
+package receipt
+
+import (
+	"strings"
+)
+
+// Text renders the receipt as fixed-width plain text.
+func Text(r Receipt, t Template) string {
+	width := t.Columns()
+
+	var b strings.Builder
+	for _, row := range layout(r, t) {
+		switch {
+		case row.rule:
+			b.WriteString(strings.Repeat("-", width))
+		case row.align == alignCenter:
+			if pad := (width - len([]rune(row.text))) / 2; pad > 0 {
+				b.WriteString(strings.Repeat(" ", pad))
+			}
+			b.WriteString(row.text)
+		default:
+			b.WriteString(row.text)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
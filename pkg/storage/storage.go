@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned for a key nothing is stored under.
+var ErrNotFound = errors.New("file not found")
+
+// Storage keeps files under slash-separated keys such as
+// "products/12/3f2a9c.jpg". Put replaces whatever was stored under the key
+// before; Delete of a missing key is not an error.
+type Storage interface {
+	Put(key string, r io.Reader) error
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
@@ -0,0 +1,23 @@
+package tax
+
+import "math"
+
+// Split returns the taxable base and the tax of amount at rate percent.
+// With inclusive pricing the tax is already part of amount, otherwise it
+// comes on top of it. Both values are rounded to cents.
+func Split(amount, rate float64, inclusive bool) (base, tax float64) {
+	if rate <= 0 {
+		return round(amount), 0
+	}
+
+	if inclusive {
+		base = round(amount * 100 / (100 + rate))
+		return base, round(amount - base)
+	}
+
+	return round(amount), round(amount * rate / 100)
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
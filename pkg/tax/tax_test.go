@@ -0,0 +1,36 @@
+package tax
+
+import "testing"
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    float64
+		rate      float64
+		inclusive bool
+		wantBase  float64
+		wantTax   float64
+	}{
+		{"exclusive", 100, 11, false, 100, 11},
+		{"inclusive", 111, 11, true, 100, 11},
+		{"inclusive base rounded, tax takes the rest", 10, 11, true, 9.01, 0.99},
+		{"inclusive large amount", 50000, 11, true, 45045.05, 4954.95},
+		{"exclusive tax rounded up", 9.99, 10, false, 9.99, 1},
+		{"exclusive half cent rounds away from zero", 1.25, 10, false, 1.25, 0.13},
+		{"no rate", 12.5, 0, true, 12.5, 0},
+		{"negative rate", 12.5, -5, false, 12.5, 0},
+		{"zero amount", 0, 11, true, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, tax := Split(tt.amount, tt.rate, tt.inclusive)
+			if base != tt.wantBase || tax != tt.wantTax {
+				t.Errorf("Split(%v, %v, %v) = %v, %v, want %v, %v", tt.amount, tt.rate, tt.inclusive, base, tax, tt.wantBase, tt.wantTax)
+			}
+			if tt.inclusive && base+tax != tt.amount {
+				t.Errorf("base %v + tax %v != amount %v", base, tax, tt.amount)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"encoding/json"
+	"math"
 	"strconv"
 )
 
@@ -25,6 +26,11 @@ func UintToStr(num uint) string {
 	return strconv.FormatUint(uint64(num), 10)
 }
 
+// RoundMoney rounds an amount to two decimal places.
+func RoundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
 func CheckIfJSON(input string) interface{} {
 	// Try to unmarshal as generic JSON (map or slice)
 	var js interface{}
@@ -134,3 +134,13 @@ func JwtAuthInfo(c *gin.Context) (interface{}, error) {
 
 	return CheckIfJSON(decryptedData), nil
 }
+
+// AuthUserID returns the user ID stored in the context by AuthMiddleware.
+func AuthUserID(c *gin.Context) (uint, error) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return 0, appErr.Get(appErr.ErrUnauthorized, nil)
+	}
+
+	return StrToUint(fmt.Sprintf("%v", userID))
+}
@@ -0,0 +1,347 @@
+// Package xlsx reads and writes the single-sheet Office Open XML
+// spreadsheets used for importing and exporting data. It covers what such
+// files need: strings, numbers and booleans, no styles or formulas.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Write writes rows as the only sheet of a workbook. Cells may be strings,
+// integers, floats or booleans; strings are always kept as text, so codes
+// with leading zeros survive a round trip through a spreadsheet program.
+func Write(w io.Writer, sheet string, rows [][]any) error {
+	zw := zip.NewWriter(w)
+
+	files := []struct{ name, body string }{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", fmt.Sprintf(workbook, escape(sheet))},
+		{"xl/_rels/workbook.xml.rels", workbookRels},
+	}
+	for _, file := range files {
+		if err := writeFile(zw, file.name, file.body); err != nil {
+			return err
+		}
+	}
+
+	fw, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for i, row := range rows {
+		fmt.Fprintf(&b, `<row r="%d">`, i+1)
+		for j, value := range row {
+			ref := columnName(j) + strconv.Itoa(i+1)
+			switch v := value.(type) {
+			case nil:
+			case string:
+				if v != "" {
+					fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escape(v))
+				}
+			case bool:
+				bit := 0
+				if v {
+					bit = 1
+				}
+				fmt.Fprintf(&b, `<c r="%s" t="b"><v>%d</v></c>`, ref, bit)
+			case int:
+				fmt.Fprintf(&b, `<c r="%s"><v>%d</v></c>`, ref, v)
+			case int64:
+				fmt.Fprintf(&b, `<c r="%s"><v>%d</v></c>`, ref, v)
+			case uint64:
+				fmt.Fprintf(&b, `<c r="%s"><v>%d</v></c>`, ref, v)
+			case float64:
+				fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'f', -1, 64))
+			default:
+				return fmt.Errorf("xlsx: cannot write %T", value)
+			}
+		}
+		b.WriteString(`</row>`)
+		if b.Len() > 1<<16 {
+			if _, err := io.WriteString(fw, b.String()); err != nil {
+				return err
+			}
+			b.Reset()
+		}
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	if _, err := io.WriteString(fw, b.String()); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// maxPartSize bounds each part of a workbook once decompressed, so a small
+// file cannot unpack into gigabytes.
+const maxPartSize = 64 << 20
+
+// Read returns the cells of the first sheet of a workbook as text, one
+// slice per row with gaps left empty. Numbers come back as Go formats them,
+// without exponents for whole numbers such as barcodes; booleans as "1" or
+// "0". A sheet with rows past maxRows, a first row wider than maxColumns
+// or a cell to the right of the first row is refused.
+func Read(r io.ReaderAt, size int64, maxRows, maxColumns int) ([][]string, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("xlsx: not a spreadsheet: %w", err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, file := range zr.File {
+		files[file.Name] = file
+	}
+
+	sheet, err := firstSheet(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared []string
+	if file, ok := files["xl/sharedStrings.xml"]; ok {
+		var sst struct {
+			Items []richText `xml:"si"`
+		}
+		if err := decode(file, &sst); err != nil {
+			return nil, err
+		}
+		for _, item := range sst.Items {
+			shared = append(shared, item.String())
+		}
+	}
+
+	file, ok := files[sheet]
+	if !ok {
+		return nil, fmt.Errorf("xlsx: sheet %s is missing", sheet)
+	}
+	var ws struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				R      string   `xml:"r,attr"`
+				T      string   `xml:"t,attr"`
+				V      string   `xml:"v"`
+				Inline richText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decode(file, &ws); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	width := maxColumns
+	for _, row := range ws.Rows {
+		index := len(rows)
+		if row.R > 0 {
+			index = row.R - 1
+		}
+		if index >= maxRows {
+			return nil, fmt.Errorf("xlsx: sheet has more than %d rows", maxRows)
+		}
+		for len(rows) <= index {
+			rows = append(rows, nil)
+		}
+
+		var cells []string
+		for _, cell := range row.Cells {
+			column := len(cells)
+			if cell.R != "" {
+				if column, err = columnIndex(cell.R); err != nil {
+					return nil, err
+				}
+			}
+			if column >= width {
+				if index == 0 {
+					return nil, fmt.Errorf("xlsx: sheet has more than %d columns", maxColumns)
+				}
+				return nil, fmt.Errorf("xlsx: cell %s lies outside the columns of the first row", columnName(column)+strconv.Itoa(index+1))
+			}
+
+			var value string
+			switch cell.T {
+			case "s":
+				i, err := strconv.Atoi(cell.V)
+				if err != nil || i < 0 || i >= len(shared) {
+					return nil, fmt.Errorf("xlsx: cell %s refers to a missing string", cell.R)
+				}
+				value = shared[i]
+			case "inlineStr":
+				value = cell.Inline.String()
+			case "str", "b", "e":
+				value = cell.V
+			default:
+				value = number(cell.V)
+			}
+
+			for len(cells) < column {
+				cells = append(cells, "")
+			}
+			cells = append(cells, value)
+		}
+		rows[index] = cells
+		if index == 0 {
+			width = len(cells)
+		}
+	}
+	return rows, nil
+}
+
+// firstSheet returns the path of the workbook's first sheet.
+func firstSheet(files map[string]*zip.File) (string, error) {
+	file, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", errors.New("xlsx: workbook is missing")
+	}
+	var wb struct {
+		Sheets []struct {
+			ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := decode(file, &wb); err != nil {
+		return "", err
+	}
+	if len(wb.Sheets) == 0 {
+		return "", errors.New("xlsx: workbook has no sheets")
+	}
+
+	if file, ok := files["xl/_rels/workbook.xml.rels"]; ok {
+		var rels struct {
+			Relationships []struct {
+				ID     string `xml:"Id,attr"`
+				Target string `xml:"Target,attr"`
+			} `xml:"Relationship"`
+		}
+		if err := decode(file, &rels); err != nil {
+			return "", err
+		}
+		for _, rel := range rels.Relationships {
+			if rel.ID == wb.Sheets[0].ID {
+				if strings.HasPrefix(rel.Target, "/") {
+					return strings.TrimPrefix(rel.Target, "/"), nil
+				}
+				return path.Join("xl", rel.Target), nil
+			}
+		}
+	}
+	return "xl/worksheets/sheet1.xml", nil
+}
+
+// richText is a string that may be split into formatted runs.
+type richText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t richText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.T)
+	}
+	return b.String()
+}
+
+func decode(file *zip.File, v any) error {
+	if file.UncompressedSize64 > maxPartSize {
+		return fmt.Errorf("xlsx: %s is larger than %d bytes", file.Name, maxPartSize)
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	// The size in the zip header is the sender's word; the limit holds
+	// whatever the data unpacks to
+	if err := xml.NewDecoder(io.LimitReader(rc, maxPartSize)).Decode(v); err != nil {
+		return fmt.Errorf("xlsx: reading %s: %w", file.Name, err)
+	}
+	return nil
+}
+
+// number undoes the exponent spreadsheet programs store long whole numbers
+// with, e.g. 8.99123456789E+12 for a barcode typed into a numeric cell.
+func number(v string) string {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || !strings.ContainsAny(v, "eE") {
+		return v
+	}
+	if f == math.Trunc(f) && math.Abs(f) < 1e15 {
+		return strconv.FormatFloat(f, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// columnName turns a zero-based column index into A, B, ..., Z, AA, ...
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// columnIndex returns the zero-based column of a cell reference like C12.
+func columnIndex(ref string) (int, error) {
+	column := 0
+	for _, r := range ref {
+		if r >= 'A' && r <= 'Z' {
+			column = column*26 + int(r-'A') + 1
+		} else {
+			break
+		}
+	}
+	if column == 0 {
+		return 0, fmt.Errorf("xlsx: invalid cell reference %q", ref)
+	}
+	return column - 1, nil
+}
+
+func writeFile(zw *zip.Writer, name, body string) error {
+	fw, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(fw, body)
+	return err
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+const contentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`</Types>`
+
+const rootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const workbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>` +
+	`</workbook>`
+
+const workbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`</Relationships>`
@@ -0,0 +1,202 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	rows := [][]any{
+		{"code", "name", "price", "stock", "is_active", "barcode"},
+		{"0001", "Kopi <Susu> & Gula", 18500.5, 12, true, "8991234567890"},
+		{"0002", "", int64(-3), uint64(7), false, nil},
+		{},
+		{"0004", "  spaced  "},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, "Products & more", rows); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()), 100, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{
+		{"code", "name", "price", "stock", "is_active", "barcode"},
+		{"0001", "Kopi <Susu> & Gula", "18500.5", "12", "1", "8991234567890"},
+		{"0002", "", "-3", "7", "0"},
+		nil,
+		{"0004", "  spaced  "},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Read = %q, want %q", got, want)
+	}
+}
+
+func TestReadNumbers(t *testing.T) {
+	tests := []struct {
+		v    string
+		want string
+	}{
+		{"8.99123456789E+12", "8991234567890"},
+		{"8.99123456789e12", "8991234567890"},
+		{"1.5E-3", "0.0015"},
+		{"1E+16", "10000000000000000"},
+		{"12.50", "12.50"},
+		{"42", "42"},
+		{"abc", "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.v, func(t *testing.T) {
+			data := workbookWith(t, `<row r="1"><c r="A1"><v>`+tt.v+`</v></c></row>`)
+			rows, err := Read(bytes.NewReader(data), int64(len(data)), 10, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := rows[0][0]; got != tt.want {
+				t.Errorf("cell = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadCells(t *testing.T) {
+	tests := []struct {
+		name    string
+		sheet   string
+		want    [][]string
+		wantErr string
+	}{
+		{
+			name:  "gaps are left empty",
+			sheet: `<row r="1"><c r="A1" t="str"><v>a</v></c><c r="C1" t="str"><v>c</v></c></row><row r="3"><c r="B3" t="b"><v>1</v></c></row>`,
+			want:  [][]string{{"a", "", "c"}, nil, {"", "1"}},
+		},
+		{
+			name:  "rich inline text",
+			sheet: `<row r="1"><c r="A1" t="inlineStr"><is><r><t>Es </t></r><r><t>Teh</t></r></is></c></row>`,
+			want:  [][]string{{"Es Teh"}},
+		},
+		{
+			name:    "missing shared string",
+			sheet:   `<row r="1"><c r="A1" t="s"><v>0</v></c></row>`,
+			wantErr: "missing string",
+		},
+		{
+			name:    "rows past the limit",
+			sheet:   `<row r="1"><c r="A1"><v>1</v></c></row><row r="1048576"><c r="A1048576"><v>1</v></c></row>`,
+			wantErr: "more than 5 rows",
+		},
+		{
+			name:    "first row too wide",
+			sheet:   `<row r="1"><c r="XFD1"><v>1</v></c></row>`,
+			wantErr: "more than 4 columns",
+		},
+		{
+			name:    "cell outside the first row",
+			sheet:   `<row r="1"><c r="A1"><v>1</v></c><c r="B1"><v>1</v></c></row><row r="2"><c r="C2"><v>1</v></c></row>`,
+			wantErr: "cell C2 lies outside",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := workbookWith(t, tt.sheet)
+			rows, err := Read(bytes.NewReader(data), int64(len(data)), 5, 4)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Read error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(rows, tt.want) {
+				t.Errorf("Read = %q, want %q", rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadPartTooLarge(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	writeTestParts(t, zw)
+	fw, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.CopyN(fw, spaces{}, maxPartSize+1); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()), 10, 10); err == nil || !strings.Contains(err.Error(), "larger than") {
+		t.Fatalf("Read error = %v, want a size error", err)
+	}
+}
+
+func TestColumns(t *testing.T) {
+	for i, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(i); got != name {
+			t.Errorf("columnName(%d) = %s, want %s", i, got, name)
+		}
+		if got, err := columnIndex(fmt.Sprintf("%s12", name)); err != nil || got != i {
+			t.Errorf("columnIndex(%s12) = %d, %v, want %d", name, got, err, i)
+		}
+	}
+	if _, err := columnIndex("12"); err == nil {
+		t.Error("columnIndex(12) want error")
+	}
+}
+
+// workbookWith returns a workbook whose only sheet holds the given rows.
+func workbookWith(t *testing.T, rows string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	writeTestParts(t, zw)
+	sheet := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + rows + `</sheetData></worksheet>`
+	if err := writeFile(zw, "xl/worksheets/sheet1.xml", sheet); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func writeTestParts(t *testing.T, zw *zip.Writer) {
+	t.Helper()
+	for name, body := range map[string]string{
+		"[Content_Types].xml":        contentTypes,
+		"_rels/.rels":                rootRels,
+		"xl/workbook.xml":            fmt.Sprintf(workbook, "Sheet1"),
+		"xl/_rels/workbook.xml.rels": workbookRels,
+	} {
+		if err := writeFile(zw, name, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// spaces is an endless run of blanks, which compresses to almost nothing.
+type spaces struct{}
+
+func (spaces) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = ' '
+	}
+	return len(p), nil
+}